@@ -0,0 +1,305 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+	"gopkg.in/yaml.v3"
+)
+
+// daemonConfig is the -config file for the daemon command. Each setting
+// mirrors a daemon flag and every field is optional:
+//
+//	screens: [Logo, Clock, CPU, Memory, Gateways, WAN Traffic]
+//	rotate_interval: 15s
+//	screensaver: 10m
+//	menu: true
+//	power: true
+//	services: [unbound, dpinger, openvpn, sshd]
+//	public_ip: true
+//	public_ip_url: https://api.ipify.org
+//	bit_rates: true
+//	rate_smoothing: 0.5
+//	backlight:
+//	  day_level: 200
+//	  night_level: 20
+//	  night_start: "22:00"
+//	  night_end: "07:00"
+//	leds:
+//	  cpu_warn: 70
+//	  cpu_critical: 90
+//	  mem_warn: 80
+//	  mem_critical: 90
+//	  temp_critical: 80
+//	  states_warn: 80
+//	  states_critical: 95
+//	interfaces:
+//	  - {category: wan, description: "^(WAN|ISP)"}
+//	  - {category: tunnel, name_prefix: ovpn}
+//	  - {category: lan, description: .}
+//	service_icons: {running: arrow-up, stopped: x}
+//	splash:
+//	  title: pfSense
+//	  subtitle: Starting...
+//	  logo: /usr/local/share/eziolcd/logo.png
+//
+// The interfaces rules, service icons and splash have no flag. When given the rules
+// replace the default grouping of the traffic screens; each matches a name
+// or description by prefix or regular expression, and the first match wins.
+// Service icons are named as in ui.GetIcon. The splash is shown until the
+// first metrics arrive; its logo is a PNG no larger than the display, lit
+// where it is light.
+type daemonConfig struct {
+	Screens        []string       `yaml:"screens"`
+	RotateInterval *time.Duration `yaml:"rotate_interval"`
+	Screensaver    *time.Duration `yaml:"screensaver"`
+	Menu           *bool          `yaml:"menu"`
+	Power          *bool          `yaml:"power"`
+	Services       []string       `yaml:"services"`
+	PublicIP       *bool          `yaml:"public_ip"`
+	PublicIPURL    string         `yaml:"public_ip_url"`
+	BitRates       *bool          `yaml:"bit_rates"`
+	RateSmoothing  *float64       `yaml:"rate_smoothing"`
+	Backlight      struct {
+		DayLevel   *int   `yaml:"day_level"`
+		NightLevel *int   `yaml:"night_level"`
+		NightStart string `yaml:"night_start"`
+		NightEnd   string `yaml:"night_end"`
+	} `yaml:"backlight"`
+	LEDs struct {
+		CPUWarn        *float64 `yaml:"cpu_warn"`
+		CPUCritical    *float64 `yaml:"cpu_critical"`
+		MemWarn        *float64 `yaml:"mem_warn"`
+		MemCritical    *float64 `yaml:"mem_critical"`
+		TempCritical   *float64 `yaml:"temp_critical"`
+		StatesWarn     *float64 `yaml:"states_warn"`
+		StatesCritical *float64 `yaml:"states_critical"`
+	} `yaml:"leds"`
+	Interfaces   []interfaceRuleConfig `yaml:"interfaces"`
+	ServiceIcons struct {
+		Running string `yaml:"running"`
+		Stopped string `yaml:"stopped"`
+	} `yaml:"service_icons"`
+	Splash splashConfig `yaml:"splash"`
+}
+
+// splashConfig is the startup screen shown by cmdDaemon.
+type splashConfig struct {
+	Title    string `yaml:"title"`
+	Subtitle string `yaml:"subtitle"`
+	Logo     string `yaml:"logo"` // Image path; empty shows only the text
+}
+
+// defaultSplash is shown when the config doesn't set a splash.
+var defaultSplash = splashConfig{Title: "pfSense", Subtitle: "Starting..."}
+
+// withDefaults fills in an empty title and subtitle.
+func (s splashConfig) withDefaults() splashConfig {
+	if s.Title == "" {
+		s.Title = defaultSplash.Title
+	}
+	if s.Subtitle == "" {
+		s.Subtitle = defaultSplash.Subtitle
+	}
+	return s
+}
+
+// loadLogo reads the splash logo, or returns nil if there is none.
+func (s splashConfig) loadLogo() (*ui.Bitmap, error) {
+	if s.Logo == "" {
+		return nil, nil
+	}
+	f, err := os.Open(s.Logo)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported or invalid image %s: %w", s.Logo, err)
+	}
+	if b := img.Bounds(); b.Dx() > eziog500.Width || b.Dy() > eziog500.Height {
+		return nil, fmt.Errorf("logo %s is %dx%d, larger than the %dx%d display", s.Logo, b.Dx(), b.Dy(), eziog500.Width, eziog500.Height)
+	}
+	return ui.BitmapFromImage(img, eziog500.DefaultThreshold), nil
+}
+
+// interfaceRuleConfig is one interfaces entry. Exactly one of the match
+// fields is set.
+type interfaceRuleConfig struct {
+	Category          string `yaml:"category"` // wan, tunnel, lan or none
+	Name              string `yaml:"name"`     // Regular expressions
+	Description       string `yaml:"description"`
+	NamePrefix        string `yaml:"name_prefix"`
+	DescriptionPrefix string `yaml:"description_prefix"`
+}
+
+// loadDaemonConfig reads a daemon config file. Unknown keys are rejected so
+// typos don't silently fall back to defaults.
+func loadDaemonConfig(path string) (*daemonConfig, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var cfg daemonConfig
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// flagValues returns the config's settings keyed by daemon flag name.
+func (c *daemonConfig) flagValues() map[string]string {
+	v := make(map[string]string)
+	if len(c.Screens) > 0 {
+		v["screens"] = strings.Join(c.Screens, ",")
+	}
+	if len(c.Services) > 0 {
+		v["services"] = strings.Join(c.Services, ",")
+	}
+	if c.PublicIP != nil {
+		v["public-ip"] = strconv.FormatBool(*c.PublicIP)
+	}
+	if c.PublicIPURL != "" {
+		v["public-ip-url"] = c.PublicIPURL
+	}
+	if c.BitRates != nil {
+		v["bit-rates"] = strconv.FormatBool(*c.BitRates)
+	}
+	if c.RateSmoothing != nil {
+		v["rate-smoothing"] = strconv.FormatFloat(*c.RateSmoothing, 'g', -1, 64)
+	}
+	if c.RotateInterval != nil {
+		v["rotate-interval"] = c.RotateInterval.String()
+	}
+	if c.Screensaver != nil {
+		v["screensaver"] = c.Screensaver.String()
+	}
+	if c.Menu != nil {
+		v["menu"] = strconv.FormatBool(*c.Menu)
+	}
+	if c.Power != nil {
+		v["power"] = strconv.FormatBool(*c.Power)
+	}
+	if c.Backlight.DayLevel != nil {
+		v["day-level"] = strconv.Itoa(*c.Backlight.DayLevel)
+	}
+	if c.Backlight.NightLevel != nil {
+		v["night-level"] = strconv.Itoa(*c.Backlight.NightLevel)
+	}
+	if c.Backlight.NightStart != "" {
+		v["night-start"] = c.Backlight.NightStart
+	}
+	if c.Backlight.NightEnd != "" {
+		v["night-end"] = c.Backlight.NightEnd
+	}
+	floats := map[string]*float64{
+		"cpu-warn":       c.LEDs.CPUWarn,
+		"cpu-crit":       c.LEDs.CPUCritical,
+		"mem-warn":       c.LEDs.MemWarn,
+		"mem-crit":       c.LEDs.MemCritical,
+		"temp-threshold": c.LEDs.TempCritical,
+		"states-warn":    c.LEDs.StatesWarn,
+		"states-crit":    c.LEDs.StatesCritical,
+	}
+	for name, f := range floats {
+		if f != nil {
+			v[name] = strconv.FormatFloat(*f, 'g', -1, 64)
+		}
+	}
+	return v
+}
+
+// interfaceRules converts the interfaces entries, or returns nil if there
+// are none so the defaults stay in place.
+func (c *daemonConfig) interfaceRules() ([]pfsense.InterfaceRule, error) {
+	var rules []pfsense.InterfaceRule
+	for i, rc := range c.Interfaces {
+		rule := pfsense.InterfaceRule{Category: pfsense.InterfaceCategory(rc.Category)}
+		switch rule.Category {
+		case pfsense.CategoryWAN, pfsense.CategoryTunnel, pfsense.CategoryLAN:
+		case "none":
+			rule.Category = pfsense.CategoryNone
+		default:
+			return nil, fmt.Errorf("config: interfaces[%d]: unknown category %q", i, rc.Category)
+		}
+
+		var pattern string
+		set := 0
+		for _, m := range []struct {
+			value  string
+			field  pfsense.InterfaceField
+			prefix bool
+		}{
+			{rc.Name, pfsense.MatchName, false},
+			{rc.Description, pfsense.MatchDescription, false},
+			{rc.NamePrefix, pfsense.MatchName, true},
+			{rc.DescriptionPrefix, pfsense.MatchDescription, true},
+		} {
+			if m.value == "" {
+				continue
+			}
+			set++
+			rule.Field = m.field
+			if m.prefix {
+				rule.Prefix = m.value
+			} else {
+				pattern = m.value
+			}
+		}
+		if set != 1 {
+			return nil, fmt.Errorf("config: interfaces[%d]: need exactly one of name, description, name_prefix or description_prefix", i)
+		}
+		if pattern != "" {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("config: interfaces[%d]: %w", i, err)
+			}
+			rule.Regexp = re
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// applyDaemonConfig sets each flag from the config file unless it was given
+// explicitly on the command line, so flags override the file.
+func applyDaemonConfig(fs *flag.FlagSet, cfg *daemonConfig) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for name, value := range cfg.flagValues() {
+		if explicit[name] {
+			continue
+		}
+		if err := fs.Set(name, value); err != nil {
+			return fmt.Errorf("config: invalid %s %q: %w", name, value, err)
+		}
+	}
+	return nil
+}
+
+// splitScreens parses a comma-separated name list such as -screens.
+func splitScreens(s string) []string {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
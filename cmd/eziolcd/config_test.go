@@ -0,0 +1,184 @@
+package main
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "daemon.yml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// newTestDaemon returns a status daemon drawing to a temporary file.
+func newTestDaemon(t *testing.T, rotate time.Duration) *pfsense.StatusDaemon {
+	t.Helper()
+	d, _ := testutil.NewDisplay(t)
+	return pfsense.NewStatusDaemon(d, time.Second, rotate)
+}
+
+func TestDaemonConfig_ScreenResolution(t *testing.T) {
+	path := writeConfig(t, `
+screens: [Logo, clock, Gateways, WAN Traffic]
+rotate_interval: 15s
+backlight:
+  night_start: "22:30"
+leds:
+  cpu_critical: 95
+  mem_warn: 60
+`)
+	cfg, err := loadDaemonConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	screens := fs.String("screens", "", "")
+	rotate := fs.Duration("rotate-interval", 10*time.Second, "")
+	nightStart := fs.String("night-start", "", "")
+	cpuCrit := fs.Float64("cpu-crit", 90, "")
+	memWarn := fs.Float64("mem-warn", 80, "")
+	// Given on the command line, so it wins over the file
+	if err := fs.Parse([]string{"-mem-warn", "75"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyDaemonConfig(fs, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if *rotate != 15*time.Second || *nightStart != "22:30" || *cpuCrit != 95 {
+		t.Errorf("rotate=%s night-start=%q cpu-crit=%v, want 15s 22:30 95", *rotate, *nightStart, *cpuCrit)
+	}
+	if *memWarn != 75 {
+		t.Errorf("mem-warn = %v, want the command-line 75", *memWarn)
+	}
+
+	// The resolved names build the daemon's screen list in order
+	daemon := newTestDaemon(t, *rotate)
+	if err := daemon.SetScreens(splitScreens(*screens)); err != nil {
+		t.Fatalf("SetScreens(%q): %v", *screens, err)
+	}
+}
+
+func TestDaemonConfig_Errors(t *testing.T) {
+	if _, err := loadDaemonConfig(writeConfig(t, "screen: [CPU]\n")); err == nil {
+		t.Error("Expected an unknown key to be rejected")
+	}
+
+	cfg, err := loadDaemonConfig(writeConfig(t, "screens: [CPU, Weather]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("daemon", flag.ContinueOnError)
+	screens := fs.String("screens", "", "")
+	if err := applyDaemonConfig(fs, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	err = newTestDaemon(t, time.Second).SetScreens(splitScreens(*screens))
+	if err == nil || !strings.Contains(err.Error(), `"Weather"`) {
+		t.Errorf("Expected an unknown screen error naming Weather, got %v", err)
+	}
+}
+
+func TestDaemonConfig_InterfaceRules(t *testing.T) {
+	cfg, err := loadDaemonConfig(writeConfig(t, `
+interfaces:
+  - {category: wan, description: "^(WAN|ISP)"}
+  - {category: tunnel, name_prefix: ovpn}
+  - {category: none, description_prefix: MGMT}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := cfg.interfaceRules()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := pfsense.NewInterfaceClassifier(rules...)
+	for iface, want := range map[pfsense.InterfaceMetrics]pfsense.InterfaceCategory{
+		{Name: "igb0", Description: "ISP_B"}:  pfsense.CategoryWAN,
+		{Name: "ovpnc1", Description: "VPN"}:  pfsense.CategoryTunnel,
+		{Name: "igb1", Description: "MGMT"}:   pfsense.CategoryNone,
+		{Name: "igb2", Description: "OFFICE"}: pfsense.CategoryNone,
+	} {
+		if got := c.Classify(iface); got != want {
+			t.Errorf("Classify(%s) = %q, want %q", iface.Name, got, want)
+		}
+	}
+
+	// No interfaces section keeps the defaults
+	if rules, err := (&daemonConfig{}).interfaceRules(); err != nil || rules != nil {
+		t.Errorf("interfaceRules() = %v, %v; want nil", rules, err)
+	}
+
+	for _, bad := range []string{
+		"interfaces: [{category: dmz, name: igb}]\n",
+		"interfaces: [{category: lan}]\n",
+		"interfaces: [{category: lan, name: igb, name_prefix: igb}]\n",
+		"interfaces: [{category: lan, name: \"(\"}]\n",
+	} {
+		cfg, err := loadDaemonConfig(writeConfig(t, bad))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := cfg.interfaceRules(); err == nil {
+			t.Errorf("Expected an error for %q", bad)
+		}
+	}
+}
+
+func TestDaemonConfig_Splash(t *testing.T) {
+	logoPath := filepath.Join(t.TempDir(), "logo.png")
+	img := image.NewGray(image.Rect(0, 0, 16, 8))
+	img.SetGray(3, 2, color.Gray{Y: 255})
+	f, err := os.Create(logoPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cfg, err := loadDaemonConfig(writeConfig(t, "splash: {title: Branch, logo: "+logoPath+"}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	splash := cfg.Splash.withDefaults()
+	if splash.Title != "Branch" || splash.Subtitle != defaultSplash.Subtitle {
+		t.Errorf("Got splash %+v, want the title set and the default subtitle", splash)
+	}
+	logo, err := splash.loadLogo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logo.Width() != 16 || logo.Height() != 8 || !logo.Get(3, 2) || logo.Get(0, 0) {
+		t.Error("Expected the logo's size and lit pixels to match the image")
+	}
+
+	big := image.NewGray(image.Rect(0, 0, eziog500.Width+1, 8))
+	if f, err = os.Create(logoPath); err != nil {
+		t.Fatal(err)
+	}
+	png.Encode(f, big)
+	f.Close()
+	if _, err := splash.loadLogo(); err == nil {
+		t.Error("Expected an error for a logo wider than the display")
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/render3d"
+)
+
+// demoPause is how long -auto shows each demo's last frame.
+const demoPause = 2 * time.Second
+
+// demoOptions holds the demo subcommand's flags.
+type demoOptions struct {
+	preview bool   // Draw to the terminal instead of the serial port
+	frames  string // Save frames as PNGs in this directory instead
+	auto    bool   // Move on after pause instead of waiting for Enter
+	pause   time.Duration
+}
+
+// openDemoDisplay opens the panel, or a preview display for -preview or
+// -frames.
+func openDemoDisplay(opts demoOptions) (*display.Display, error) {
+	switch {
+	case opts.preview:
+		return display.NewPreview(os.Stdout), nil
+	case opts.frames != "":
+		if err := os.MkdirAll(opts.frames, 0o755); err != nil {
+			return nil, err
+		}
+		return display.NewPreviewFunc(pngFrames(opts.frames)), nil
+	}
+	return openDisplay()
+}
+
+// pngFrames returns a preview function that saves each frame in dir as
+// frame-0001.png, frame-0002.png, and so on.
+func pngFrames(dir string) func(*eziog500.FrameBuffer) error {
+	n := 0
+	return func(fb *eziog500.FrameBuffer) error {
+		n++
+		f, err := os.Create(filepath.Join(dir, fmt.Sprintf("frame-%04d.png", n)))
+		if err != nil {
+			return err
+		}
+		if err := png.Encode(f, fb.ToImage()); err != nil {
+			f.Close()
+			return err
+		}
+		return f.Close()
+	}
+}
+
+func cmdDemo(opts demoOptions) error {
+	disp, err := openDemoDisplay(opts)
+	if err != nil {
+		return err
+	}
+	defer disp.Close()
+
+	reader := bufio.NewReader(os.Stdin)
+	next := func() {
+		if opts.auto {
+			time.Sleep(opts.pause)
+			return
+		}
+		fmt.Println("Press Enter for next demo...")
+		reader.ReadString('\n')
+	}
+
+	// Demo 1: Graphics text display
+	fmt.Println("\n=== Demo 1: Graphics Text ===")
+	fmt.Println("This uses our custom bitmap font in graphics mode")
+	disp.Clear()
+	disp.DrawRect(0, 0, 128, 64)
+	disp.Print(5, 5, "EZIO-G500")
+	disp.Print(5, 25, "Go Library")
+	disp.Print(5, 45, "Demo Mode")
+	if err := disp.Update(); err != nil {
+		return err
+	}
+	next()
+
+	// Demo 2: Drawing primitives
+	fmt.Println("\n=== Demo 2: Drawing Primitives ===")
+	fmt.Println("Rectangle, diagonal lines, and text")
+	disp.Clear()
+	disp.DrawRect(0, 0, 128, 64)
+	disp.DrawLine(0, 0, 127, 63)
+	disp.DrawLine(127, 0, 0, 63)
+	disp.Print(40, 28, "GRAPHICS")
+	if err := disp.Update(); err != nil {
+		return err
+	}
+	next()
+
+	// Demo 3: Progress bar
+	fmt.Println("\n=== Demo 3: Progress Bar ===")
+	fmt.Println("Animated loading bar")
+	err = display.Animate(context.Background(), 10, disp, func(f int) error {
+		pct := float64(f) * 5
+		disp.Clear()
+		disp.DrawRect(0, 0, 128, 64)
+		bar := &display.ProgressBar{X: 10, Y: 25, Width: 108, Height: 12}
+		bar.RenderLabeled(disp, pct, "LOADING...")
+		if pct >= 100 {
+			return display.ErrStopAnimation
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	next()
+
+	// Demo 4: 3D Rotating Cube
+	fmt.Println("\n=== Demo 4: 3D Rotating Cube ===")
+	fmt.Println("Wireframe cube rotating in 3D space")
+	cube := render3d.NewCube(1.5)
+	cam := render3d.DefaultCamera()
+	err = display.Animate(context.Background(), 20, disp, func(frame int) error {
+		disp.Clear()
+		// Create a fresh cube and rotate it
+		frameCube := cube.Copy()
+		angle := float64(frame) * 0.1
+		frameCube.Rotate(angle*0.7, angle, angle*0.3)
+		disp.Draw(func(fb *eziog500.FrameBuffer) {
+			frameCube.Draw(fb, cam, true)
+		})
+		if frame == 59 {
+			return display.ErrStopAnimation
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	next()
+
+	// Demo 5: LED cycling, which needs the panel
+	fmt.Println("\n=== Demo 5: LED Cycling ===")
+	if device := disp.Device(); device == nil {
+		fmt.Println("Skipped: there are no LEDs without a display")
+	} else {
+		fmt.Println("Cycling through LED colors")
+		for _, led := range []eziog500.LED{eziog500.LED1, eziog500.LED2, eziog500.LED3} {
+			fmt.Printf("LED %d: Red...", led)
+			device.SetLED(led, eziog500.LEDRed)
+			time.Sleep(500 * time.Millisecond)
+			fmt.Print(" Green...")
+			device.SetLED(led, eziog500.LEDGreen)
+			time.Sleep(500 * time.Millisecond)
+			fmt.Print(" Orange...")
+			device.SetLED(led, eziog500.LEDOrange)
+			time.Sleep(500 * time.Millisecond)
+			fmt.Println(" Off")
+			device.SetLED(led, eziog500.LEDOff)
+			time.Sleep(200 * time.Millisecond)
+		}
+	}
+
+	// Final
+	fmt.Println("\n=== Demo Complete ===")
+	disp.Clear()
+	disp.DrawRect(0, 0, 128, 64)
+	disp.Print(15, 28, "DEMO COMPLETE")
+	return disp.Update()
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCmdDemo_Frames(t *testing.T) {
+	if testing.Short() {
+		t.Skip("the demo's animations take several seconds")
+	}
+	dir := filepath.Join(t.TempDir(), "frames")
+
+	if err := cmdDemo(demoOptions{frames: dir, auto: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two still demos, 21 progress bar frames, 60 cube frames and the
+	// final screen
+	frames, err := filepath.Glob(filepath.Join(dir, "frame-*.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(frames) != 84 {
+		t.Fatalf("Got %d frames, want 84", len(frames))
+	}
+
+	f, err := os.Open(filepath.Join(dir, "frame-0084.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 128 || b.Dy() != 64 {
+		t.Errorf("Frame is %dx%d, want 128x64", b.Dx(), b.Dy())
+	}
+}
@@ -9,19 +9,30 @@
 //	text <message>       Display text on the LCD
 //	clear                Clear the display
 //	backlight <0-255>    Set backlight level
+//	contrast <0-255>     Set contrast level
 //	led <1-3> <color>    Set LED color (off, red, green, orange)
-//	status               Show system status (pfSense mode)
+//	image <path>         Display a PNG/BMP/GIF image
+//	qr <text>            Display text as a QR code
+//	marquee <message>    Scroll a message across the display
+//	watch <command>      Show a shell command's output, refreshed
+//	status [-json]       Show system status (pfSense mode)
 //	daemon               Run as a daemon with auto-refresh
 //	menu                 Interactive menu mode
-//	demo                 Run a demo showing various features
+//	demo [-preview]      Run a demo showing various features
 package main
 
 import (
-	"bufio"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/png"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
 	"strconv"
 	"strings"
 	"syscall"
@@ -31,13 +42,18 @@ import (
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 	"github.com/sagostin/ezio-g500/pkg/menu"
 	"github.com/sagostin/ezio-g500/pkg/pfsense"
-	"github.com/sagostin/ezio-g500/pkg/render3d"
+	"github.com/sagostin/ezio-g500/pkg/render/dither"
+	"github.com/sagostin/ezio-g500/pkg/render/qr"
+	_ "golang.org/x/image/bmp"
 )
 
 var (
 	portPath    = flag.String("port", "/dev/ttyS1", "Serial port path")
 	refreshRate = flag.Duration("refresh", 5*time.Second, "Refresh rate for daemon mode")
 	verbose     = flag.Bool("v", false, "Verbose output")
+	rotation    = flag.Int("rotate", 0, "Rotate output by 0 or 180 degrees (for upside-down panels)")
+	resetPanel  = flag.Bool("reset", false, "Reset the panel into graphics mode before drawing (after text-mode tools)")
+	skipSame    = flag.Bool("skip-unchanged", false, "Don't resend frames identical to the last one (less serial traffic)")
 )
 
 func main() {
@@ -47,11 +63,17 @@ func main() {
 		fmt.Fprintln(os.Stderr, "  text <message>       Display text on the LCD")
 		fmt.Fprintln(os.Stderr, "  clear                Clear the display")
 		fmt.Fprintln(os.Stderr, "  backlight <0-255>    Set backlight level")
+		fmt.Fprintln(os.Stderr, "  contrast <0-255>     Set contrast level")
 		fmt.Fprintln(os.Stderr, "  led <1-3> <color>    Set LED color (off, red, green, orange)")
-		fmt.Fprintln(os.Stderr, "  status               Show system status")
+		fmt.Fprintln(os.Stderr, "  image <path>         Display a PNG/BMP/GIF image")
+		fmt.Fprintln(os.Stderr, "  qr <text>            Display text as a QR code")
+		fmt.Fprintln(os.Stderr, "  raw [-stdin] <hex>   Send bytes as given, e.g. ESC 42 80 (protocol debugging)")
+		fmt.Fprintln(os.Stderr, "  marquee <message>    Scroll a message across the display")
+		fmt.Fprintln(os.Stderr, "  watch <command>      Show a shell command's output, refreshed")
+		fmt.Fprintln(os.Stderr, "  status [-json]       Show system status (or print it as JSON)")
 		fmt.Fprintln(os.Stderr, "  daemon               Run as a daemon with auto-refresh")
 		fmt.Fprintln(os.Stderr, "  menu                 Interactive menu mode (use arrow keys)")
-		fmt.Fprintln(os.Stderr, "  demo                 Run a demo showing various features")
+		fmt.Fprintln(os.Stderr, "  demo [-preview]      Run a demo (-preview or -frames dir need no display; -auto)")
 		fmt.Fprintln(os.Stderr, "\nOptions:")
 		flag.PrintDefaults()
 	}
@@ -63,6 +85,11 @@ func main() {
 		eziog500.SetVerbose(true)
 	}
 
+	if *rotation != 0 && *rotation != 180 {
+		fmt.Fprintf(os.Stderr, "Error: -rotate must be 0 or 180, got %d\n", *rotation)
+		os.Exit(1)
+	}
+
 	if flag.NArg() < 1 {
 		flag.Usage()
 		os.Exit(1)
@@ -89,16 +116,39 @@ func main() {
 		}
 
 	case "backlight":
+		fs := flag.NewFlagSet("backlight", flag.ExitOnError)
+		fade := fs.Duration("fade", 0, "Fade to the new level over this duration (e.g. 2s)")
+		from := fs.Int("from", -1, "Starting level for -fade (default: assume full brightness)")
+		fs.Parse(flag.Args()[1:])
+		if fs.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd backlight [-fade 2s] [-from 0-255] <0-255>")
+			os.Exit(1)
+		}
+		level, err := strconv.Atoi(fs.Arg(0))
+		if err != nil || level < 0 || level > 255 {
+			fmt.Fprintln(os.Stderr, "Backlight level must be 0-255")
+			os.Exit(1)
+		}
+		if *from > 255 {
+			fmt.Fprintln(os.Stderr, "Starting level must be 0-255")
+			os.Exit(1)
+		}
+		if err := cmdBacklight(byte(level), *fade, *from); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "contrast":
 		if flag.NArg() < 2 {
-			fmt.Fprintln(os.Stderr, "Usage: eziolcd backlight <0-255>")
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd contrast <0-255>")
 			os.Exit(1)
 		}
 		level, err := strconv.Atoi(flag.Arg(1))
 		if err != nil || level < 0 || level > 255 {
-			fmt.Fprintln(os.Stderr, "Backlight level must be 0-255")
+			fmt.Fprintln(os.Stderr, "Contrast level must be 0-255")
 			os.Exit(1)
 		}
-		if err := cmdBacklight(byte(level)); err != nil {
+		if err := cmdContrast(byte(level)); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -119,14 +169,221 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "image":
+		fs := flag.NewFlagSet("image", flag.ExitOnError)
+		fit := fs.String("fit", "stretch", "How to fit the image: stretch, center, crop")
+		threshold := fs.Int("threshold", eziog500.DefaultThreshold, "Luminance threshold (0-255) for lit pixels")
+		dither := fs.Bool("dither", false, "Use Floyd-Steinberg dithering instead of a hard threshold")
+		fs.Parse(flag.Args()[1:])
+		if fs.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd image [-fit stretch|center|crop] [-threshold 0-255] [-dither] <path>")
+			os.Exit(1)
+		}
+		mode, err := eziog500.ParseFitMode(*fit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if *threshold < 0 || *threshold > 255 {
+			fmt.Fprintln(os.Stderr, "Threshold must be 0-255")
+			os.Exit(1)
+		}
+		if err := cmdImage(fs.Arg(0), mode, uint8(*threshold), *dither); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "raw":
+		fs := flag.NewFlagSet("raw", flag.ExitOnError)
+		stdin := fs.Bool("stdin", false, "Copy bytes from stdin to the display as they arrive")
+		fs.Parse(flag.Args()[1:])
+		if *stdin {
+			if err := cmdRawStream(os.Stdin); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			break
+		}
+		if fs.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd raw <hex bytes, e.g. ESC 42 80> | eziolcd raw -stdin")
+			os.Exit(1)
+		}
+		data, err := parseRawBytes(strings.Join(fs.Args(), " "))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cmdRaw(data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "qr":
+		if flag.NArg() < 2 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd qr <text>")
+			os.Exit(1)
+		}
+		if err := cmdQR(strings.Join(flag.Args()[1:], " ")); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "marquee":
+		fs := flag.NewFlagSet("marquee", flag.ExitOnError)
+		speed := fs.Duration("speed", 150*time.Millisecond, "Time to scroll by one character")
+		fs.Parse(flag.Args()[1:])
+		if fs.NArg() < 1 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd marquee [-speed 150ms] <message>")
+			os.Exit(1)
+		}
+		if *speed <= 0 {
+			fmt.Fprintln(os.Stderr, "Speed must be positive")
+			os.Exit(1)
+		}
+		if err := cmdMarquee(strings.Join(fs.Args(), " "), *speed); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case "watch":
+		command, interval, err := parseWatchArgs(flag.Args()[1:])
+		if err == flag.ErrHelp {
+			os.Exit(0)
+		}
+		if err != nil || len(command) == 0 {
+			fmt.Fprintln(os.Stderr, "Usage: eziolcd watch [-interval 2s] <command>")
+			os.Exit(1)
+		}
+		if interval <= 0 {
+			fmt.Fprintln(os.Stderr, "Interval must be positive")
+			os.Exit(1)
+		}
+		if err := cmdWatch(strings.Join(command, " "), interval); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
 	case "status":
+		fs := flag.NewFlagSet("status", flag.ExitOnError)
+		asJSON := fs.Bool("json", false, "Print the metrics as JSON to stdout instead of using the display")
+		fs.Parse(flag.Args()[1:])
+		if *asJSON {
+			if err := cmdStatusJSON(os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			break
+		}
 		if err := cmdStatus(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 	case "daemon":
-		if err := cmdDaemon(); err != nil {
+		fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+		freezeBlank := fs.Bool("freeze-blank", false, "Debug: freeze and dump to stderr instead of pushing blank frames; a button press resumes")
+		preview := fs.Bool("preview", false, "Draw the screens in this terminal (128+ columns) instead of on the panel")
+		dayLevel := fs.Int("day-level", 200, "Backlight level outside the night window (0-255)")
+		nightLevel := fs.Int("night-level", 20, "Backlight level during the night window (0-255)")
+		nightStart := fs.String("night-start", "", "Start of the night window, HH:MM (empty disables dimming)")
+		nightEnd := fs.String("night-end", "07:00", "End of the night window, HH:MM")
+		policy := pfsense.DefaultLEDPolicy()
+		fs.Float64Var(&policy.CPUWarn, "cpu-warn", policy.CPUWarn, "CPU % above which LED2 turns orange")
+		fs.Float64Var(&policy.CPUCritical, "cpu-crit", policy.CPUCritical, "CPU % above which LED2 turns red")
+		fs.Float64Var(&policy.MemWarn, "mem-warn", policy.MemWarn, "Memory % above which LED2 turns orange")
+		fs.Float64Var(&policy.MemCritical, "mem-crit", policy.MemCritical, "Memory % above which LED2 turns red")
+		fs.Float64Var(&policy.TempCritical, "temp-threshold", policy.TempCritical, "Temperature (°C) above which LED2 turns red")
+		fs.Float64Var(&policy.StatesWarn, "states-warn", policy.StatesWarn, "pf state table % above which LED2 turns orange")
+		fs.Float64Var(&policy.StatesCritical, "states-crit", policy.StatesCritical, "pf state table % above which LED2 turns red")
+		clock12h := fs.Bool("clock-12h", false, "Show 12-hour time on the clock screen")
+		bitRates := fs.Bool("bit-rates", false, "Show traffic rates in bits per second (Kbps, Mbps) instead of bytes")
+		rateSmoothing := fs.Float64("rate-smoothing", 1, "Weight (0-1] of each new traffic rate sample in a moving average; 1 disables smoothing")
+		httpAddr := fs.String("http", "", "Serve metrics over HTTP on this address, e.g. :9000 (empty disables)")
+		webhookURL := fs.String("alert-webhook", "", "POST alerts as JSON to this URL (empty disables)")
+		screens := fs.String("screens", "", "Comma-separated screens to show, in order (empty shows all)")
+		publicIP := fs.Bool("public-ip", false, "Look up the public IP (sent to -public-ip-url) and show it on the WAN screen")
+		publicIPURL := fs.String("public-ip-url", pfsense.DefaultPublicIPURL, "Service that returns the public IP as plain text")
+		services := fs.String("services", strings.Join(pfsense.DefaultServices, ","), "Comma-separated daemons for the Services screen")
+		rotateInterval := fs.Duration("rotate-interval", 10*time.Second, "Time each screen is shown")
+		withMenu := fs.Bool("menu", false, "Open the interactive menu with Enter; Esc at its top returns to the screens")
+		power := fs.Bool("power", false, "With -menu, add a System submenu to reboot or halt the machine, after confirmation")
+		bootState := fs.String("boot-state", defaultBootState(), "File keeping the last boot time, so a reboot is logged once the daemon restarts (empty disables)")
+		screensaver := fs.Duration("screensaver", 0, "Turn the panel off after this long without a button press; any button wakes it (0 disables)")
+		configPath := fs.String("config", "", "YAML file with daemon settings (flags given on the command line override it)")
+		fs.Parse(flag.Args()[1:])
+
+		var ifaceRules []pfsense.InterfaceRule
+		var serviceIcons [2]string
+		splash := defaultSplash
+		if *configPath != "" {
+			cfg, err := loadDaemonConfig(*configPath)
+			if err == nil {
+				err = applyDaemonConfig(fs, cfg)
+			}
+			if err == nil {
+				ifaceRules, err = cfg.interfaceRules()
+				serviceIcons = [2]string{cfg.ServiceIcons.Running, cfg.ServiceIcons.Stopped}
+				splash = cfg.Splash.withDefaults()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if *rotateInterval <= 0 {
+			fmt.Fprintln(os.Stderr, "Rotate interval must be positive")
+			os.Exit(1)
+		}
+		if *screensaver < 0 {
+			fmt.Fprintln(os.Stderr, "Screensaver delay can't be negative")
+			os.Exit(1)
+		}
+		if *screensaver > 0 && *freezeBlank {
+			// The screensaver's blank frame would freeze the display for good
+			fmt.Fprintln(os.Stderr, "-freeze-blank can't be used with -screensaver")
+			os.Exit(1)
+		}
+
+		if err := policy.Validate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		opts := daemonOptions{
+			freezeBlank: *freezeBlank,
+			preview:     *preview,
+			ledPolicy:   policy,
+			clock12h:    *clock12h,
+			bitRates:    *bitRates,
+			smoothing:   *rateSmoothing,
+			httpAddr:    *httpAddr,
+			webhookURL:  *webhookURL,
+			screens:     splitScreens(*screens),
+			services:    splitScreens(*services),
+			rotate:      *rotateInterval,
+			screensaver: *screensaver,
+			menu:        *withMenu,
+			power:       *power,
+			bootState:   *bootState,
+			ifaceRules:  ifaceRules,
+			svcIcons:    serviceIcons,
+			splash:      splash,
+		}
+		if *publicIP {
+			opts.publicIPURL = *publicIPURL
+		}
+		if *nightStart != "" {
+			if *dayLevel < 0 || *dayLevel > 255 || *nightLevel < 0 || *nightLevel > 255 {
+				fmt.Fprintln(os.Stderr, "Backlight levels must be 0-255")
+				os.Exit(1)
+			}
+			var err error
+			opts.schedule, err = pfsense.NewBacklightSchedule(byte(*dayLevel), byte(*nightLevel), *nightStart, *nightEnd)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if err := cmdDaemon(opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -138,13 +395,31 @@ func main() {
 		}
 
 	case "menu":
-		if err := cmdMenu(); err != nil {
+		fs := flag.NewFlagSet("menu", flag.ExitOnError)
+		idle := fs.Duration("idle", 0, "Return to the status screen after this long without input (0, the default, disables)")
+		power := fs.Bool("power", false, "Add a System submenu to reboot or halt the machine, after confirmation")
+		services := fs.String("services", "", "Comma-separated services to offer restarting in a Services submenu (empty hides it)")
+		fs.Parse(flag.Args()[1:])
+		if err := cmdMenu(*idle, *power, splitScreens(*services)); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
 	case "demo":
-		if err := cmdDemo(); err != nil {
+		fs := flag.NewFlagSet("demo", flag.ExitOnError)
+		preview := fs.Bool("preview", false, "Draw to the terminal instead of the serial port")
+		frames := fs.String("frames", "", "Save each frame as a PNG in this directory instead of using the serial port")
+		auto := fs.Bool("auto", false, "Move on to the next demo after a pause instead of waiting for Enter")
+		fs.Parse(flag.Args()[1:])
+		if *preview && *frames != "" {
+			fmt.Fprintln(os.Stderr, "Use only one of -preview and -frames")
+			os.Exit(1)
+		}
+		opts := demoOptions{preview: *preview, frames: *frames, auto: *auto}
+		if *auto {
+			opts.pause = demoPause
+		}
+		if err := cmdDemo(opts); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -156,6 +431,37 @@ func main() {
 	}
 }
 
+// defaultBootState is the -boot-state default: /var/db on FreeBSD, where
+// pfSense keeps daemon state, and off elsewhere, where there is no directory
+// every system is sure to have.
+func defaultBootState() string {
+	if runtime.GOOS == "freebsd" {
+		return "/var/db/eziolcd.boottime"
+	}
+	return ""
+}
+
+// openDisplay opens the display on -port with the -rotate setting applied,
+// resetting the panel first if -reset is set.
+func openDisplay() (*display.Display, error) {
+	disp, err := display.New(*portPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := disp.SetRotation(*rotation); err != nil {
+		disp.Close()
+		return nil, err
+	}
+	if *resetPanel {
+		if err := disp.Reset(); err != nil {
+			disp.Close()
+			return nil, err
+		}
+	}
+	disp.SetSkipUnchanged(*skipSame)
+	return disp, nil
+}
+
 func cmdText(message string) error {
 	// Native text mode - just send raw ASCII directly
 	device, err := eziog500.Open(*portPath)
@@ -196,7 +502,31 @@ func cmdClear() error {
 	return device.Clear()
 }
 
-func cmdBacklight(level byte) error {
+func cmdBacklight(level byte, fade time.Duration, from int) error {
+	device, err := eziog500.Open(*portPath)
+	if err != nil {
+		return err
+	}
+	defer device.Close()
+
+	if err := device.Init(); err != nil {
+		return err
+	}
+
+	if fade <= 0 {
+		return device.SetBacklight(level)
+	}
+
+	disp := display.NewWithDevice(device)
+	if from >= 0 {
+		if err := disp.SetBacklight(byte(from)); err != nil {
+			return err
+		}
+	}
+	return disp.FadeBacklight(level, fade)
+}
+
+func cmdContrast(level byte) error {
 	device, err := eziog500.Open(*portPath)
 	if err != nil {
 		return err
@@ -207,7 +537,7 @@ func cmdBacklight(level byte) error {
 		return err
 	}
 
-	return device.SetBacklight(level)
+	return device.SetContrast(level)
 }
 
 func cmdLED(ledNum int, color string) error {
@@ -248,15 +578,42 @@ func cmdLED(ledNum int, color string) error {
 	return device.SetLED(led, ledColor)
 }
 
+func cmdImage(path string, mode eziog500.FitMode, threshold uint8, useDither bool) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	img, format, err := image.Decode(f)
+	if err != nil {
+		return fmt.Errorf("unsupported or invalid image %s: %w", path, err)
+	}
+	if img.Bounds().Empty() {
+		return fmt.Errorf("image %s is empty", path)
+	}
+	if *verbose {
+		fmt.Printf("Decoded %s image %dx%d, fit=%s\n", format, img.Bounds().Dx(), img.Bounds().Dy(), mode)
+	}
+
+	scaled := eziog500.ScaleImage(img, mode)
+	var fb *eziog500.FrameBuffer
+	if useDither {
+		fb = dither.Dither(scaled)
+	} else {
+		fb = eziog500.FromImage(scaled, threshold)
+	}
+	return showFrame(fb)
+}
+
 func cmdStatus() error {
-	disp, err := display.New(*portPath)
+	disp, err := openDisplay()
 	if err != nil {
 		return err
 	}
 	defer disp.Close()
 
-	metrics := pfsense.NewSystemMetrics()
-	m, err := metrics.GetMetrics()
+	m, err := sampleMetrics()
 	if err != nil {
 		return err
 	}
@@ -273,7 +630,7 @@ func cmdStatus() error {
 	// Get first interface IP
 	if len(m.Interfaces) > 0 {
 		for _, iface := range m.Interfaces {
-			if iface.IP != "" && iface.Status == "up" {
+			if iface.IP != "" && iface.Status == pfsense.InterfaceUp {
 				status.IPAddress = iface.IP
 				break
 			}
@@ -284,27 +641,209 @@ func cmdStatus() error {
 	return template.Render(disp)
 }
 
-func cmdDaemon() error {
-	disp, err := display.New(*portPath)
+// daemonOptions holds the daemon subcommand's flags.
+type daemonOptions struct {
+	freezeBlank bool
+	preview     bool // Draw to the terminal instead of the serial port
+	schedule    *pfsense.BacklightSchedule
+	ledPolicy   pfsense.LEDPolicy
+	clock12h    bool
+	bitRates    bool
+	smoothing   float64 // Traffic rate moving average weight, 1 for none
+	httpAddr    string
+	webhookURL  string
+	screens     []string                // Screen names in order; nil shows all
+	services    []string                // Daemons checked for the Services screen
+	publicIPURL string                  // Public IP lookup service; empty disables
+	rotate      time.Duration           // Time each screen is shown
+	screensaver time.Duration           // Idle time before the panel turns off; 0 disables
+	menu        bool                    // Enter opens the menu
+	power       bool                    // The menu offers reboot and halt
+	bootState   string                  // Last boot time file for reboot detection; empty disables
+	ifaceRules  []pfsense.InterfaceRule // Traffic screen grouping; nil uses the defaults
+	svcIcons    [2]string               // Services screen running and stopped icon names; empty for the defaults
+	splash      splashConfig            // Shown until the first metrics arrive
+}
+
+// cmdQR shows text as a QR code, e.g. a management URL for a phone to scan.
+func cmdQR(text string) error {
+	fb, err := qr.Encode(text)
+	if err != nil {
+		return err
+	}
+	return showFrame(fb)
+}
+
+// showFrame sends a prepared frame through openDisplay, so -rotate, -reset
+// and -skip-unchanged apply to it as to any other frame.
+func showFrame(frame *eziog500.FrameBuffer) error {
+	disp, err := openDisplay()
 	if err != nil {
 		return err
 	}
 	defer disp.Close()
 
+	return disp.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		*fb = *frame
+	})
+}
+
+// statusSampleInterval is how long the one-shot status commands measure CPU
+// usage over.
+const statusSampleInterval = time.Second
+
+// sampleMetrics collects metrics twice, statusSampleInterval apart, since
+// CPU usage is measured between two samples and the first reads as 0.
+func sampleMetrics() (*pfsense.Metrics, error) {
+	metrics := pfsense.NewMetricsProvider()
+	if _, err := metrics.GetMetrics(); err != nil {
+		return nil, err
+	}
+	time.Sleep(statusSampleInterval)
+	return metrics.GetMetrics()
+}
+
+// cmdStatusJSON collects metrics and writes them to w as indented JSON,
+// without opening the display.
+func cmdStatusJSON(w io.Writer) error {
+	m, err := sampleMetrics()
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(m)
+}
+
+func cmdDaemon(opts daemonOptions) error {
+	var disp *display.Display
+	if opts.preview {
+		disp = display.NewPreview(os.Stdout)
+	} else {
+		var err error
+		if disp, err = openDisplay(); err != nil {
+			return err
+		}
+	}
+	defer disp.Close()
+
+	if opts.freezeBlank {
+		disp.SetFreezeOnBlank(os.Stderr)
+	}
+
 	if *verbose {
 		fmt.Printf("Starting status daemon on %s\n", *portPath)
-		fmt.Printf("Update interval: %s, Screen rotation: 10s\n", *refreshRate)
+		fmt.Printf("Update interval: %s, Screen rotation: %s\n", *refreshRate, opts.rotate)
 	}
 
 	// Create status daemon with rotating screens
-	// Updates every refreshRate, rotates screens every 10 seconds
-	daemon := pfsense.NewStatusDaemon(disp, *refreshRate, 10*time.Second)
+	// Updates every refreshRate, rotates screens every opts.rotate
+	daemon := pfsense.NewStatusDaemon(disp, *refreshRate, opts.rotate)
+	if opts.screens != nil {
+		if err := daemon.SetScreens(opts.screens); err != nil {
+			return err
+		}
+	}
+	daemon.SetServices(opts.services)
+	daemon.SetPublicIPURL(opts.publicIPURL)
+	daemon.SetBacklightSchedule(opts.schedule)
+	daemon.SetLEDPolicy(opts.ledPolicy)
+	daemon.SetClock12Hour(opts.clock12h)
+	daemon.SetBitRates(opts.bitRates)
+	if err := daemon.SetRateSmoothing(opts.smoothing); err != nil {
+		return err
+	}
+	daemon.SetInterfaceRules(opts.ifaceRules)
+	if err := daemon.SetServiceIcons(opts.svcIcons[0], opts.svcIcons[1]); err != nil {
+		return fmt.Errorf("service icons: %w", err)
+	}
+	logo, err := opts.splash.loadLogo()
+	if err != nil {
+		return fmt.Errorf("splash: %w", err)
+	}
+	daemon.SetLoadingScreen(&pfsense.LoadingScreen{Title: opts.splash.Title, Subtitle: opts.splash.Subtitle, Logo: logo})
+	daemon.SetOnReboot(func(boot time.Time) {
+		fmt.Fprintf(os.Stderr, "System rebooted at %s\n", boot.Format(time.RFC3339))
+	})
+	daemon.SetOnConnState(func(state eziog500.ConnState) {
+		fmt.Fprintf(os.Stderr, "Display %s\n", state)
+	})
+	if opts.bootState != "" {
+		if err := daemon.SetBootStateFile(opts.bootState); err != nil {
+			// A stale or unreadable file only loses one reboot report
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+		daemon.SetOnBootStateError(func(err error) {
+			fmt.Fprintf(os.Stderr, "Warning: boot state: %v\n", err)
+		})
+	}
+	var buttons *eziog500.ButtonReader
+	if dev := disp.Device(); dev != nil {
+		buttons = eziog500.NewButtonReader(dev, 100*time.Millisecond, eziog500.DefaultDebounce)
+		daemon.SetButtons(buttons)
+	}
+	daemon.EnableScreensaver(opts.screensaver)
+
+	// Stop on SIGINT/SIGTERM so Run returns and the deferred cleanup runs;
+	// with a menu, the app is stopped instead so an open menu closes too
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	stopOnSignal := func(stop func()) {
+		go func() {
+			<-sigChan
+			stop()
+		}()
+	}
+
+	// Notify a webhook when the built-in alert rules fire or clear
+	if opts.webhookURL != "" {
+		alerts := pfsense.NewAlertManager(pfsense.DefaultAlertRules(opts.ledPolicy)...)
+		var stopWebhook func()
+		alerts.OnAlert, alerts.OnClear, stopWebhook = pfsense.WebhookNotifier(opts.webhookURL)
+		defer stopWebhook()
+		daemon.SetAlertManager(alerts)
+		if *verbose {
+			fmt.Printf("Sending alerts to %s\n", opts.webhookURL)
+		}
+	}
 
-	// Run the daemon (blocks forever)
+	// Serve the daemon's cached metrics alongside the display loop
+	if opts.httpAddr != "" {
+		srv := pfsense.NewMetricsServer(opts.httpAddr, daemon)
+		defer srv.Close()
+		go func() {
+			if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				fmt.Fprintf(os.Stderr, "Metrics server error: %v\n", err)
+			}
+		}()
+		if *verbose {
+			fmt.Printf("Serving metrics on %s (/metrics, /metrics.json)\n", opts.httpAddr)
+		}
+	}
+
+	// With a menu, Enter switches between it and the screens
+	if opts.menu && buttons != nil {
+		// The menu reads the daemon's cached metrics and can reset its peaks;
+		// the Services screen's daemons can be restarted from it
+		menuBuilder := menu.NewPfSenseMenuBuilder(disp)
+		menuBuilder.SetMetricsProvider(daemon)
+		menuBuilder.SetPowerActions(opts.power)
+		if len(opts.services) > 0 {
+			menuBuilder.SetServiceControl(&pfsense.CommandServiceController{}, opts.services)
+		}
+		app := menu.NewApp(disp, buttons, daemon, menuBuilder.Build())
+		menuBuilder.SetButtons(app.Menu().Buttons())
+		stopOnSignal(app.Stop)
+		return app.Run()
+	}
+
+	// Run the daemon until a signal stops it
+	stopOnSignal(daemon.Stop)
 	return daemon.Run()
 }
 
-func updateStatus(disp *display.Display, metrics *pfsense.SystemMetrics) error {
+func updateStatus(disp *display.Display, metrics pfsense.MetricsProvider) error {
 	m, err := metrics.GetMetrics()
 	if err != nil {
 		return err
@@ -321,7 +860,7 @@ func updateStatus(disp *display.Display, metrics *pfsense.SystemMetrics) error {
 
 	if len(m.Interfaces) > 0 {
 		for _, iface := range m.Interfaces {
-			if iface.IP != "" && iface.Status == "up" {
+			if iface.IP != "" && iface.Status == pfsense.InterfaceUp {
 				status.IPAddress = iface.IP
 				break
 			}
@@ -332,104 +871,8 @@ func updateStatus(disp *display.Display, metrics *pfsense.SystemMetrics) error {
 	return template.Render(disp)
 }
 
-func cmdDemo() error {
-	disp, err := display.New(*portPath)
-	if err != nil {
-		return err
-	}
-	defer disp.Close()
-
-	reader := bufio.NewReader(os.Stdin)
-
-	// Demo 1: Graphics text display
-	fmt.Println("\n=== Demo 1: Graphics Text ===")
-	fmt.Println("This uses our custom bitmap font in graphics mode")
-	disp.Clear()
-	disp.DrawRect(0, 0, 128, 64)
-	disp.Print(5, 5, "EZIO-G500")
-	disp.Print(5, 25, "Go Library")
-	disp.Print(5, 45, "Demo Mode")
-	disp.Update()
-	fmt.Println("Press Enter for next demo...")
-	reader.ReadString('\n')
-
-	// Demo 2: Drawing primitives
-	fmt.Println("\n=== Demo 2: Drawing Primitives ===")
-	fmt.Println("Rectangle, diagonal lines, and text")
-	disp.Clear()
-	disp.DrawRect(0, 0, 128, 64)
-	disp.DrawLine(0, 0, 127, 63)
-	disp.DrawLine(127, 0, 0, 63)
-	disp.Print(40, 28, "GRAPHICS")
-	disp.Update()
-	fmt.Println("Press Enter for next demo...")
-	reader.ReadString('\n')
-
-	// Demo 3: Progress bar
-	fmt.Println("\n=== Demo 3: Progress Bar ===")
-	fmt.Println("Animated loading bar")
-	for pct := 0.0; pct <= 100.0; pct += 5.0 {
-		disp.Clear()
-		disp.DrawRect(0, 0, 128, 64)
-		disp.Print(35, 8, "LOADING...")
-		bar := &display.ProgressBar{X: 10, Y: 25, Width: 108, Height: 12}
-		bar.Render(disp, pct)
-		disp.Print(52, 45, fmt.Sprintf("%.0f%%", pct))
-		disp.Update()
-		time.Sleep(100 * time.Millisecond)
-	}
-	fmt.Println("Press Enter for next demo...")
-	reader.ReadString('\n')
-
-	// Demo 4: 3D Rotating Cube
-	fmt.Println("\n=== Demo 4: 3D Rotating Cube ===")
-	fmt.Println("Wireframe cube rotating in 3D space")
-	cube := render3d.NewCube(1.5)
-	cam := render3d.DefaultCamera()
-	for frame := 0; frame < 60; frame++ {
-		disp.Clear()
-		// Create a fresh cube and rotate it
-		frameCube := cube.Copy()
-		angle := float64(frame) * 0.1
-		frameCube.Rotate(angle*0.7, angle, angle*0.3)
-		frameCube.Draw(disp.FrameBuffer(), cam, true)
-		disp.Update()
-		time.Sleep(50 * time.Millisecond)
-	}
-	fmt.Println("Press Enter for next demo...")
-	reader.ReadString('\n')
-
-	// Demo 4: LED cycling
-	fmt.Println("\n=== Demo 5: LED Cycling ===")
-	fmt.Println("Cycling through LED colors")
-	device := disp.Device()
-	for _, led := range []eziog500.LED{eziog500.LED1, eziog500.LED2, eziog500.LED3} {
-		fmt.Printf("LED %d: Red...", led)
-		device.SetLED(led, eziog500.LEDRed)
-		time.Sleep(500 * time.Millisecond)
-		fmt.Print(" Green...")
-		device.SetLED(led, eziog500.LEDGreen)
-		time.Sleep(500 * time.Millisecond)
-		fmt.Print(" Orange...")
-		device.SetLED(led, eziog500.LEDOrange)
-		time.Sleep(500 * time.Millisecond)
-		fmt.Println(" Off")
-		device.SetLED(led, eziog500.LEDOff)
-		time.Sleep(200 * time.Millisecond)
-	}
-
-	// Final
-	fmt.Println("\n=== Demo Complete ===")
-	disp.Clear()
-	disp.DrawRect(0, 0, 128, 64)
-	disp.Print(15, 28, "DEMO COMPLETE")
-	disp.Update()
-
-	return nil
-}
-
-func cmdMenu() error {
-	disp, err := display.New(*portPath)
+func cmdMenu(idleTimeout time.Duration, power bool, services []string) error {
+	disp, err := openDisplay()
 	if err != nil {
 		return err
 	}
@@ -450,14 +893,23 @@ func cmdMenu() error {
 	}()
 
 	// Create button reader
-	buttonReader := eziog500.NewButtonReader(disp.Device(), 100*time.Millisecond)
+	buttonReader := eziog500.NewButtonReader(disp.Device(), 100*time.Millisecond, eziog500.DefaultDebounce)
 
 	// Build pfSense menu
 	menuBuilder := menu.NewPfSenseMenuBuilder(disp)
+	menuBuilder.SetPowerActions(power)
+	if len(services) > 0 {
+		menuBuilder.SetServiceControl(&pfsense.CommandServiceController{}, services)
+	}
 	rootMenu := menuBuilder.Build()
 
 	// Create menu controller
 	controller := menu.NewMenuController(disp, buttonReader, rootMenu)
+	menuBuilder.SetButtons(controller.Buttons())
+	controller.SetIdleTimeout(idleTimeout, func() {
+		controller.GoToRoot()
+		menuBuilder.ShowStatus()
+	})
 
 	if *verbose {
 		fmt.Printf("Starting interactive menu on %s\n", *portPath)
@@ -487,8 +939,8 @@ func cmdButtonTest() error {
 	defer session.Close()
 
 	// Create session button reader
-	buttonReader := eziog500.NewSessionButtonReader(session)
-	buttons, stop := buttonReader.ButtonChannel()
+	buttonReader := eziog500.NewSessionButtonReader(session, eziog500.DefaultDebounce)
+	events, stop := buttonReader.EventChannel()
 	defer stop()
 
 	// Set up signal handling
@@ -499,8 +951,8 @@ func cmdButtonTest() error {
 
 	for {
 		select {
-		case btn := <-buttons:
-			fmt.Printf("Button pressed: %s (0x%02X)\n", btn.String(), byte(btn))
+		case ev := <-events:
+			fmt.Printf("Button %s: %s (0x%02X)\n", ev.Kind, ev.Button, byte(ev.Button))
 		case <-sigChan:
 			fmt.Println("\nExiting...")
 			return nil
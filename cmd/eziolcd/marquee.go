@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// marqueeScale is the text scale for marquee; the built-in font is 16px
+// tall at 2x.
+const marqueeScale = 2
+
+// marqueeChars is how many characters a marquee frame draws: enough of the
+// narrowest glyphs to span the display. Anything past the edge is clipped.
+const marqueeChars = eziog500.Width/(3*marqueeScale) + 1
+
+// cmdMarquee scrolls message across the display one character every speed
+// until interrupted, then clears the display.
+func cmdMarquee(message string, speed time.Duration) error {
+	disp, err := openDisplay()
+	if err != nil {
+		return err
+	}
+	defer disp.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(speed)
+	defer ticker.Stop()
+
+	for pos := 0; ; pos++ {
+		drawMarquee(disp.FrameBuffer(), message, pos)
+		if err := disp.Update(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return disp.ClearAndUpdate()
+		case <-ticker.C:
+		}
+	}
+}
+
+// drawMarquee draws frame pos of a marquee. Messages that fit are centred
+// and don't move; longer ones scroll and wrap around seamlessly.
+func drawMarquee(fb *eziog500.FrameBuffer, message string, pos int) {
+	fb.Clear()
+	f := font.BuiltinFont
+	y := (eziog500.Height - f.Height()*marqueeScale) / 2
+
+	width := font.MeasureText(f, message) * marqueeScale
+	if width <= eziog500.Width {
+		font.RenderTextScaled(fb, f, (eziog500.Width-width)/2, y, message, marqueeScale)
+		return
+	}
+
+	// ScrollWindow only scrolls text longer than the window
+	n := min(marqueeChars, len([]rune(message))-1)
+	font.RenderTextScaled(fb, f, 0, y, ui.ScrollWindow(message, n, pos), marqueeScale)
+}
+
+// parseWatchArgs parses the watch command's arguments. Flags come first;
+// the command is everything from the first other argument, or after --,
+// taken verbatim so its own flags are left alone.
+func parseWatchArgs(args []string) (command []string, interval time.Duration, err error) {
+	fs := flag.NewFlagSet("watch", flag.ContinueOnError)
+	fs.DurationVar(&interval, "interval", 2*time.Second, "Time between runs of the command")
+	if err := fs.Parse(args); err != nil {
+		return nil, 0, err
+	}
+	return fs.Args(), interval, nil
+}
+
+// cmdWatch runs command through the shell every interval and shows its
+// output until interrupted, then clears the display.
+func cmdWatch(command string, interval time.Duration) error {
+	disp, err := openDisplay()
+	if err != nil {
+		return err
+	}
+	defer disp.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		output := runWatchCommand(ctx, command)
+		if ctx.Err() != nil {
+			return disp.ClearAndUpdate()
+		}
+		drawWatch(disp.FrameBuffer(), output)
+		if err := disp.Update(); err != nil {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return disp.ClearAndUpdate()
+		case <-ticker.C:
+		}
+	}
+}
+
+// runWatchCommand runs command with sh -c and returns its trimmed stdout.
+// If the command fails, the error is appended so it shows on the display.
+func runWatchCommand(ctx context.Context, command string) string {
+	out, err := exec.CommandContext(ctx, "sh", "-c", command).Output()
+	text := strings.TrimSpace(string(out))
+	if err != nil {
+		if text != "" {
+			text += "\n"
+		}
+		text += "error: " + err.Error()
+	}
+	return text
+}
+
+// drawWatch draws as many lines of output as fit, truncating long ones.
+func drawWatch(fb *eziog500.FrameBuffer, output string) {
+	fb.Clear()
+	f := font.BuiltinFont
+	lines := strings.Split(output, "\n")
+	lines = lines[:min(len(lines), eziog500.Height/f.Height())]
+	for i, line := range lines {
+		line = strings.ReplaceAll(strings.TrimRight(line, " \t\r"), "\t", "  ")
+		font.RenderText(fb, f, 0, i*f.Height(), font.TruncateText(f, line, eziog500.Width))
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestDrawMarquee_Wraps(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	message := "This message is much too long to fit on the display at once"
+
+	drawMarquee(fb, message, 0)
+	first := fb.ToDeviceFormat()
+
+	drawMarquee(fb, message, 1)
+	if fb.ToDeviceFormat() == first {
+		t.Error("Expected the marquee to move between frames")
+	}
+
+	// One full cycle is the message plus the gap before it repeats
+	drawMarquee(fb, message, len(message)+4)
+	if fb.ToDeviceFormat() != first {
+		t.Error("Expected the marquee to wrap back to the start")
+	}
+}
+
+func TestDrawMarquee_ShortMessageStill(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	drawMarquee(fb, "Hi", 0)
+	first := fb.ToDeviceFormat()
+	drawMarquee(fb, "Hi", 5)
+	if fb.ToDeviceFormat() != first {
+		t.Error("Expected a message that fits not to scroll")
+	}
+	if fb.CountSetPixels() == 0 {
+		t.Error("Expected the message to be drawn")
+	}
+}
+
+func TestRunWatchCommand(t *testing.T) {
+	ctx := context.Background()
+	if got := runWatchCommand(ctx, "printf '  one\\ntwo  \\n\\n'"); got != "one\ntwo" {
+		t.Errorf("runWatchCommand = %q, want %q", got, "one\ntwo")
+	}
+	got := runWatchCommand(ctx, "echo partial; exit 3")
+	if !strings.HasPrefix(got, "partial\nerror: ") {
+		t.Errorf("runWatchCommand on failure = %q, want output then error", got)
+	}
+}
+
+func TestParseWatchArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		command  string
+		interval time.Duration
+	}{
+		{[]string{"uptime"}, "uptime", 2 * time.Second},
+		{[]string{"-interval", "5s", "df", "-h"}, "df -h", 5 * time.Second},
+		{[]string{"ls", "-la", "-interval", "1s"}, "ls -la -interval 1s", 2 * time.Second},
+		{[]string{"--", "-weird-name"}, "-weird-name", 2 * time.Second},
+	}
+	for _, tt := range tests {
+		command, interval, err := parseWatchArgs(tt.args)
+		if err != nil {
+			t.Errorf("parseWatchArgs(%q) error = %v", tt.args, err)
+			continue
+		}
+		if got := strings.Join(command, " "); got != tt.command || interval != tt.interval {
+			t.Errorf("parseWatchArgs(%q) = %q, %s, want %q, %s", tt.args, got, interval, tt.command, tt.interval)
+		}
+	}
+}
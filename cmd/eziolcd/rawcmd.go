@@ -1,40 +1,97 @@
 package main
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 )
 
-// cmdRaw sends raw ASCII text directly to the display (like typing in cu)
-// This bypasses all ESC commands and graphics mode
-func cmdRaw(text string) error {
-	// Enable verbose if flag is set
-	if *verbose {
-		eziog500.SetVerbose(true)
-		fmt.Fprintf(os.Stderr, "[RAW] Opening port: %s\n", *portPath)
+// rawNames are the control bytes raw accepts by name. FF (form feed, 0C)
+// is left out as it reads as the hex byte FF.
+var rawNames = map[string]byte{
+	"NUL": 0x00,
+	"LF":  0x0A,
+	"VT":  0x0B, // Home
+	"CR":  0x0D,
+	"ESC": eziog500.ESC,
+}
+
+// parseRawBytes parses raw's arguments: hex bytes separated by spaces or
+// commas, each optionally prefixed with 0x, with runs such as 1B40 read as
+// several bytes, and the names in rawNames in any case. For example
+// "ESC 42 ff" and "0x1b,0x42,0xFF" both give 1B 42 FF.
+func parseRawBytes(s string) ([]byte, error) {
+	var out []byte
+	for _, tok := range strings.FieldsFunc(s, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n'
+	}) {
+		if b, ok := rawNames[strings.ToUpper(tok)]; ok {
+			out = append(out, b)
+			continue
+		}
+		digits := strings.TrimPrefix(strings.TrimPrefix(tok, "0x"), "0X")
+		if len(digits) == 1 {
+			digits = "0" + digits
+		}
+		b, err := hex.DecodeString(digits)
+		if err != nil || digits == "" {
+			return nil, fmt.Errorf("invalid byte %q: want hex such as 1B or 0x1b, or a name (ESC, NUL, LF, CR, VT)", tok)
+		}
+		out = append(out, b...)
 	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no bytes given")
+	}
+	return out, nil
+}
 
-	// Open without any stty configuration to see if that's the issue
-	device, err := eziog500.OpenWithoutStty(*portPath)
+// cmdRaw writes data to the display exactly as given, with none of the
+// usual setup, for exploring the protocol.
+func cmdRaw(data []byte) error {
+	device, err := eziog500.Open(*portPath)
 	if err != nil {
-		return fmt.Errorf("failed to open device: %w", err)
+		return err
 	}
 	defer device.Close()
 
 	if *verbose {
-		fmt.Fprintf(os.Stderr, "[RAW] Sending raw text: %q\n", text)
+		fmt.Fprintf(os.Stderr, "[RAW] Sending % 02X\n", data)
 	}
-
-	// Just write raw bytes directly - exactly like typing in cu
-	if err := device.Write([]byte(text)); err != nil {
-		return fmt.Errorf("failed to write: %w", err)
+	if err := device.Write(data); err != nil {
+		return err
 	}
+	return device.Flush()
+}
 
-	if *verbose {
-		fmt.Fprintf(os.Stderr, "[RAW] Done\n")
+// cmdRawStream copies r to the display until EOF, flushing each read so
+// bytes arrive as they are produced, e.g. piped from xxd -r -p.
+func cmdRawStream(r io.Reader) error {
+	device, err := eziog500.Open(*portPath)
+	if err != nil {
+		return err
 	}
+	defer device.Close()
 
-	return nil
+	buf := make([]byte, 4096)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			if werr := device.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if ferr := device.Flush(); ferr != nil {
+				return ferr
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
 }
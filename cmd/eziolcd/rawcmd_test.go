@@ -0,0 +1,39 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseRawBytes(t *testing.T) {
+	for _, tt := range []struct {
+		in   string
+		want []byte
+	}{
+		{"ESC 42 ff", []byte{0x1B, 0x42, 0xFF}},
+		{"0x1b,0x42,0xFF", []byte{0x1B, 0x42, 0xFF}},
+		{"esc 40 0C", []byte{0x1B, 0x40, 0x0C}},
+		{"1B47", []byte{0x1B, 0x47}},
+		{"ESC, C, 7", []byte{0x1B, 0x0C, 0x07}},
+	} {
+		got, err := parseRawBytes(tt.in)
+		if err != nil {
+			t.Errorf("parseRawBytes(%q): %v", tt.in, err)
+			continue
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("parseRawBytes(%q) = % 02X, want % 02X", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{"", " , ", "ESC zz", "1B4", "0x", "0x1G"} {
+		_, err := parseRawBytes(in)
+		if err == nil {
+			t.Errorf("parseRawBytes(%q): expected an error", in)
+		}
+		if strings.Contains(in, "zz") && (err == nil || !strings.Contains(err.Error(), `"zz"`)) {
+			t.Errorf("Expected the error to name the bad token, got %v", err)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 // Example: pfSense status display daemon
 //
 // This example runs as a daemon, displaying system status with auto-refresh.
+// Left and Right switch screens; rotation pauses for a while after a press.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"os"
@@ -37,17 +39,18 @@ func main() {
 	disp.SetLED(eziog500.LED1, eziog500.LEDGreen)
 	disp.SetBacklight(200)
 
-	metrics := pfsense.NewSystemMetrics()
+	metrics := pfsense.NewMetricsProvider()
 
 	// Handle shutdown gracefully
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
-	// Create a multi-screen display
-	multiScreen := display.NewMultiScreen(*interval)
+	// Create a multi-screen display that rotates every 3 refreshes
+	multiScreen := display.NewMultiScreen(*interval * 3)
+	multiScreen.SetRefreshInterval(*interval)
 
 	// Screen 1: System status
-	multiScreen.AddScreen(func(d *display.Display) error {
+	multiScreen.AddScreen(showErrors(func(d *display.Display) error {
 		m, err := metrics.GetMetrics()
 		if err != nil {
 			return err
@@ -71,10 +74,10 @@ func main() {
 
 		template := status.ToTemplate()
 		return template.Render(d)
-	})
+	}))
 
 	// Screen 2: Network interfaces
-	multiScreen.AddScreen(func(d *display.Display) error {
+	multiScreen.AddScreen(showErrors(func(d *display.Display) error {
 		m, err := metrics.GetMetrics()
 		if err != nil {
 			return err
@@ -96,40 +99,33 @@ func main() {
 		netStatus := &display.NetworkStatus{Interfaces: infos}
 		template := netStatus.ToTemplate()
 		return template.Render(d)
-	})
+	}))
 
-	// Initial render
-	if err := multiScreen.RenderCurrent(disp); err != nil {
-		fmt.Fprintf(os.Stderr, "Render error: %v\n", err)
+	// Left/Right switch screens. Without buttons, multiScreen.Run(ctx, disp)
+	// does the refresh and rotation alone.
+	buttons := eziog500.NewButtonReader(disp.Device(), 100*time.Millisecond, eziog500.DefaultDebounce)
+	controller := display.NewMultiScreenController(disp, multiScreen, buttons)
+	controller.SetTabs("SYSTEM", "NETWORK")
+
+	if err := controller.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Display error: %v\n", err)
 	}
 
-	// Refresh loop
-	ticker := time.NewTicker(*interval)
-	defer ticker.Stop()
-
-	screenTicker := time.NewTicker(*interval * 3) // Rotate screens every 3 intervals
-	defer screenTicker.Stop()
-
-	for {
-		select {
-		case <-sigChan:
-			fmt.Println("\nShutting down...")
-			disp.Clear()
-			disp.PrintLineCentered(3, "SHUTDOWN")
-			disp.Update()
-			disp.SetLED(eziog500.LED1, eziog500.LEDOff)
-			return
-
-		case <-ticker.C:
-			if err := multiScreen.RenderCurrent(disp); err != nil {
-				fmt.Fprintf(os.Stderr, "Render error: %v\n", err)
-				disp.SetLED(eziog500.LED2, eziog500.LEDRed)
-			} else {
-				disp.SetLED(eziog500.LED2, eziog500.LEDOff)
-			}
+	fmt.Println("\nShutting down...")
+	disp.Clear()
+	disp.PrintLineCentered(3, "SHUTDOWN")
+	disp.Update()
+	disp.SetLED(eziog500.LED1, eziog500.LEDOff)
+}
 
-		case <-screenTicker.C:
-			multiScreen.Next()
+// showErrors reports a screen's render errors on LED2 instead of stopping
+// the display.
+func showErrors(screen func(*display.Display) error) func(*display.Display) error {
+	return func(d *display.Display) error {
+		if err := screen(d); err != nil {
+			fmt.Fprintf(os.Stderr, "Render error: %v\n", err)
+			return d.SetLED(eziog500.LED2, eziog500.LEDRed)
 		}
+		return d.SetLED(eziog500.LED2, eziog500.LEDOff)
 	}
 }
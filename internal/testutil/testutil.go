@@ -0,0 +1,47 @@
+// Package testutil has helpers shared by the module's tests.
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// NewDevice returns a device backed by a temporary file instead of a serial
+// port, plus a function that flushes and returns everything written. The
+// device is closed when the test ends.
+func NewDevice(t testing.TB) (*eziog500.Device, func() []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lcd")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dev, err := eziog500.OpenWithoutStty(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev.SetCommandDelay(0)
+	t.Cleanup(func() { dev.Close() })
+
+	written := func() []byte {
+		if err := dev.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	return dev, written
+}
+
+// NewDisplay is NewDevice wrapped in a display.
+func NewDisplay(t testing.TB) (*display.Display, func() []byte) {
+	t.Helper()
+	dev, written := NewDevice(t)
+	return display.NewWithDevice(dev), written
+}
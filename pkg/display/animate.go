@@ -0,0 +1,53 @@
+package display
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrStopAnimation can be returned by an Animate frame function to end the
+// animation after that frame without an error.
+var ErrStopAnimation = errors.New("display: stop animation")
+
+// Animate calls frame with 0, 1, 2, ... at fps frames per second, sending
+// each drawn frame to d with Update, until ctx is cancelled, frame returns
+// ErrStopAnimation, or an error occurs. Frames are scheduled from the start
+// time rather than the previous frame, so slow frames don't make the
+// animation drift; if it falls a whole frame behind, it skips ahead instead
+// of rushing to catch up, and f counts the frames actually drawn.
+func Animate(ctx context.Context, fps int, d *Display, frame func(f int) error) error {
+	if fps <= 0 {
+		return fmt.Errorf("display: animation rate %d fps must be positive", fps)
+	}
+	interval := time.Second / time.Duration(fps)
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	next := time.Now()
+	for f := 0; ; f++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		if err := frame(f); err != nil {
+			if errors.Is(err, ErrStopAnimation) {
+				err = d.Update()
+			}
+			return err
+		}
+		if err := d.Update(); err != nil {
+			return err
+		}
+
+		next = next.Add(interval)
+		now := time.Now()
+		if behind := now.Sub(next); behind > interval {
+			next = next.Add(behind / interval * interval)
+		}
+		timer.Reset(next.Sub(now))
+	}
+}
@@ -0,0 +1,53 @@
+package display
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// DefaultCaretWidth is the caret width used when Caret.Width is 0, about one
+// BuiltinFont character.
+const DefaultCaretWidth = 5
+
+// Caret is a blinking text-entry cursor: an underscore along the bottom of a
+// character cell, or the whole cell inverted. It keeps no time of its own;
+// the caller passes a frame counter, such as the daemon's, and the caret is
+// shown on even phases and hidden on odd ones.
+type Caret struct {
+	X, Y        int  // Top-left of the character cell
+	Width       int  // 0 uses DefaultCaretWidth
+	Height      int  // 0 uses the BuiltinFont height
+	Block       bool // Invert the cell instead of underlining it
+	BlinkFrames int  // Frames per on or off phase; 0 toggles every frame
+}
+
+// Visible reports whether the caret shows on frame.
+func (c *Caret) Visible(frame int) bool {
+	phase := max(1, c.BlinkFrames)
+	return (frame/phase)%2 == 0
+}
+
+// Render draws the caret if it is visible on frame. A block caret inverts
+// the cell so a character under it stays readable.
+func (c *Caret) Render(fb *eziog500.FrameBuffer, frame int) {
+	if !c.Visible(frame) {
+		return
+	}
+	w, h := c.Width, c.Height
+	if w == 0 {
+		w = DefaultCaretWidth
+	}
+	if h == 0 {
+		h = font.BuiltinFont.Height()
+	}
+
+	if !c.Block {
+		fb.DrawHLine(c.X, c.X+w-1, c.Y+h-1, true)
+		return
+	}
+	for y := c.Y; y < c.Y+h; y++ {
+		for x := c.X; x < c.X+w; x++ {
+			fb.Invert(x, y)
+		}
+	}
+}
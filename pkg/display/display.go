@@ -2,15 +2,50 @@
 package display
 
 import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 	"github.com/sagostin/ezio-g500/pkg/font"
 )
 
+// BlankCoverage is the pixel coverage at or below which a frame is
+// considered blank by the freeze-on-blank debug check.
+const BlankCoverage = 0.001
+
+// DefaultBacklight is the backlight level assumed until SetBacklight is
+// called, since the device cannot report its current level.
+const DefaultBacklight = 255
+
+// fadeStepInterval is the minimum time between backlight steps while fading.
+const fadeStepInterval = 20 * time.Millisecond
+
 // Display provides a high-level interface for text and graphics on the LCD.
+//
+// Its methods are safe to call from several goroutines: a mutex serializes
+// drawing, the Push/Pop stack and Update, so a frame is never sent while
+// another goroutine is halfway through drawing with a Display method. Code
+// drawing on FrameBuffer() directly should do so inside Draw or DrawFrame
+// when other goroutines may draw too.
 type Display struct {
-	device *eziog500.Device
-	fb     *eziog500.FrameBuffer
-	font   font.Font
+	mu        sync.Mutex // Guards every field below except device
+	device    *eziog500.Device
+	preview   func(*eziog500.FrameBuffer) error // Receives frames in place of the device
+	pages     map[byte]*eziog500.FrameBuffer    // A preview's saved pages
+	fb        *eziog500.FrameBuffer
+	font      font.Font
+	freezeLog io.Writer // Debug: where to dump blank frames (nil = disabled)
+	frozen    bool
+	backlight byte                    // Last backlight level written (device is write-only)
+	rotation  int                     // Degrees applied on Update: 0 or 180
+	saved     []*eziog500.FrameBuffer // Snapshots from Push, most recent last
+	overlay   func(*eziog500.FrameBuffer)
+	skipSame  bool   // Update skips frames identical to the last one sent
+	lastHash  uint64 // Hash of the last frame sent
+	hashValid bool   // lastHash matches what the panel shows
 }
 
 // New creates a new Display connected to the specified serial port.
@@ -21,9 +56,10 @@ func New(portPath string) (*Display, error) {
 	}
 
 	d := &Display{
-		device: device,
-		fb:     eziog500.NewFrameBuffer(),
-		font:   font.BuiltinFont,
+		device:    device,
+		fb:        eziog500.NewFrameBuffer(),
+		font:      font.BuiltinFont,
+		backlight: DefaultBacklight,
 	}
 
 	// Don't call Init - it interferes with graphics mode
@@ -34,69 +70,343 @@ func New(portPath string) (*Display, error) {
 // NewWithDevice creates a Display using an existing device connection.
 func NewWithDevice(device *eziog500.Device) *Display {
 	return &Display{
-		device: device,
-		fb:     eziog500.NewFrameBuffer(),
-		font:   font.BuiltinFont,
+		device:    device,
+		fb:        eziog500.NewFrameBuffer(),
+		font:      font.BuiltinFont,
+		backlight: DefaultBacklight,
+	}
+}
+
+// NewPreview creates a Display without a panel that draws each updated
+// frame to w as text (see FrameBuffer.String), starting from the top-left
+// of an ANSI terminal so successive frames overwrite each other. The
+// terminal needs to be at least 128 columns wide. Device returns nil, and
+// backlight, contrast and LED calls do nothing.
+func NewPreview(w io.Writer) *Display {
+	first := true
+	return NewPreviewFunc(func(fb *eziog500.FrameBuffer) error {
+		home := "\x1b[H"
+		if first {
+			home, first = "\x1b[2J\x1b[H", false
+		}
+		_, err := io.WriteString(w, home+fb.String())
+		return err
+	})
+}
+
+// NewPreviewFunc creates a Display without a panel that passes each
+// updated frame to fn, with any overlay drawn but not rotated, e.g. to save
+// it as a PNG with FrameBuffer.ToImage. fn must not keep the framebuffer.
+func NewPreviewFunc(fn func(*eziog500.FrameBuffer) error) *Display {
+	return &Display{
+		fb:        eziog500.NewFrameBuffer(),
+		font:      font.BuiltinFont,
+		backlight: DefaultBacklight,
+		preview:   fn,
 	}
 }
 
+// Reset puts the panel back into a known graphics state, e.g. after another
+// tool left it in text mode, where uploads can come out garbled. New doesn't
+// do this itself because Init on its own interferes with graphics mode; the
+// blank upload that follows it here switches the panel back. The bytes sent,
+// in one flush, are:
+//
+//	1B 40          ESC @  Init
+//	0B             Home
+//	0C             Clear
+//	1B 42 nn       ESC B  Backlight, restoring the last level set
+//	1B 47 00...    ESC G  Upload 1024 blank bytes
+//
+// The framebuffer and the Push stack are cleared to match. Reset is safe to
+// repeat.
+func (d *Display) Reset() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.saved = nil
+	d.frozen = false
+	d.fb.ClearClip()
+	d.fb.Clear()
+	d.hashValid = false
+	if d.device == nil {
+		return d.preview(d.fb)
+	}
+
+	for _, step := range []func() error{
+		d.device.Init,
+		d.device.Home,
+		d.device.Clear,
+		func() error { return d.device.SetBacklight(d.backlight) },
+	} {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	// Uploads flush, sending the whole sequence at once
+	return d.device.UploadImage(d.fb.ToDeviceFormat())
+}
+
 // Close closes the display connection.
 func (d *Display) Close() error {
+	if d.device == nil {
+		return nil
+	}
 	return d.device.Close()
 }
 
-// Device returns the underlying device for advanced operations.
+// Device returns the underlying device for advanced operations, or nil for
+// a preview.
 func (d *Display) Device() *eziog500.Device {
 	return d.device
 }
 
-// FrameBuffer returns the underlying framebuffer for advanced drawing.
+// FrameBuffer returns the underlying framebuffer for advanced drawing. It
+// isn't locked; see Draw.
 func (d *Display) FrameBuffer() *eziog500.FrameBuffer {
 	return d.fb
 }
 
+// Push saves a snapshot of the framebuffer so an overlay can be drawn on
+// top and later removed with Pop. Pushes nest.
+func (d *Display) Push() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.saved = append(d.saved, d.fb.Copy())
+}
+
+// Pop restores the framebuffer to the most recent Push. The framebuffer is
+// restored in place, so references from FrameBuffer() stay valid. Call
+// Update afterwards to show the restored frame.
+func (d *Display) Pop() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.saved) == 0 {
+		return fmt.Errorf("display: Pop without matching Push")
+	}
+	last := d.saved[len(d.saved)-1]
+	d.saved = d.saved[:len(d.saved)-1]
+	*d.fb = *last
+	return nil
+}
+
 // SetFont sets the font used for text rendering.
 func (d *Display) SetFont(f font.Font) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.font = f
 }
 
+// Font returns the font used for text rendering, so code drawing on the
+// framebuffer directly can follow SetFont.
+func (d *Display) Font() font.Font {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.font
+}
+
 // Clear clears the framebuffer and optionally updates the display.
 func (d *Display) Clear() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.Clear()
 	return nil
 }
 
+// SetFreezeOnBlank enables a debug check for rendering bugs: when Update is
+// called with a blank frame, the frame is dumped as ASCII to w and the display
+// freezes on the last good frame instead of pushing it. Pass nil to disable.
+func (d *Display) SetFreezeOnBlank(w io.Writer) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.freezeLog = w
+	d.frozen = false
+}
+
+// Frozen reports whether the display was frozen by the blank-frame check.
+func (d *Display) Frozen() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.frozen
+}
+
+// Unfreeze lets Update push frames again after the blank-frame check froze
+// the display. The check stays enabled, so the next blank frame freezes it
+// again.
+func (d *Display) Unfreeze() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.frozen = false
+}
+
+// SetRotation sets how frames are rotated when sent to the display, for
+// panels mounted upside-down. Only 0 and 180 degrees are supported. Drawing
+// coordinates are unaffected; the transform is applied on Update.
+func (d *Display) SetRotation(deg int) error {
+	if deg != 0 && deg != 180 {
+		return fmt.Errorf("unsupported rotation %d (must be 0 or 180)", deg)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rotation = deg
+	return nil
+}
+
+// Rotation returns the rotation set by SetRotation.
+func (d *Display) Rotation() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.rotation
+}
+
+// SetOverlay sets a function that draws over every frame Update sends, such
+// as a tab bar, or removes it if nil. It draws on a copy, so the
+// framebuffer itself is left as the caller drew it.
+func (d *Display) SetOverlay(draw func(*eziog500.FrameBuffer)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.overlay = draw
+}
+
 // Update sends the current framebuffer contents to the display.
 func (d *Display) Update() error {
-	data := d.fb.ToDeviceFormat()
-	return d.device.UploadImage(data)
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.update()
+}
+
+// Draw runs fn with the display locked, for drawing straight onto the
+// framebuffer without interleaving with other goroutines. fn must not call
+// the Display's own methods.
+func (d *Display) Draw(fn func(fb *eziog500.FrameBuffer)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn(d.fb)
+}
+
+// DrawFrame is Draw followed by Update as one step, so no other goroutine
+// can draw between the two.
+func (d *Display) DrawFrame(fn func(fb *eziog500.FrameBuffer)) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	fn(d.fb)
+	return d.update()
+}
+
+// update is Update with d.mu held.
+func (d *Display) update() error {
+	if d.freezeLog != nil {
+		if d.frozen {
+			return nil
+		}
+		if d.fb.Coverage() <= BlankCoverage {
+			d.frozen = true
+			fmt.Fprintf(d.freezeLog, "blank frame detected (%d pixels set), freezing display:\n%s",
+				d.fb.CountSetPixels(), asciiFrame(d.fb))
+			return nil
+		}
+	}
+
+	out := d.fb
+	if d.overlay != nil || d.rotation == 180 {
+		// Work on a copy so callers can keep drawing on their own buffer
+		out = d.fb.Copy()
+	}
+	if d.overlay != nil {
+		out.ClearClip()
+		d.overlay(out)
+	}
+	if d.device == nil {
+		return d.preview(out)
+	}
+	if d.rotation == 180 {
+		out.Rotate180()
+	}
+	if d.skipSame {
+		h := out.Hash()
+		if d.hashValid && h == d.lastHash {
+			return nil
+		}
+		d.lastHash, d.hashValid = h, false
+	}
+	if err := d.device.UploadImage(out.ToDeviceFormat()); err != nil {
+		return err
+	}
+	d.hashValid = d.skipSame
+	return nil
+}
+
+// SetSkipUnchanged makes Update skip the upload when the frame is the same
+// as the last one sent, saving serial traffic on screens that rarely
+// change. It is off by default; call Invalidate to force the next upload,
+// e.g. after the panel may have been power cycled.
+func (d *Display) SetSkipUnchanged(enabled bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.skipSame = enabled
+	d.hashValid = false
+}
+
+// Invalidate makes the next Update upload the frame even if it hasn't
+// changed.
+func (d *Display) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hashValid = false
+}
+
+// asciiFrame renders the framebuffer as text, '#' for on and '.' for off.
+func asciiFrame(fb *eziog500.FrameBuffer) string {
+	var sb strings.Builder
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) {
+				sb.WriteByte('#')
+			} else {
+				sb.WriteByte('.')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
 }
 
 // ClearAndUpdate clears and immediately updates the display.
 func (d *Display) ClearAndUpdate() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.Clear()
-	return d.Update()
+	return d.update()
 }
 
 // Print renders text at the specified pixel position.
 func (d *Display) Print(x, y int, text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	font.RenderText(d.fb, d.font, x, y, text)
 }
 
 // PrintInverted renders inverted text (white background, black text).
 func (d *Display) PrintInverted(x, y int, text string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	font.RenderTextInverted(d.fb, d.font, x, y, text)
 }
 
-// PrintLine renders text on a specific line number (0-7 for 8px font).
+// PrintLine renders text on a specific line number (0-7 for 8px font),
+// lines being the font's LineHeight apart.
 func (d *Display) PrintLine(line int, text string) {
-	y := line * d.font.Height()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	y := line * d.font.LineHeight()
 	font.RenderText(d.fb, d.font, 0, y, text)
 }
 
 // PrintLineCentered renders centered text on a specific line.
 func (d *Display) PrintLineCentered(line int, text string) {
-	y := line * d.font.Height()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	y := line * d.font.LineHeight()
 	width := font.MeasureText(d.font, text)
 	x := (eziog500.Width - width) / 2
 	if x < 0 {
@@ -107,7 +417,9 @@ func (d *Display) PrintLineCentered(line int, text string) {
 
 // PrintLineRight renders right-aligned text on a specific line.
 func (d *Display) PrintLineRight(line int, text string) {
-	y := line * d.font.Height()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	y := line * d.font.LineHeight()
 	width := font.MeasureText(d.font, text)
 	x := eziog500.Width - width
 	if x < 0 {
@@ -118,35 +430,108 @@ func (d *Display) PrintLineRight(line int, text string) {
 
 // MaxLines returns the maximum number of text lines for the current font.
 func (d *Display) MaxLines() int {
-	return eziog500.Height / d.font.Height()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return eziog500.Height / d.font.LineHeight()
 }
 
 // SetBacklight sets the display backlight level (0-255).
 func (d *Display) SetBacklight(level byte) error {
+	d.mu.Lock()
+	d.backlight = level
+	d.mu.Unlock()
+	if d.device == nil {
+		return nil
+	}
 	return d.device.SetBacklight(level)
 }
 
+// Backlight returns the last backlight level set through the Display.
+func (d *Display) Backlight() byte {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.backlight
+}
+
+// FadeBacklight steps the backlight from its current level to target over
+// the given duration, using evenly spaced SetBacklight calls. Each step is
+// flushed immediately so the fade is visible. It blocks until done.
+func (d *Display) FadeBacklight(target byte, duration time.Duration) error {
+	start := int(d.Backlight())
+	diff := int(target) - start
+	if diff < 0 {
+		diff = -diff
+	}
+
+	steps := int(duration / fadeStepInterval)
+	if steps > diff {
+		steps = diff
+	}
+	if steps < 1 {
+		if err := d.SetBacklight(target); err != nil || d.device == nil {
+			return err
+		}
+		return d.device.Flush()
+	}
+
+	interval := duration / time.Duration(steps)
+	for i := 1; i <= steps; i++ {
+		level := start + (int(target)-start)*i/steps
+		if err := d.SetBacklight(byte(level)); err != nil {
+			return err
+		}
+		if d.device != nil {
+			if err := d.device.Flush(); err != nil {
+				return err
+			}
+		}
+		if i < steps {
+			time.Sleep(interval)
+		}
+	}
+	return nil
+}
+
+// SetContrast sets the display contrast level (0-255).
+func (d *Display) SetContrast(level byte) error {
+	if d.device == nil {
+		return nil
+	}
+	return d.device.SetContrast(level)
+}
+
 // SetLED sets the color of an LED.
 func (d *Display) SetLED(led eziog500.LED, color eziog500.LEDColor) error {
+	if d.device == nil {
+		return nil
+	}
 	return d.device.SetLED(led, color)
 }
 
 // DrawLine draws a line on the framebuffer.
 func (d *Display) DrawLine(x1, y1, x2, y2 int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.DrawLine(x1, y1, x2, y2, true)
 }
 
 // DrawRect draws a rectangle outline on the framebuffer.
 func (d *Display) DrawRect(x, y, w, h int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.DrawRect(x, y, w, h, true)
 }
 
 // FillRect fills a rectangle on the framebuffer.
 func (d *Display) FillRect(x, y, w, h int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.FillRect(x, y, w, h, true)
 }
 
 // SetPixel sets a pixel on the framebuffer.
 func (d *Display) SetPixel(x, y int, on bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.fb.SetPixel(x, y, on)
 }
@@ -0,0 +1,871 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// newTestDisplay returns a display backed by a temporary file instead of a
+// serial port, plus a function that flushes and returns everything written.
+// Other packages use internal/testutil, which imports this one, so its
+// tests keep this copy.
+func newTestDisplay(t *testing.T) (*Display, func() []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lcd")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dev, err := eziog500.OpenWithoutStty(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dev.SetCommandDelay(0)
+	t.Cleanup(func() { dev.Close() })
+
+	written := func() []byte {
+		if err := dev.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	return NewWithDevice(dev), written
+}
+
+func TestDisplay_FreezeOnBlank(t *testing.T) {
+	d, written := newTestDisplay(t)
+	var log bytes.Buffer
+	d.SetFreezeOnBlank(&log)
+
+	// A normal frame is pushed
+	d.Print(0, 0, "HELLO")
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if d.Frozen() {
+		t.Fatal("Normal frame should not freeze the display")
+	}
+	if n := len(written()); n != eziog500.BufferSize+2 {
+		t.Fatalf("Expected one upload of %d bytes, got %d", eziog500.BufferSize+2, n)
+	}
+
+	// A blank frame freezes and is logged instead of pushed
+	d.Clear()
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if !d.Frozen() {
+		t.Error("Blank frame should freeze the display")
+	}
+	if n := len(written()); n != eziog500.BufferSize+2 {
+		t.Errorf("Blank frame should not be uploaded, got %d bytes total", n)
+	}
+	if !strings.Contains(log.String(), "blank frame") {
+		t.Errorf("Expected blank frame log, got %q", log.String())
+	}
+	if lines := strings.Count(log.String(), strings.Repeat(".", eziog500.Width)+"\n"); lines != eziog500.Height {
+		t.Errorf("Expected %d blank rows in the dump, got %d", eziog500.Height, lines)
+	}
+
+	// Later frames stay frozen
+	d.Print(0, 0, "AGAIN")
+	d.Update()
+	if n := len(written()); n != eziog500.BufferSize+2 {
+		t.Error("Frozen display should not upload further frames")
+	}
+
+	// Until it is unfrozen; the check stays armed
+	d.Unfreeze()
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(written()); n != 2*(eziog500.BufferSize+2) {
+		t.Errorf("Expected a second upload after Unfreeze, got %d bytes total", n)
+	}
+	d.ClearAndUpdate()
+	if !d.Frozen() {
+		t.Error("Blank frame after Unfreeze should freeze the display again")
+	}
+}
+
+func TestDisplay_FreezeDisabled(t *testing.T) {
+	d, written := newTestDisplay(t)
+
+	// Without the debug check, blank frames are pushed as usual
+	if err := d.ClearAndUpdate(); err != nil {
+		t.Fatal(err)
+	}
+	if d.Frozen() {
+		t.Error("Display should not freeze when the check is disabled")
+	}
+	if len(written()) != eziog500.BufferSize+2 {
+		t.Error("Blank frame should be uploaded when the check is disabled")
+	}
+}
+
+// backlightLevels extracts the levels of all ESC B n commands in data.
+func backlightLevels(data []byte) []byte {
+	var levels []byte
+	for i := 0; i+2 < len(data); i++ {
+		if data[i] == eziog500.ESC && data[i+1] == 'B' {
+			levels = append(levels, data[i+2])
+			i += 2
+		}
+	}
+	return levels
+}
+
+func TestDisplay_FadeBacklight(t *testing.T) {
+	d, written := newTestDisplay(t)
+
+	if err := d.SetBacklight(20); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.FadeBacklight(200, 100*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	levels := backlightLevels(written())
+	if len(levels) < 3 {
+		t.Fatalf("Expected several fade steps, got %v", levels)
+	}
+	for i := 1; i < len(levels); i++ {
+		if levels[i] < levels[i-1] {
+			t.Errorf("Fade up should be monotonic, got %v", levels)
+			break
+		}
+	}
+	if last := levels[len(levels)-1]; last != 200 {
+		t.Errorf("Fade should end at 200, got %d", last)
+	}
+	if d.Backlight() != 200 {
+		t.Errorf("Tracked level should be 200, got %d", d.Backlight())
+	}
+}
+
+func TestDisplay_FadeBacklightDown(t *testing.T) {
+	d, written := newTestDisplay(t)
+
+	// Starts from the assumed default level
+	if err := d.FadeBacklight(0, 60*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+
+	levels := backlightLevels(written())
+	for i := 1; i < len(levels); i++ {
+		if levels[i] > levels[i-1] {
+			t.Errorf("Fade down should be monotonic, got %v", levels)
+			break
+		}
+	}
+	if len(levels) == 0 || levels[len(levels)-1] != 0 {
+		t.Errorf("Fade should end at 0, got %v", levels)
+	}
+}
+
+func TestDisplay_SetRotation(t *testing.T) {
+	d, written := newTestDisplay(t)
+	if err := d.SetRotation(90); err == nil {
+		t.Error("Expected 90 degrees to be rejected")
+	}
+	if err := d.SetRotation(180); err != nil {
+		t.Fatal(err)
+	}
+
+	d.SetPixel(0, 0, true)
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The uploaded frame is rotated, but the drawing buffer is not
+	want := eziog500.NewFrameBuffer()
+	want.SetPixel(eziog500.Width-1, eziog500.Height-1, true)
+	wantData := want.ToDeviceFormat()
+	if got := written()[2:]; !bytes.Equal(got, wantData[:]) {
+		t.Error("Expected the uploaded frame to be rotated 180 degrees")
+	}
+	if !d.FrameBuffer().GetPixel(0, 0) {
+		t.Error("Expected the framebuffer itself to stay unrotated")
+	}
+}
+
+func TestDisplay_SetOverlay(t *testing.T) {
+	d, written := newTestDisplay(t)
+	d.SetOverlay(func(fb *eziog500.FrameBuffer) {
+		fb.SetPixel(5, 5, true)
+	})
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	want := eziog500.NewFrameBuffer()
+	want.SetPixel(5, 5, true)
+	wantData := want.ToDeviceFormat()
+	if got := written()[2:]; !bytes.Equal(got, wantData[:]) {
+		t.Error("Expected the overlay in the uploaded frame")
+	}
+	if d.FrameBuffer().GetPixel(5, 5) {
+		t.Error("Expected the overlay to leave the framebuffer alone")
+	}
+}
+
+func TestDisplay_PushPop(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	fb := d.FrameBuffer()
+	d.Print(0, 0, "HELLO")
+	d.DrawRect(10, 20, 30, 10)
+	original := fb.ToDeviceFormat()
+
+	d.Push()
+	d.Clear()
+	d.Print(0, 30, "OVERLAY")
+
+	d.Push()
+	d.FillRect(0, 0, 20, 20)
+	if err := d.Pop(); err != nil {
+		t.Fatal(err)
+	}
+	if fb.GetPixel(15, 15) {
+		t.Error("Expected the inner Pop to remove the filled rect")
+	}
+
+	if err := d.Pop(); err != nil {
+		t.Fatal(err)
+	}
+	if fb.ToDeviceFormat() != original {
+		t.Error("Expected Pop to restore the original pixels")
+	}
+	if d.FrameBuffer() != fb {
+		t.Error("Expected Pop to restore in place")
+	}
+
+	if err := d.Pop(); err == nil {
+		t.Error("Expected an error popping an empty stack")
+	}
+}
+
+func TestToast(t *testing.T) {
+	d, written := newTestDisplay(t)
+	d.Print(0, 0, "UNDERNEATH")
+	d.FillRect(0, 40, 128, 24)
+	original := d.FrameBuffer().ToDeviceFormat()
+
+	if err := Toast(d, "Config saved", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// One upload with the toast, then one restoring the frame
+	data := written()
+	frame := eziog500.BufferSize + 2
+	if len(data) != 2*frame {
+		t.Fatalf("Expected 2 uploads (%d bytes), got %d bytes", 2*frame, len(data))
+	}
+
+	var shown eziog500.FrameBuffer
+	var toastData [eziog500.BufferSize]byte
+	copy(toastData[:], data[2:frame])
+	shown.FromDeviceFormat(toastData)
+	// Box interior is cleared even over the filled area, and has a border
+	h := toastHeight(font.BuiltinFont)
+	y := (eziog500.Height - h) / 2
+	if !shown.GetPixel(64, y) {
+		t.Error("Expected the toast's top border in the first frame")
+	}
+	if shown.GetPixel(64, y+h-2) {
+		t.Error("Expected the toast's interior to be cleared")
+	}
+
+	if !bytes.Equal(data[frame+2:], original[:]) {
+		t.Error("Expected the second upload to restore the original frame")
+	}
+	if d.FrameBuffer().ToDeviceFormat() != original {
+		t.Error("Expected the framebuffer to be restored")
+	}
+}
+
+func TestToast_TruncatesLongMessages(t *testing.T) {
+	d, written := newTestDisplay(t)
+	if err := Toast(d, "This message is far too long to fit on the display", 0); err != nil {
+		t.Fatal(err)
+	}
+	var shown eziog500.FrameBuffer
+	var toastData [eziog500.BufferSize]byte
+	copy(toastData[:], written()[2:eziog500.BufferSize+2])
+	shown.FromDeviceFormat(toastData)
+
+	// Nothing is drawn outside the widest box
+	margin := (eziog500.Width - toastMaxWidth) / 2
+	for y := 0; y < eziog500.Height; y++ {
+		if shown.GetPixel(margin-1, y) || shown.GetPixel(eziog500.Width-margin, y) {
+			t.Fatalf("Expected text to be truncated inside the box (row %d)", y)
+		}
+	}
+}
+
+// chanEvents is an EventSource fed by the test.
+type chanEvents chan eziog500.ButtonEvent
+
+func (c chanEvents) EventChannel() (<-chan eziog500.ButtonEvent, func()) {
+	return c, func() {}
+}
+
+func TestMultiScreenController(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	screens := NewMultiScreen(10 * time.Second)
+	for i := 0; i < 3; i++ {
+		screens.AddScreen(func(*Display) error { return nil })
+	}
+
+	c := NewMultiScreenController(d, screens, chanEvents(nil))
+	c.SetRotationPause(30 * time.Second)
+	clock := time.Unix(0, 0)
+	c.now = func() time.Time { return clock }
+
+	// Without presses the screens rotate on the interval
+	clock = clock.Add(10 * time.Second)
+	c.tick()
+	if screens.Current() != 1 {
+		t.Fatalf("Expected auto-rotation to screen 1, got %d", screens.Current())
+	}
+
+	press := func(b eziog500.Button) bool {
+		return c.handleEvent(eziog500.ButtonEvent{Button: b, Kind: eziog500.Press})
+	}
+	press(eziog500.ButtonRight)
+	if screens.Current() != 2 {
+		t.Fatalf("Right: expected screen 2, got %d", screens.Current())
+	}
+	press(eziog500.ButtonLeft)
+	press(eziog500.ButtonLeft)
+	press(eziog500.ButtonLeft)
+	if screens.Current() != 2 {
+		t.Fatalf("Left x3: expected to wrap to screen 2, got %d", screens.Current())
+	}
+	if press(eziog500.ButtonUp) {
+		t.Error("Up should not change screens")
+	}
+
+	// A press pauses rotation
+	for i := 0; i < 29; i++ {
+		clock = clock.Add(time.Second)
+		c.tick()
+	}
+	if screens.Current() != 2 {
+		t.Fatalf("Expected rotation paused after a press, got screen %d", screens.Current())
+	}
+	clock = clock.Add(time.Second)
+	c.tick()
+	if screens.Current() != 0 {
+		t.Errorf("Expected rotation to resume after the pause, got screen %d", screens.Current())
+	}
+}
+
+func TestMultiScreenController_Run(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	screens := NewMultiScreen(time.Hour)
+	for i := 0; i < 3; i++ {
+		screens.AddScreen(func(*Display) error { return nil })
+	}
+
+	events := make(chanEvents)
+	c := NewMultiScreenController(d, screens, events)
+	c.SetRefreshInterval(time.Hour)
+	entered := -1
+	c.SetOnEnter(func(index int, _ <-chan eziog500.ButtonEvent) error {
+		entered = index
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	events <- eziog500.ButtonEvent{Button: eziog500.ButtonRight, Kind: eziog500.Press}
+	events <- eziog500.ButtonEvent{Button: eziog500.ButtonRight, Kind: eziog500.LongPress}
+	events <- eziog500.ButtonEvent{Button: eziog500.ButtonEnter, Kind: eziog500.Press}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if screens.Current() != 1 {
+		t.Errorf("Expected screen 1, got %d", screens.Current())
+	}
+	if entered != 1 {
+		t.Errorf("Expected Enter to open screen 1, got %d", entered)
+	}
+}
+
+// readerEvents is an EventSource like eziog500.ButtonReader: each channel
+// has a goroutine blocked reading the next press, and a press it reads after
+// the channel is stopped is dropped.
+type readerEvents chan eziog500.Button
+
+func (r readerEvents) EventChannel() (<-chan eziog500.ButtonEvent, func()) {
+	out := make(chan eziog500.ButtonEvent)
+	done := make(chan struct{})
+	go func() {
+		for {
+			var b eziog500.Button
+			select {
+			case b = <-r: // Blocking Read
+			case <-done:
+				return
+			}
+			select {
+			case out <- eziog500.ButtonEvent{Button: b, Kind: eziog500.Press}:
+			case <-done:
+				return // Stopped while holding a press
+			}
+		}
+	}()
+	var once sync.Once
+	return out, func() { once.Do(func() { close(done) }) }
+}
+
+func TestMultiScreenController_EnterKeepsPresses(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	screens := NewMultiScreen(time.Hour)
+	for i := 0; i < 3; i++ {
+		screens.AddScreen(func(*Display) error { return nil })
+	}
+
+	presses := make(readerEvents)
+	c := NewMultiScreenController(d, screens, presses)
+	c.SetRefreshInterval(time.Hour)
+	inHandler := make(chan eziog500.Button, 1)
+	c.SetOnEnter(func(_ int, events <-chan eziog500.ButtonEvent) error {
+		ev := <-events
+		inHandler <- ev.Button
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error)
+	go func() { done <- c.Run(ctx) }()
+
+	// The handler gets the press after Enter, and the controller the next
+	presses <- eziog500.ButtonEnter
+	presses <- eziog500.ButtonEsc
+	presses <- eziog500.ButtonRight
+	select {
+	case b := <-inHandler:
+		if b != eziog500.ButtonEsc {
+			t.Errorf("Handler got %v, want Esc", b)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Handler didn't get the press after Enter")
+	}
+	presses <- eziog500.ButtonUp // Taken once the controller has Right
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if screens.Current() != 1 {
+		t.Errorf("Right after the handler returned was lost: screen %d", screens.Current())
+	}
+}
+
+func TestMultiScreen_Run(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	m := NewMultiScreen(3 * time.Second)
+	m.SetRefreshInterval(time.Second)
+
+	// Fake tickers, driven below
+	tickers := map[time.Duration]chan time.Time{
+		time.Second:     make(chan time.Time),
+		3 * time.Second: make(chan time.Time),
+	}
+	m.ticker = func(d time.Duration) (<-chan time.Time, func()) {
+		return tickers[d], func() {}
+	}
+
+	renders := make([]int, 3)
+	for i := 0; i < 3; i++ {
+		i := i
+		m.AddScreen(func(*Display) error {
+			renders[i]++
+			return nil
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx, d) }()
+
+	// Simulate 7 seconds: a refresh every second, a rotation every third
+	for sec := 1; sec <= 7; sec++ {
+		if sec%3 == 0 {
+			tickers[3*time.Second] <- time.Time{}
+		} else {
+			tickers[time.Second] <- time.Time{}
+		}
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// Initial render plus one per tick: seconds 0-2 on screen 0, 3-5 on
+	// screen 1, 6-7 on screen 2
+	if want := []int{3, 3, 2}; renders[0] != want[0] || renders[1] != want[1] || renders[2] != want[2] {
+		t.Errorf("renders per screen = %v, want %v", renders, want)
+	}
+	if m.Current() != 2 {
+		t.Errorf("Expected screen 2 after two rotations, got %d", m.Current())
+	}
+}
+
+// TestDisplay_ConcurrentUpdate is meant for go test -race: a render loop, a
+// toast-like overlay and a status writer all draw and update at once.
+func TestDisplay_ConcurrentUpdate(t *testing.T) {
+	d, written := newTestDisplay(t)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		g := g
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 20; i++ {
+				switch g {
+				case 0:
+					d.Clear()
+					d.Print(0, 0, "LOOP")
+					d.DrawRect(0, 10, 20, 10)
+				case 1:
+					d.Push()
+					d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+						fb.FillRect(40, 20, 40, 20, true)
+					})
+					d.Pop()
+				case 2:
+					d.SetBacklight(byte(i))
+					d.PrintLineRight(7, "OK")
+				}
+				if err := d.Update(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := written(); bytes.Count(got, []byte{0x1B, 'G'}) < 80 {
+		t.Errorf("Expected every Update to upload a frame, got %d", bytes.Count(got, []byte{0x1B, 'G'}))
+	}
+}
+
+func TestDisplay_Reset(t *testing.T) {
+	d, written := newTestDisplay(t)
+	d.SetBacklight(80)
+	d.Print(0, 0, "STALE")
+	d.Push()
+
+	for i := 0; i < 2; i++ {
+		if err := d.Reset(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []byte{0x1B, '@', 0x0B, 0x0C, 0x1B, 'B', 80, 0x1B, 'G'}
+	want = append(want, make([]byte, eziog500.BufferSize)...)
+	got := written()
+	got = got[3:] // The SetBacklight above
+	if !bytes.Equal(got, append(want, want...)) {
+		t.Errorf("Reset wrote % X..., want % X... twice", got[:min(len(got), 12)], want[:12])
+	}
+
+	if d.FrameBuffer().CountSetPixels() != 0 {
+		t.Error("Expected Reset to clear the framebuffer")
+	}
+	if err := d.Pop(); err == nil {
+		t.Error("Expected Reset to drop the Push stack")
+	}
+}
+
+func TestCaret_Blink(t *testing.T) {
+	c := &Caret{X: 10, Y: 20}
+	for frame := 0; frame < 6; frame++ {
+		fb := eziog500.NewFrameBuffer()
+		c.Render(fb, frame)
+		visible := fb.CountSetPixels() == DefaultCaretWidth
+		if visible != (frame%2 == 0) {
+			t.Errorf("frame %d: visible = %v, want %v", frame, visible, frame%2 == 0)
+		}
+	}
+
+	// The underscore sits on the bottom row of the cell
+	fb := eziog500.NewFrameBuffer()
+	c.Render(fb, 0)
+	if !fb.GetPixel(10, 27) || !fb.GetPixel(14, 27) || fb.GetPixel(15, 27) {
+		t.Error("Expected a 5 pixel underscore at y=27")
+	}
+
+	// A block caret inverts the cell, and slower blinks hold each phase
+	block := &Caret{X: 0, Y: 0, Width: 2, Height: 2, Block: true, BlinkFrames: 3}
+	fb = eziog500.NewFrameBuffer()
+	fb.SetPixel(0, 0, true)
+	block.Render(fb, 2)
+	if fb.GetPixel(0, 0) || !fb.GetPixel(1, 1) {
+		t.Error("Expected the block caret to invert its cell")
+	}
+	if block.Visible(3) || block.Visible(5) || !block.Visible(6) {
+		t.Error("Expected 3-frame blink phases")
+	}
+}
+
+func TestAnimate(t *testing.T) {
+	d, _ := newTestDisplay(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	calls := 0
+	err := Animate(ctx, 50, d, func(f int) error {
+		if f != calls {
+			t.Errorf("frame %d, want %d", f, calls)
+		}
+		calls++
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 50fps for 200ms is 10 frames, with slack for a slow machine
+	if calls < 6 || calls > 12 {
+		t.Errorf("Expected about 10 frames in 200ms, got %d", calls)
+	}
+}
+
+func TestAnimate_Stop(t *testing.T) {
+	d, written := newTestDisplay(t)
+
+	calls := 0
+	err := Animate(context.Background(), 1000, d, func(f int) error {
+		calls++
+		if f == 2 {
+			return ErrStopAnimation
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("Animate = %v after %d frames, want nil after 3", err, calls)
+	}
+	if got := bytes.Count(written(), []byte{0x1B, 'G'}); got < 3 {
+		t.Errorf("Expected 3 frame uploads, got %d", got)
+	}
+
+	boom := errors.New("boom")
+	err = Animate(context.Background(), 1000, d, func(f int) error { return boom })
+	if !errors.Is(err, boom) {
+		t.Errorf("Animate = %v, want the frame error", err)
+	}
+	if err := Animate(context.Background(), 0, d, func(int) error { return nil }); err == nil {
+		t.Error("Expected an error for 0 fps")
+	}
+}
+
+func TestProgressBar_RenderLabeled(t *testing.T) {
+	d, written := newTestDisplay(t)
+	bar := &ProgressBar{X: 10, Y: 25, Width: 108, Height: 12}
+
+	filled := -1
+	for _, pct := range []float64{0, 50, 100} {
+		d.Clear()
+		bar.RenderLabeled(d, pct, "A LABEL FAR TOO LONG TO FIT ABOVE THE BAR")
+		if err := d.Update(); err != nil {
+			t.Fatal(err)
+		}
+
+		fb := d.FrameBuffer()
+		// Nothing spills past the bar's sides
+		for y := 0; y < eziog500.Height; y++ {
+			if fb.GetPixel(bar.X-1, y) || fb.GetPixel(bar.X+bar.Width, y) {
+				t.Errorf("%v%%: text drawn outside the bar's width at y=%d", pct, y)
+				break
+			}
+		}
+		n := fb.CountSetPixels()
+		if n <= filled {
+			t.Errorf("%v%%: expected more pixels than the last bar", pct)
+		}
+		filled = n
+	}
+	if got := bytes.Count(written(), []byte{0x1B, 'G'}); got < 3 {
+		t.Errorf("Expected 3 uploads, got %d", got)
+	}
+
+	// Without a label, nothing is drawn above the bar
+	d.Clear()
+	bar.RenderLabeled(d, 50, "")
+	for y := 0; y < bar.Y; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if d.FrameBuffer().GetPixel(x, y) {
+				t.Fatalf("Unexpected pixel above the bar at %d,%d", x, y)
+			}
+		}
+	}
+}
+
+// textBounds returns the width and height of the set pixels in fb.
+func textBounds(fb *eziog500.FrameBuffer) (w, h int) {
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) {
+				w, h = max(w, x+1), max(h, y+1)
+			}
+		}
+	}
+	return w, h
+}
+
+func TestDisplay_SetFont(t *testing.T) {
+	d, _ := newTestDisplay(t)
+	const text = "HELLO 123"
+
+	d.PrintLine(0, text)
+	bigW, bigH := textBounds(d.FrameBuffer())
+
+	d.Clear()
+	d.SetFont(font.SmallFont)
+	if d.Font() != font.SmallFont {
+		t.Fatal("Expected Font to return the font set")
+	}
+	d.PrintLine(0, text)
+	smallW, smallH := textBounds(d.FrameBuffer())
+
+	if smallW >= bigW || smallH >= bigH {
+		t.Errorf("Small text is %dx%d, want smaller than %dx%d", smallW, smallH, bigW, bigH)
+	}
+	if smallH > font.SmallFont.Height() || bigH > font.BuiltinFont.Height() {
+		t.Errorf("Text taller than its font: %d and %d", smallH, bigH)
+	}
+	if d.MaxLines() != eziog500.Height/font.SmallFont.Height() {
+		t.Errorf("MaxLines() = %d for the small font", d.MaxLines())
+	}
+}
+
+func TestDisplay_SkipUnchanged(t *testing.T) {
+	d, written := newTestDisplay(t)
+	uploads := func() int { return bytes.Count(written(), []byte{0x1B, 'G'}) }
+
+	d.Print(0, 0, "SAME")
+	d.Update()
+	d.Update()
+	if got := uploads(); got != 2 {
+		t.Fatalf("Expected every frame sent by default, got %d uploads", got)
+	}
+
+	d.SetSkipUnchanged(true)
+	d.Update()
+	d.Update()
+	if got := uploads(); got != 3 {
+		t.Errorf("Expected a repeated frame to be skipped, got %d uploads", got)
+	}
+
+	d.SetPixel(100, 50, true)
+	d.Update()
+	if got := uploads(); got != 4 {
+		t.Errorf("Expected a changed frame to be sent, got %d uploads", got)
+	}
+
+	d.Invalidate()
+	d.Update()
+	if got := uploads(); got != 5 {
+		t.Errorf("Expected Invalidate to force an upload, got %d uploads", got)
+	}
+}
+
+func TestNewPreview(t *testing.T) {
+	var out bytes.Buffer
+	d := NewPreview(&out)
+	if d.Device() != nil {
+		t.Error("Expected a preview to have no device")
+	}
+
+	d.Print(0, 0, "HI")
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetBacklight(10); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetLED(eziog500.LED1, eziog500.LEDRed); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Update(); err != nil {
+		t.Fatal(err)
+	}
+
+	frames := strings.Split(out.String(), "\x1b[H")
+	if len(frames) != 3 || !strings.HasPrefix(frames[0], "\x1b[2J") {
+		t.Fatalf("Expected the screen cleared once, then 2 frames; got %q", out.String())
+	}
+	if frames[1] != d.FrameBuffer().String() || !strings.Contains(frames[1], "█") {
+		t.Error("Expected each frame drawn with FrameBuffer.String")
+	}
+	if err := d.Close(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSplash(t *testing.T) {
+	d, written := newTestDisplay(t)
+	logo := ui.NewBitmap(10, 10)
+	for i := 0; i < 10; i++ {
+		logo.Set(i, i, true)
+	}
+
+	if err := Splash(d, "PFSENSE", "STARTING", logo); err != nil {
+		t.Fatal(err)
+	}
+	if n := bytes.Count(written(), []byte{0x1B, 'G'}); n != 1 {
+		t.Errorf("Expected the splash to be uploaded once, got %d uploads", n)
+	}
+
+	// 10px logo, 8px title and 6px subtitle with two 3px gaps is 30px,
+	// so the block starts 17px down and the logo is centred across
+	fb := d.FrameBuffer()
+	if !fb.GetPixel(59, 17) || !fb.GetPixel(68, 26) {
+		t.Error("Expected the logo centred at the top of the block")
+	}
+	if top, bottom := litRows(fb, 28); top < 30 || bottom > 46 {
+		t.Errorf("Text spans rows %d-%d, want within 30-46", top, bottom)
+	}
+
+	// Without a logo the title alone is centred, 28px down
+	if err := Splash(d, "PFSENSE", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if top, bottom := litRows(d.FrameBuffer(), 0); top < 28 || bottom > 35 {
+		t.Errorf("Title spans rows %d-%d, want within 28-35", top, bottom)
+	}
+}
+
+// litRows returns the first and last rows from y on with a lit pixel.
+func litRows(fb *eziog500.FrameBuffer, y int) (top, bottom int) {
+	top = eziog500.Height
+	for ; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) {
+				top, bottom = min(top, y), max(bottom, y)
+			}
+		}
+	}
+	return top, bottom
+}
@@ -0,0 +1,166 @@
+package display
+
+import (
+	"context"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+const (
+	// DefaultRefreshInterval is how often MultiScreen.Run and
+	// MultiScreenController redraw the current screen.
+	DefaultRefreshInterval = time.Second
+
+	// DefaultRotationPause is how long auto-rotation stays paused after a
+	// button press.
+	DefaultRotationPause = 30 * time.Second
+)
+
+// EventSource provides button events to a MultiScreenController. Both
+// eziog500.ButtonReader and eziog500.SessionButtonReader implement it.
+type EventSource interface {
+	EventChannel() (<-chan eziog500.ButtonEvent, func())
+}
+
+// MultiScreenController shows a MultiScreen and lets the buttons drive it:
+// Left and Right switch screens and Enter calls the OnEnter handler.
+// Screens rotate every MultiScreen interval, except for a while after a
+// button press so a screen picked by hand stays up.
+type MultiScreenController struct {
+	display *Display
+	screens *MultiScreen
+	buttons EventSource
+	refresh time.Duration
+	pause   time.Duration
+	onEnter func(index int, events <-chan eziog500.ButtonEvent) error
+	tabs    *ui.TabBar
+	now     func() time.Time
+	rotated time.Time // Last screen change, manual or automatic
+	touched time.Time // Last button press
+}
+
+// NewMultiScreenController creates a controller for screens on d, using
+// their refresh interval.
+func NewMultiScreenController(d *Display, screens *MultiScreen, buttons EventSource) *MultiScreenController {
+	return &MultiScreenController{
+		display: d,
+		screens: screens,
+		buttons: buttons,
+		refresh: screens.refresh,
+		pause:   DefaultRotationPause,
+		now:     time.Now,
+	}
+}
+
+// SetRefreshInterval sets how often the current screen is redrawn.
+func (c *MultiScreenController) SetRefreshInterval(d time.Duration) {
+	c.refresh = d
+}
+
+// SetRotationPause sets how long auto-rotation pauses after a button press.
+func (c *MultiScreenController) SetRotationPause(d time.Duration) {
+	c.pause = d
+}
+
+// SetOnEnter sets the handler Enter calls with the current screen index,
+// for drilling into a screen's details or opening a menu. It runs on the
+// controller's loop and gets the controller's own event channel, so button
+// events go to it until it returns and none are lost in between; the screen
+// is redrawn when it returns.
+func (c *MultiScreenController) SetOnEnter(fn func(index int, events <-chan eziog500.ButtonEvent) error) {
+	c.onEnter = fn
+}
+
+// SetTabs shows a tab bar with one label per screen across the top of every
+// frame, highlighting the current screen. It covers the top
+// TabBar.Height() rows of each screen.
+func (c *MultiScreenController) SetTabs(labels ...string) {
+	c.tabs = ui.NewTabBar(eziog500.Width, labels...)
+}
+
+// Run shows the screens until ctx is cancelled or a screen fails to render.
+func (c *MultiScreenController) Run(ctx context.Context) error {
+	events, stop := c.buttons.EventChannel()
+	defer stop()
+
+	if c.tabs != nil {
+		c.display.SetOverlay(func(fb *eziog500.FrameBuffer) {
+			c.tabs.Active = c.screens.Current()
+			c.tabs.Render(fb, 0, 0)
+		})
+		defer c.display.SetOverlay(nil)
+	}
+
+	ticker := time.NewTicker(c.refresh)
+	defer ticker.Stop()
+
+	c.rotated = c.now()
+	if err := c.screens.RenderCurrent(c.display); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case <-ticker.C:
+			c.tick()
+			if err := c.screens.RenderCurrent(c.display); err != nil {
+				return err
+			}
+
+		case ev, ok := <-events:
+			if !ok {
+				events = nil // Reader stopped; keep rotating
+				continue
+			}
+			if ev.Button == eziog500.ButtonEnter && ev.Kind == eziog500.Press && c.onEnter != nil {
+				err := c.onEnter(c.screens.Current(), events)
+				c.touched = c.now()
+				if err != nil {
+					return err
+				}
+			} else if !c.handleEvent(ev) {
+				continue
+			}
+			if err := c.screens.RenderCurrent(c.display); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// handleEvent switches screens for Left and Right. It reports whether the
+// screen changed.
+func (c *MultiScreenController) handleEvent(ev eziog500.ButtonEvent) bool {
+	if ev.Kind != eziog500.Press && ev.Kind != eziog500.Repeat {
+		return false
+	}
+	switch ev.Button {
+	case eziog500.ButtonLeft:
+		c.screens.Previous()
+	case eziog500.ButtonRight:
+		c.screens.Next()
+	default:
+		return false
+	}
+	c.touched = c.now()
+	c.rotated = c.touched
+	return true
+}
+
+// tick advances to the next screen if the rotation interval has passed and
+// no button was pressed within the rotation pause.
+func (c *MultiScreenController) tick() {
+	now := c.now()
+	if c.screens.interval <= 0 || now.Sub(c.touched) < c.pause {
+		return
+	}
+	if now.Sub(c.rotated) >= c.screens.interval {
+		c.screens.Next()
+		c.rotated = now
+	}
+}
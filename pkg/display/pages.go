@@ -0,0 +1,109 @@
+package display
+
+import (
+	"fmt"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// The panel keeps graphics pages that it can save the screen into (ESC S n)
+// and switch back to (ESC P n). Switching sends 3 bytes instead of a
+// 1026-byte upload, so it is instant and can't tear. The protocol notes
+// don't say how many pages a unit has, nor whether they survive a power
+// cycle, so use as few as you need starting from 0 and re-save them after
+// Reset or a reconnect.
+
+// SaveToPage sends the framebuffer to the panel, then saves the screen
+// into page. The frame is briefly shown while it is saved.
+func (d *Display) SaveToPage(page byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err := d.update(); err != nil {
+		return err
+	}
+	if d.device == nil {
+		if d.pages == nil {
+			d.pages = make(map[byte]*eziog500.FrameBuffer)
+		}
+		d.pages[page] = d.fb.Copy()
+		return nil
+	}
+	if err := d.device.SavePage(page); err != nil {
+		return err
+	}
+	return d.device.Flush()
+}
+
+// ShowPage switches the panel to a page saved with SaveToPage. The
+// framebuffer is left as it was, so the next Update replaces the page.
+func (d *Display) ShowPage(page byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.hashValid = false
+	if d.device == nil {
+		fb, ok := d.pages[page]
+		if !ok {
+			return fmt.Errorf("page %d was never saved", page)
+		}
+		return d.preview(fb)
+	}
+	if err := d.device.ShowPage(page); err != nil {
+		return err
+	}
+	return d.device.Flush()
+}
+
+// PageManager pre-renders named screens into pages 0 to n-1, then shows
+// any of them instantly, e.g. to flip between a few static screens without
+// redrawing them.
+type PageManager struct {
+	d     *Display
+	n     int
+	pages map[string]byte
+}
+
+// NewPageManager creates a page manager using n of d's pages.
+func NewPageManager(d *Display, n int) *PageManager {
+	return &PageManager{d: d, n: n, pages: make(map[string]byte)}
+}
+
+// Render draws a screen with draw, starting from a cleared framebuffer,
+// and saves it to the page for name: the one it had before, or the next
+// free one. It errors once all pages are in use. The display's own
+// framebuffer is restored afterwards.
+func (m *PageManager) Render(name string, draw func(fb *eziog500.FrameBuffer)) error {
+	page, ok := m.pages[name]
+	if !ok {
+		if len(m.pages) >= m.n {
+			return fmt.Errorf("no free page for %q: all %d in use", name, m.n)
+		}
+		page = byte(len(m.pages))
+	}
+
+	m.d.Push()
+	defer m.d.Pop()
+	m.d.Draw(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		draw(fb)
+	})
+	if err := m.d.SaveToPage(page); err != nil {
+		return err
+	}
+	m.pages[name] = page
+	return nil
+}
+
+// Show switches to the page rendered for name.
+func (m *PageManager) Show(name string) error {
+	page, ok := m.pages[name]
+	if !ok {
+		return fmt.Errorf("no page rendered for %q", name)
+	}
+	return m.d.ShowPage(page)
+}
+
+// Page returns the page holding name, if it has been rendered.
+func (m *PageManager) Page(name string) (byte, bool) {
+	page, ok := m.pages[name]
+	return page, ok
+}
@@ -0,0 +1,79 @@
+package display
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestPageManager(t *testing.T) {
+	d, written := newTestDisplay(t)
+	pm := NewPageManager(d, 2)
+
+	d.Print(0, 0, "MINE")
+	before := d.FrameBuffer().ToDeviceFormat()
+
+	var frame eziog500.FrameBuffer
+	frame.DrawRect(0, 0, 128, 64, true)
+	if err := pm.Render("border", func(fb *eziog500.FrameBuffer) { fb.DrawRect(0, 0, 128, 64, true) }); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Render("blank", func(fb *eziog500.FrameBuffer) {}); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Render("third", func(fb *eziog500.FrameBuffer) {}); err == nil {
+		t.Error("Expected an error once all pages are in use")
+	}
+	if err := pm.Show("border"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Show("missing"); err == nil {
+		t.Error("Expected an error for a page never rendered")
+	}
+
+	// Upload then save for each page, then show page 0
+	data := frame.ToDeviceFormat()
+	blank := eziog500.NewFrameBuffer().ToDeviceFormat()
+	var want []byte
+	want = append(want, 0x1B, 'G')
+	want = append(want, data[:]...)
+	want = append(want, 0x1B, 'S', 0)
+	want = append(want, 0x1B, 'G')
+	want = append(want, blank[:]...)
+	want = append(want, 0x1B, 'S', 1)
+	want = append(want, 0x1B, 'P', 0)
+	if got := written(); !bytes.Equal(got, want) {
+		t.Errorf("Got %d bytes, want upload+save twice then show (%d bytes)", len(got), len(want))
+	}
+
+	if d.FrameBuffer().ToDeviceFormat() != before {
+		t.Error("Expected the framebuffer to be restored after rendering pages")
+	}
+	if p, ok := pm.Page("blank"); !ok || p != 1 {
+		t.Errorf("Page(blank) = %d, %v; want 1", p, ok)
+	}
+}
+
+func TestPreview_Pages(t *testing.T) {
+	var shown []*eziog500.FrameBuffer
+	d := NewPreviewFunc(func(fb *eziog500.FrameBuffer) error {
+		shown = append(shown, fb.Copy())
+		return nil
+	})
+
+	d.SetPixel(5, 5, true)
+	if err := d.SaveToPage(3); err != nil {
+		t.Fatal(err)
+	}
+	d.Clear()
+	if err := d.ShowPage(3); err != nil {
+		t.Fatal(err)
+	}
+	if len(shown) != 2 || !shown[1].GetPixel(5, 5) {
+		t.Error("Expected ShowPage to show the saved frame")
+	}
+	if err := d.ShowPage(4); err == nil {
+		t.Error("Expected an error for a page never saved")
+	}
+}
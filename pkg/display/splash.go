@@ -0,0 +1,57 @@
+package display
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// splashGap is the space in pixels between the splash's logo, title and
+// subtitle.
+const splashGap = 3
+
+// Splash shows a startup screen: logo above title, with subtitle under it in
+// the small font, centred as a block. Any of them may be empty or nil; text
+// that is too wide is truncated. It replaces the framebuffer and updates the
+// display.
+func Splash(d *Display, title, subtitle string, logo *ui.Bitmap) error {
+	f, sf := font.BuiltinFont, font.SmallFont
+
+	// Centre the parts that are present as one block
+	var heights []int
+	if logo != nil {
+		heights = append(heights, logo.Height())
+	}
+	if title != "" {
+		heights = append(heights, f.Height())
+	}
+	if subtitle != "" {
+		heights = append(heights, sf.Height())
+	}
+	total := 0
+	for i, h := range heights {
+		if i > 0 {
+			total += splashGap
+		}
+		total += h
+	}
+
+	d.Draw(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		y := (eziog500.Height - total) / 2
+		if logo != nil {
+			logo.Render(fb, (eziog500.Width-logo.Width())/2, y)
+			y += logo.Height() + splashGap
+		}
+		if title != "" {
+			text := font.TruncateText(f, title, eziog500.Width)
+			font.RenderText(fb, f, (eziog500.Width-font.MeasureText(f, text))/2, y, text)
+			y += f.Height() + splashGap
+		}
+		if subtitle != "" {
+			text := font.TruncateText(sf, subtitle, eziog500.Width)
+			font.RenderText(fb, sf, (eziog500.Width-font.MeasureText(sf, text))/2, y, text)
+		}
+	})
+	return d.Update()
+}
@@ -1,9 +1,11 @@
 package display
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
 	"github.com/sagostin/ezio-g500/pkg/font"
 )
 
@@ -22,16 +24,23 @@ type StatusTemplate struct {
 
 // Render draws the status template to the display.
 func (t *StatusTemplate) Render(d *Display) error {
-	d.fb.Clear()
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		t.draw(fb, d.font)
+	})
+}
+
+// draw renders the template onto fb in f.
+func (t *StatusTemplate) draw(fb *eziog500.FrameBuffer, f font.Font) {
+	fb.Clear()
 
 	y := 0
-	fontHeight := d.font.Height()
+	lineHeight := f.LineHeight()
 
 	// Render title if present
 	if t.Title != "" {
 		// Draw title with inverted style
-		font.RenderTextInverted(d.fb, d.font, 0, y, t.Title)
-		y += fontHeight
+		font.RenderTextInverted(fb, f, 0, y, t.Title)
+		y += lineHeight
 	}
 
 	// Render status lines
@@ -46,11 +55,9 @@ func (t *StatusTemplate) Render(d *Display) error {
 			text = line.Label + ": " + line.Value
 		}
 
-		font.RenderText(d.fb, d.font, 0, y, text)
-		y += fontHeight
+		font.RenderText(fb, f, 0, y, text)
+		y += lineHeight
 	}
-
-	return d.Update()
 }
 
 // SystemStatus is a predefined template for system information.
@@ -169,14 +176,36 @@ func (p *ProgressBar) Render(d *Display, percent float64) {
 		percent = 100
 	}
 
-	// Draw border
-	d.fb.DrawRect(p.X, p.Y, p.Width, p.Height, true)
+	d.Draw(func(fb *eziog500.FrameBuffer) {
+		// Draw border
+		fb.DrawRect(p.X, p.Y, p.Width, p.Height, true)
 
-	// Fill based on percentage
-	fillWidth := int(float64(p.Width-2) * percent / 100)
-	if fillWidth > 0 {
-		d.fb.FillRect(p.X+1, p.Y+1, fillWidth, p.Height-2, true)
-	}
+		// Fill based on percentage
+		fillWidth := int(float64(p.Width-2) * percent / 100)
+		if fillWidth > 0 {
+			fb.FillRect(p.X+1, p.Y+1, fillWidth, p.Height-2, true)
+		}
+	})
+}
+
+// RenderLabeled draws the bar with the percentage centred below it and, if
+// label isn't empty, the label centred above it, in the display's font.
+// Text wider than the bar is truncated with an ellipsis.
+func (p *ProgressBar) RenderLabeled(d *Display, percent float64, label string) {
+	percent = max(0, min(percent, 100))
+	p.Render(d, percent)
+
+	d.Draw(func(fb *eziog500.FrameBuffer) {
+		f := d.font
+		centered := func(y int, text string) {
+			text = font.TruncateText(f, text, p.Width)
+			font.RenderText(fb, f, p.X+(p.Width-font.MeasureText(f, text))/2, y, text)
+		}
+		if label != "" {
+			centered(p.Y-f.Height()-2, label)
+		}
+		centered(p.Y+p.Height+2, fmt.Sprintf("%.0f%%", percent))
+	})
 }
 
 // MultiScreen manages multiple display screens that can be cycled.
@@ -184,13 +213,59 @@ type MultiScreen struct {
 	screens  []func(*Display) error
 	current  int
 	interval time.Duration
+	refresh  time.Duration
+
+	// ticker starts a ticker; tests replace it to control time
+	ticker func(time.Duration) (<-chan time.Time, func())
 }
 
-// NewMultiScreen creates a multi-screen manager.
+// NewMultiScreen creates a multi-screen manager that Run advances every
+// interval.
 func NewMultiScreen(interval time.Duration) *MultiScreen {
 	return &MultiScreen{
 		screens:  make([]func(*Display) error, 0),
 		interval: interval,
+		refresh:  DefaultRefreshInterval,
+		ticker: func(d time.Duration) (<-chan time.Time, func()) {
+			t := time.NewTicker(d)
+			return t.C, t.Stop
+		},
+	}
+}
+
+// SetRefreshInterval sets how often Run redraws the current screen.
+func (m *MultiScreen) SetRefreshInterval(d time.Duration) {
+	m.refresh = d
+}
+
+// Run redraws the current screen every refresh interval and advances to
+// the next screen every rotation interval until ctx is cancelled. A zero
+// rotation interval disables rotation. Render errors stop Run.
+func (m *MultiScreen) Run(ctx context.Context, d *Display) error {
+	refresh, stopRefresh := m.ticker(m.refresh)
+	defer stopRefresh()
+
+	var rotate <-chan time.Time // nil never fires
+	if m.interval > 0 {
+		var stopRotate func()
+		rotate, stopRotate = m.ticker(m.interval)
+		defer stopRotate()
+	}
+
+	if err := m.RenderCurrent(d); err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh:
+		case <-rotate:
+			m.Next()
+		}
+		if err := m.RenderCurrent(d); err != nil {
+			return err
+		}
 	}
 }
 
@@ -227,3 +302,8 @@ func (m *MultiScreen) SetScreen(index int) {
 		m.current = index
 	}
 }
+
+// Current returns the current screen index.
+func (m *MultiScreen) Current() int {
+	return m.current
+}
@@ -0,0 +1,55 @@
+package display
+
+import (
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// Toast box geometry.
+const (
+	toastPadding  = 6                  // Horizontal space around the text
+	toastVPadding = 5                  // Vertical space around the text
+	toastMaxWidth = eziog500.Width - 8 // Widest box; longer messages are truncated
+	toastMinWidth = 40                 // Narrowest box, so short messages still stand out
+	toastRadius   = 3                  // Corner radius
+)
+
+// Toast flashes msg in a centred box over the current frame, waits for
+// duration, then restores and re-sends the frame underneath. It blocks for
+// the whole duration, so when called from a render loop nothing else draws
+// over it in the meantime. The message is drawn in the display's font.
+func Toast(d *Display, msg string, duration time.Duration) error {
+	f := d.Font()
+	h := toastHeight(f)
+	text := font.TruncateText(f, msg, toastMaxWidth-2*toastPadding)
+
+	w := font.MeasureText(f, text) + 2*toastPadding
+	if w < toastMinWidth {
+		w = toastMinWidth
+	}
+	x := (eziog500.Width - w) / 2
+	y := (eziog500.Height - h) / 2
+
+	d.Push()
+	err := d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.FillRect(x, y, w, h, false)
+		fb.DrawRoundedRect(x, y, w, h, toastRadius, true)
+		font.RenderText(fb, f, (eziog500.Width-font.MeasureText(f, text))/2, y+toastVPadding, text)
+	})
+	if err == nil {
+		time.Sleep(duration)
+	}
+
+	d.Pop()
+	if err != nil {
+		return err
+	}
+	return d.Update()
+}
+
+// toastHeight returns the height of the toast box for one line of f.
+func toastHeight(f font.Font) int {
+	return f.Height() + 2*toastVPadding
+}
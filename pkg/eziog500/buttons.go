@@ -44,16 +44,35 @@ func (b Button) String() string {
 type ButtonReader struct {
 	device  *Device
 	timeout time.Duration
+	timing  ButtonTiming
+	chords  []Chord
 }
 
-// NewButtonReader creates a button reader for the device.
-func NewButtonReader(device *Device, timeout time.Duration) *ButtonReader {
+// NewButtonReader creates a button reader for the device. Repeats of a
+// button code within debounce of the last accepted one are dropped; 0
+// disables debouncing.
+func NewButtonReader(device *Device, timeout, debounce time.Duration) *ButtonReader {
+	timing := DefaultButtonTiming
+	timing.Debounce = debounce
 	return &ButtonReader{
 		device:  device,
 		timeout: timeout,
+		timing:  timing,
 	}
 }
 
+// SetTiming sets the debounce and hold timing.
+func (br *ButtonReader) SetTiming(t ButtonTiming) {
+	br.timing = t
+}
+
+// SetChords enables ChordPress events from EventChannel for the given
+// button pairs. Presses of chord buttons are delayed by up to ChordWindow
+// while waiting for the other button.
+func (br *ButtonReader) SetChords(chords ...Chord) {
+	br.chords = chords
+}
+
 // ReadButton reads a single button press with timeout.
 // Returns ButtonNone if no button was pressed within the timeout.
 func (br *ButtonReader) ReadButton() Button {
@@ -92,6 +111,8 @@ func (br *ButtonReader) ButtonChannel() (<-chan Button, func()) {
 	ch := make(chan Button, 10)
 	stop := make(chan struct{})
 
+	debounce := &debouncer{window: br.timing.Debounce}
+
 	go func() {
 		defer close(ch)
 		buf := make([]byte, 1)
@@ -104,7 +125,7 @@ func (br *ButtonReader) ButtonChannel() (<-chan Button, func()) {
 				n, err := br.device.Read(buf)
 				if err == nil && n > 0 {
 					btn := Button(buf[0])
-					if btn != ButtonNone {
+					if btn != ButtonNone && debounce.accept(btn, time.Now()) {
 						select {
 						case ch <- btn:
 						default:
@@ -124,14 +145,38 @@ func (br *ButtonReader) ButtonChannel() (<-chan Button, func()) {
 	return ch, stopper
 }
 
+// EventChannel returns a channel that emits button events, including
+// repeats and long presses while a button is held. Unlike ButtonChannel it
+// sends one Press per press however often the panel repeats the code.
+func (br *ButtonReader) EventChannel() (<-chan ButtonEvent, func()) {
+	return buttonEvents(br.device.Read, 1, br.timing, br.chords)
+}
+
 // SessionButtonReader reads button input from a PersistentSession.
 type SessionButtonReader struct {
 	session *PersistentSession
+	timing  ButtonTiming
+	chords  []Chord
 }
 
 // NewSessionButtonReader creates a button reader using a persistent session.
-func NewSessionButtonReader(session *PersistentSession) *SessionButtonReader {
-	return &SessionButtonReader{session: session}
+// Repeats of a button code within debounce of the last accepted one are
+// dropped; 0 disables debouncing.
+func NewSessionButtonReader(session *PersistentSession, debounce time.Duration) *SessionButtonReader {
+	timing := DefaultButtonTiming
+	timing.Debounce = debounce
+	return &SessionButtonReader{session: session, timing: timing}
+}
+
+// SetTiming sets the debounce and hold timing.
+func (br *SessionButtonReader) SetTiming(t ButtonTiming) {
+	br.timing = t
+}
+
+// SetChords enables ChordPress events from EventChannel for the given
+// button pairs.
+func (br *SessionButtonReader) SetChords(chords ...Chord) {
+	br.chords = chords
 }
 
 // ReadButton reads a single button press.
@@ -159,6 +204,8 @@ func (br *SessionButtonReader) ButtonChannel() (<-chan Button, func()) {
 	ch := make(chan Button, 10)
 	stop := make(chan struct{})
 
+	debounce := &debouncer{window: br.timing.Debounce}
+
 	go func() {
 		defer close(ch)
 		buf := make([]byte, 16) // Read multiple bytes at once
@@ -174,9 +221,10 @@ func (br *SessionButtonReader) ButtonChannel() (<-chan Button, func()) {
 					debugf("Raw bytes received (%d): % 02X", n, buf[:n])
 
 					// Process each byte
+					now := time.Now()
 					for i := 0; i < n; i++ {
 						btn := Button(buf[i])
-						if btn != ButtonNone {
+						if btn != ButtonNone && debounce.accept(btn, now) {
 							debugf("Button: 0x%02X (%s)", buf[i], btn.String())
 							select {
 							case ch <- btn:
@@ -197,3 +245,9 @@ func (br *SessionButtonReader) ButtonChannel() (<-chan Button, func()) {
 
 	return ch, stopper
 }
+
+// EventChannel returns a channel that emits button events, including
+// repeats and long presses while a button is held.
+func (br *SessionButtonReader) EventChannel() (<-chan ButtonEvent, func()) {
+	return buttonEvents(br.session.Read, 16, br.timing, br.chords)
+}
@@ -12,6 +12,7 @@ const (
 	// ESC + second byte commands
 	cmdInit      = 0x40 // '@' - Display initialization
 	cmdBacklight = 0x42 // 'B' - Backlight control
+	cmdContrast  = 0x43 // 'C' - Contrast control (unverified, see SetContrast)
 	cmdUpload    = 0x47 // 'G' - Upload graphics
 	cmdLED       = 0x4C // 'L' - LED control
 	cmdShowPage  = 0x50 // 'P' - Show graphics page
@@ -59,6 +60,22 @@ func (d *Device) SetBacklight(level byte) error {
 	return d.Write([]byte{ESC, cmdBacklight, level})
 }
 
+// SetContrast sets the LCD contrast level (ESC C n).
+// Level is 0-255, where higher values darken the pixels.
+//
+// The contrast opcode is not part of the published protocol notes; ESC C is
+// used by analogy with ESC B and has not been confirmed on every unit. If it
+// has no effect on your panel, try other opcodes with SetContrastRaw.
+func (d *Device) SetContrast(level byte) error {
+	return d.SetContrastRaw(cmdContrast, level)
+}
+
+// SetContrastRaw sends a contrast command with an explicit opcode (ESC op n).
+// This allows the opcode to be tuned per unit without changing the library.
+func (d *Device) SetContrastRaw(opcode, level byte) error {
+	return d.Write([]byte{ESC, opcode, level})
+}
+
 // UploadImage uploads a 1024-byte graphics image to the display (ESC G + data).
 // The data must be exactly 1024 bytes in the correct format.
 // Use FrameBuffer.ToDeviceFormat() to get properly formatted data.
@@ -76,12 +93,14 @@ func (d *Device) UploadImage(data [1024]byte) error {
 	return d.Flush()
 }
 
-// ShowPage displays a previously uploaded graphics page (ESC P n).
+// ShowPage displays a page saved with SavePage (ESC P n). The number of
+// pages isn't documented, so callers pick it; see display.PageManager.
 func (d *Device) ShowPage(page byte) error {
 	return d.Write([]byte{ESC, cmdShowPage, page})
 }
 
-// SavePage saves the current screen to a page (ESC S n).
+// SavePage saves the current screen to a page (ESC S n). Like ShowPage it
+// is buffered until Flush.
 func (d *Device) SavePage(page byte) error {
 	return d.Write([]byte{ESC, cmdSavePage, page})
 }
@@ -0,0 +1,54 @@
+package eziog500
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestDevice returns a device backed by a temporary file instead of a
+// serial port, plus a function that flushes and returns everything written.
+// Other packages use internal/testutil, which imports this one, so its
+// tests keep this copy.
+func newTestDevice(t *testing.T) (*Device, func() []byte) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "lcd")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := OpenWithoutStty(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetCommandDelay(0)
+	t.Cleanup(func() { d.Close() })
+
+	written := func() []byte {
+		if err := d.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+	return d, written
+}
+
+func TestDevice_SetContrast(t *testing.T) {
+	d, written := newTestDevice(t)
+
+	if err := d.SetContrast(0x80); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.SetContrastRaw(0x63, 0x10); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{ESC, cmdContrast, 0x80, ESC, 0x63, 0x10}
+	if got := written(); !bytes.Equal(got, want) {
+		t.Errorf("Expected % 02X, got % 02X", want, got)
+	}
+}
@@ -29,10 +29,17 @@ var Verbose = false
 // Device represents a connection to an EZIO-G500 LCD display.
 type Device struct {
 	portPath     string
-	port         *os.File // Direct file handle to serial port
+	port         serialPort // Direct handle to serial port; nil while reconnecting
 	mu           sync.Mutex
 	commandDelay time.Duration
 	buffer       bytes.Buffer // Buffer to collect data to send
+
+	reconnect // Reopens the port after I/O failures
+}
+
+// serialPort is the subset of *os.File the device uses.
+type serialPort interface {
+	io.ReadWriteCloser
 }
 
 // SetVerbose enables or disables verbose debug output globally
@@ -52,47 +59,59 @@ func debugf(format string, args ...interface{}) {
 func Open(portPath string) (*Device, error) {
 	debugf("Opening port: %s at %d baud", portPath, DefaultBaudRate)
 
-	// Configure serial port using stty (one-time setup)
-	cmd := exec.Command("stty", "-f", portPath, fmt.Sprintf("%d", DefaultBaudRate), "cs8", "-cstopb", "-parenb", "raw", "-echo")
-	if err := cmd.Run(); err != nil {
-		debugf("stty failed: %v (continuing anyway)", err)
-	}
+	return newDevice(portPath, func() (serialPort, error) {
+		return openSerial(portPath, true)
+	})
+}
 
-	// Open the serial port directly
-	port, err := os.OpenFile(portPath, os.O_RDWR, 0)
+// OpenWithoutStty opens without stty configuration (for testing)
+func OpenWithoutStty(portPath string) (*Device, error) {
+	return newDevice(portPath, func() (serialPort, error) {
+		return openSerial(portPath, false)
+	})
+}
+
+// newDevice opens the port with open and keeps it for reconnecting.
+func newDevice(portPath string, open func() (serialPort, error)) (*Device, error) {
+	port, err := open()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open serial port: %w", err)
+		return nil, err
 	}
 
-	d := &Device{
+	return &Device{
 		portPath:     portPath,
 		port:         port,
 		commandDelay: DefaultCommandDelay,
-	}
-
-	return d, nil
+		reconnect:    reconnect{open: open, now: time.Now},
+	}, nil
 }
 
-// OpenWithoutStty opens without stty configuration (for testing)
-func OpenWithoutStty(portPath string) (*Device, error) {
+// openSerial opens the serial port, configuring it with stty first if asked.
+func openSerial(portPath string, configure bool) (serialPort, error) {
+	if configure {
+		// Configure serial port using stty
+		cmd := exec.Command("stty", "-f", portPath, fmt.Sprintf("%d", DefaultBaudRate), "cs8", "-cstopb", "-parenb", "raw", "-echo")
+		if err := cmd.Run(); err != nil {
+			debugf("stty failed: %v (continuing anyway)", err)
+		}
+	}
+
+	// Open the serial port directly
 	port, err := os.OpenFile(portPath, os.O_RDWR, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open serial port: %w", err)
 	}
-
-	return &Device{
-		portPath:     portPath,
-		port:         port,
-		commandDelay: DefaultCommandDelay,
-	}, nil
+	return port, nil
 }
 
-// Close closes the connection to the display.
+// Close closes the connection to the display. A closed device no longer
+// tries to reconnect.
 func (d *Device) Close() error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	debugf("Closing port")
+	d.closed = true
 
 	// Flush any remaining data
 	if d.buffer.Len() > 0 {
@@ -115,8 +134,10 @@ func (d *Device) flushDirect() error {
 		return nil
 	}
 
-	if d.port == nil {
-		return fmt.Errorf("serial port not open")
+	if err := d.ensureConnected(); err != nil {
+		// Drop the stale frame; the caller redraws after reconnecting
+		d.buffer.Reset()
+		return err
 	}
 
 	data := d.buffer.Bytes()
@@ -134,11 +155,15 @@ func (d *Device) flushDirect() error {
 	// Write directly to the serial port
 	_, err := d.port.Write(data)
 	if err != nil {
+		d.buffer.Reset()
+		d.disconnect(err)
 		return fmt.Errorf("failed to write to serial port: %w", err)
 	}
 
 	// Sync to ensure data is sent
-	d.port.Sync()
+	if s, ok := d.port.(interface{ Sync() error }); ok {
+		s.Sync()
+	}
 
 	// Clear the buffer
 	d.buffer.Reset()
@@ -181,19 +206,41 @@ func (d *Device) Write(data []byte) error {
 	return nil
 }
 
-// Flush sends all buffered data immediately
+// Flush sends all buffered data immediately. If the port has failed, Flush
+// reconnects first once the backoff delay has passed, and otherwise returns
+// ErrDisconnected, discarding the buffered data.
 func (d *Device) Flush() error {
 	d.mu.Lock()
-	defer d.mu.Unlock()
-	return d.flushDirect()
+	err := d.flushDirect()
+	d.unlockAndNotify()
+	return err
 }
 
-// Read reads bytes from the display (for button input).
+// Read reads bytes from the display (for button input). Read errors other
+// than io.EOF mark the port as failed, like write errors.
 func (d *Device) Read(buf []byte) (int, error) {
-	if d.port == nil {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
 		return 0, io.EOF
 	}
-	return d.port.Read(buf)
+	err := d.ensureConnected()
+	port := d.port
+	d.unlockAndNotify()
+	if err != nil {
+		return 0, err
+	}
+
+	// Read without the lock so a blocking read doesn't stall display updates
+	n, err := port.Read(buf)
+	if err != nil && err != io.EOF {
+		d.mu.Lock()
+		if d.port == port {
+			d.disconnect(err)
+		}
+		d.unlockAndNotify()
+	}
+	return n, err
 }
 
 // PortPath returns the serial port path.
@@ -202,10 +249,9 @@ func (d *Device) PortPath() string {
 }
 
 // PersistentSession represents a long-running session for bidirectional I/O.
-// Now uses direct file I/O instead of spawning cu processes.
+// Its I/O goes through the Device, so it follows the port across reconnects.
 type PersistentSession struct {
-	port *os.File
-	mu   sync.Mutex
+	device *Device
 }
 
 // StartSession starts a persistent session for bidirectional communication.
@@ -213,47 +259,32 @@ func (d *Device) StartSession() (*PersistentSession, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	debugf("Starting persistent session on %s", d.portPath)
-
-	// Use the existing port if available, otherwise open a new one
-	if d.port != nil {
-		return &PersistentSession{port: d.port}, nil
-	}
-
-	// Configure serial port
-	cmd := exec.Command("stty", "-f", d.portPath, fmt.Sprintf("%d", DefaultBaudRate), "cs8", "-cstopb", "-parenb", "raw", "-echo")
-	if err := cmd.Run(); err != nil {
-		debugf("stty failed: %v", err)
-	}
-
-	port, err := os.OpenFile(d.portPath, os.O_RDWR, 0)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open serial port: %w", err)
+	if d.closed {
+		return nil, fmt.Errorf("serial port not open")
 	}
-
-	debugf("Persistent session started")
-	return &PersistentSession{port: port}, nil
+	debugf("Starting persistent session on %s", d.portPath)
+	return &PersistentSession{device: d}, nil
 }
 
 // Write sends data to the display via the persistent session.
 func (ps *PersistentSession) Write(data []byte) (int, error) {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-	return ps.port.Write(data)
+	if err := ps.device.Write(data); err != nil {
+		return 0, err
+	}
+	if err := ps.device.Flush(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
 }
 
 // Read reads data from the display (button presses).
 func (ps *PersistentSession) Read(buf []byte) (int, error) {
-	return ps.port.Read(buf)
+	return ps.device.Read(buf)
 }
 
-// Close ends the persistent session.
+// Close ends the persistent session. The port belongs to the Device, so it
+// stays open until Device.Close.
 func (ps *PersistentSession) Close() error {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
 	debugf("Closing persistent session")
-	// Don't close the port here - it may be shared with the Device
-	// The Device.Close() will handle port cleanup
 	return nil
 }
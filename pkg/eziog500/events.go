@@ -0,0 +1,234 @@
+package eziog500
+
+import (
+	"time"
+)
+
+// ButtonEventKind says whether a button event is a new press or comes from
+// a button being held down.
+type ButtonEventKind int
+
+const (
+	Press      ButtonEventKind = iota // The button went down
+	Repeat                            // Auto-repeat while held past RepeatDelay
+	LongPress                         // Held for LongPress; sent once per hold
+	ChordPress                        // Two buttons of a Chord pressed together
+)
+
+// String returns the event kind name.
+func (k ButtonEventKind) String() string {
+	switch k {
+	case Press:
+		return "press"
+	case Repeat:
+		return "repeat"
+	case LongPress:
+		return "long-press"
+	case ChordPress:
+		return "chord"
+	default:
+		return "unknown"
+	}
+}
+
+// ButtonEvent is a button press or a held button.
+type ButtonEvent struct {
+	Button Button
+	Kind   ButtonEventKind
+	With   Button // The other button of a ChordPress
+}
+
+// Chord is a pair of buttons that can be pressed together for a ChordPress
+// event. The order doesn't matter.
+type Chord [2]Button
+
+// has reports whether b is one of the chord's buttons.
+func (c Chord) has(b Button) bool {
+	return c[0] == b || c[1] == b
+}
+
+// DefaultDebounce is the debounce window used by the command-line tools.
+const DefaultDebounce = 30 * time.Millisecond
+
+// ButtonTiming controls how held buttons turn into events. The panel keeps
+// sending a button's code while it is held, so a hold is a run of the same
+// code with no gap longer than Release.
+type ButtonTiming struct {
+	RepeatDelay    time.Duration // Hold time before the first Repeat
+	RepeatInterval time.Duration // Time between Repeats
+	LongPress      time.Duration // Hold time before the LongPress
+	Release        time.Duration // Gap after which the button counts as released
+	Debounce       time.Duration // Repeated codes closer than this are one press
+	ChordWindow    time.Duration // Max time between the two presses of a chord
+}
+
+// DefaultButtonTiming is the timing used by new button readers.
+var DefaultButtonTiming = ButtonTiming{
+	RepeatDelay:    500 * time.Millisecond,
+	RepeatInterval: 100 * time.Millisecond,
+	LongPress:      time.Second,
+	Release:        150 * time.Millisecond,
+	Debounce:       DefaultDebounce,
+	ChordWindow:    100 * time.Millisecond,
+}
+
+// holdTracker turns a stream of button codes into events.
+type holdTracker struct {
+	timing     ButtonTiming
+	chords     []Chord
+	held       Button
+	pressedAt  time.Time
+	lastSeen   time.Time
+	lastRepeat time.Time // Zero until the first Repeat of this hold
+	longSent   bool
+	pending    bool   // held is a chord button whose Press waits for ChordWindow
+	chord      *Chord // Chord being held, whose codes are swallowed
+}
+
+// feed records that b was received at now and returns the events it
+// produces, if any.
+func (t *holdTracker) feed(b Button, now time.Time) []ButtonEvent {
+	if b == ButtonNone {
+		return nil
+	}
+	events := t.flush(now)
+
+	// While a chord is held the panel sends both codes
+	if t.chord != nil {
+		if t.chord.has(b) && now.Sub(t.lastSeen) <= t.timing.Release {
+			t.lastSeen = now
+			return events
+		}
+		t.chord = nil
+		t.held = ButtonNone
+	}
+
+	if t.pending && b != t.held {
+		t.pending = false
+		for i, c := range t.chords {
+			if c.has(t.held) && c.has(b) {
+				events = append(events, ButtonEvent{Button: t.held, Kind: ChordPress, With: b})
+				t.chord = &t.chords[i]
+				t.held = ButtonNone
+				t.lastSeen = now
+				return events
+			}
+		}
+		events = append(events, ButtonEvent{Button: t.held, Kind: Press})
+	}
+
+	if b != t.held || now.Sub(t.lastSeen) > max(t.timing.Release, t.timing.Debounce) {
+		t.held = b
+		t.pressedAt, t.lastSeen = now, now
+		t.lastRepeat = time.Time{}
+		t.longSent = false
+		if t.inChord(b) {
+			t.pending = true
+			return events
+		}
+		return append(events, ButtonEvent{Button: b, Kind: Press})
+	}
+	t.lastSeen = now
+	if t.pending {
+		return events
+	}
+
+	held := now.Sub(t.pressedAt)
+	if !t.longSent && held >= t.timing.LongPress {
+		t.longSent = true
+		events = append(events, ButtonEvent{Button: b, Kind: LongPress})
+	}
+	due := t.pressedAt.Add(t.timing.RepeatDelay)
+	if !t.lastRepeat.IsZero() {
+		due = t.lastRepeat.Add(t.timing.RepeatInterval)
+	}
+	if !now.Before(due) {
+		t.lastRepeat = now
+		events = append(events, ButtonEvent{Button: b, Kind: Repeat})
+	}
+	return events
+}
+
+// flush sends a pending chord button's Press once its partner can no longer
+// arrive in time.
+func (t *holdTracker) flush(now time.Time) []ButtonEvent {
+	if !t.pending || now.Sub(t.pressedAt) <= t.timing.ChordWindow {
+		return nil
+	}
+	t.pending = false
+	return []ButtonEvent{{Button: t.held, Kind: Press}}
+}
+
+// inChord reports whether b belongs to a chord, so its Press has to wait.
+func (t *holdTracker) inChord(b Button) bool {
+	if t.timing.ChordWindow <= 0 {
+		return false
+	}
+	for _, c := range t.chords {
+		if c.has(b) {
+			return true
+		}
+	}
+	return false
+}
+
+// debouncer drops a button code repeated within the debounce window of the
+// last one it let through.
+type debouncer struct {
+	window time.Duration
+	last   Button
+	at     time.Time
+}
+
+// accept reports whether b, received at now, is a new press.
+func (d *debouncer) accept(b Button, now time.Time) bool {
+	if b == d.last && now.Sub(d.at) < d.window {
+		return false
+	}
+	d.last, d.at = b, now
+	return true
+}
+
+// buttonEvents polls read for button codes and sends the resulting events
+// until the returned stop function is called.
+func buttonEvents(read func([]byte) (int, error), bufSize int, timing ButtonTiming, chords []Chord) (<-chan ButtonEvent, func()) {
+	ch := make(chan ButtonEvent, 10)
+	stop := make(chan struct{})
+	tracker := &holdTracker{timing: timing, chords: chords}
+
+	go func() {
+		defer close(ch)
+		buf := make([]byte, bufSize)
+
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				n, err := read(buf)
+				now := time.Now()
+				events := tracker.flush(now)
+				if err == nil && n > 0 {
+					for i := 0; i < n; i++ {
+						events = append(events, tracker.feed(Button(buf[i]), now)...)
+					}
+				}
+				for _, ev := range events {
+					debugf("Button event: %s %s", ev.Button, ev.Kind)
+					select {
+					case ch <- ev:
+					default:
+						// Channel full, drop
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+		}
+	}()
+
+	stopper := func() {
+		close(stop)
+	}
+
+	return ch, stopper
+}
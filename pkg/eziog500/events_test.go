@@ -0,0 +1,196 @@
+package eziog500
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// hold feeds b to the tracker every step for d, starting at start, and
+// returns the events and the time after the last code.
+func hold(tr *holdTracker, b Button, start time.Time, d, step time.Duration) ([]ButtonEvent, time.Time) {
+	var events []ButtonEvent
+	now := start
+	for ; now.Sub(start) <= d; now = now.Add(step) {
+		events = append(events, tr.feed(b, now)...)
+	}
+	return events, now
+}
+
+func TestHoldTracker(t *testing.T) {
+	timing := ButtonTiming{
+		RepeatDelay:    500 * time.Millisecond,
+		RepeatInterval: 100 * time.Millisecond,
+		LongPress:      time.Second,
+		Release:        150 * time.Millisecond,
+	}
+	press := func(b Button) ButtonEvent { return ButtonEvent{Button: b, Kind: Press} }
+	repeat := func(b Button) ButtonEvent { return ButtonEvent{Button: b, Kind: Repeat} }
+	long := func(b Button) ButtonEvent { return ButtonEvent{Button: b, Kind: LongPress} }
+
+	tests := []struct {
+		name string
+		run  func(tr *holdTracker) []ButtonEvent
+		want []ButtonEvent
+	}{
+		{
+			name: "tap",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, _ := hold(tr, ButtonDown, time.Unix(0, 0), 100*time.Millisecond, 50*time.Millisecond)
+				return ev
+			},
+			want: []ButtonEvent{press(ButtonDown)},
+		},
+		{
+			name: "held past repeat delay",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, _ := hold(tr, ButtonDown, time.Unix(0, 0), 700*time.Millisecond, 50*time.Millisecond)
+				return ev
+			},
+			// Repeats at 500, 600, and 700ms
+			want: []ButtonEvent{press(ButtonDown), repeat(ButtonDown), repeat(ButtonDown), repeat(ButtonDown)},
+		},
+		{
+			name: "long press",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, _ := hold(tr, ButtonEsc, time.Unix(0, 0), 1200*time.Millisecond, 50*time.Millisecond)
+				return ev
+			},
+			want: []ButtonEvent{
+				press(ButtonEsc),
+				repeat(ButtonEsc), repeat(ButtonEsc), repeat(ButtonEsc), repeat(ButtonEsc), repeat(ButtonEsc), // 500-900ms
+				long(ButtonEsc), repeat(ButtonEsc), // 1000ms
+				repeat(ButtonEsc), repeat(ButtonEsc), // 1100, 1200ms
+			},
+		},
+		{
+			name: "release gap starts a new press",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, end := hold(tr, ButtonUp, time.Unix(0, 0), 400*time.Millisecond, 50*time.Millisecond)
+				more, _ := hold(tr, ButtonUp, end.Add(200*time.Millisecond), 400*time.Millisecond, 50*time.Millisecond)
+				return append(ev, more...)
+			},
+			want: []ButtonEvent{press(ButtonUp), press(ButtonUp)},
+		},
+		{
+			name: "different button starts a new press",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, end := hold(tr, ButtonUp, time.Unix(0, 0), 400*time.Millisecond, 50*time.Millisecond)
+				more, _ := hold(tr, ButtonDown, end, 0, 50*time.Millisecond)
+				return append(ev, more...)
+			},
+			want: []ButtonEvent{press(ButtonUp), press(ButtonDown)},
+		},
+		{
+			name: "none is ignored",
+			run: func(tr *holdTracker) []ButtonEvent {
+				ev, _ := hold(tr, ButtonNone, time.Unix(0, 0), time.Second, 50*time.Millisecond)
+				return ev
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := tt.run(&holdTracker{timing: timing})
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHoldTracker_Debounce(t *testing.T) {
+	tr := &holdTracker{timing: ButtonTiming{
+		RepeatDelay:    500 * time.Millisecond,
+		RepeatInterval: 100 * time.Millisecond,
+		LongPress:      time.Second,
+		Release:        20 * time.Millisecond,
+		Debounce:       50 * time.Millisecond,
+	}}
+	ms := func(n int) time.Time { return time.Unix(0, 0).Add(time.Duration(n) * time.Millisecond) }
+
+	var got []ButtonEvent
+	for _, c := range []struct {
+		b  Button
+		at int
+	}{
+		{ButtonDown, 0}, {ButtonDown, 1}, {ButtonDown, 40}, // Bounce: one press
+		{ButtonDown, 200},                  // Distinct press
+		{ButtonUp, 210}, {ButtonDown, 220}, // Different buttons always pass
+	} {
+		got = append(got, tr.feed(c.b, ms(c.at))...)
+	}
+
+	want := []ButtonEvent{
+		{Button: ButtonDown, Kind: Press},
+		{Button: ButtonDown, Kind: Press},
+		{Button: ButtonUp, Kind: Press},
+		{Button: ButtonDown, Kind: Press},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestDebouncer(t *testing.T) {
+	d := &debouncer{window: 50 * time.Millisecond}
+	start := time.Unix(0, 0)
+
+	var accepted []Button
+	for _, c := range []struct {
+		b  Button
+		at time.Duration
+	}{
+		{ButtonEnter, 0}, {ButtonEnter, 5 * time.Millisecond}, {ButtonEnter, 10 * time.Millisecond},
+		{ButtonEsc, 15 * time.Millisecond},
+		{ButtonEnter, 20 * time.Millisecond}, // Different from the last accepted
+		{ButtonEnter, 100 * time.Millisecond},
+	} {
+		if d.accept(c.b, start.Add(c.at)) {
+			accepted = append(accepted, c.b)
+		}
+	}
+
+	want := []Button{ButtonEnter, ButtonEsc, ButtonEnter, ButtonEnter}
+	if !reflect.DeepEqual(accepted, want) {
+		t.Errorf("accepted %v, want %v", accepted, want)
+	}
+}
+
+func TestHoldTracker_Chord(t *testing.T) {
+	tr := &holdTracker{
+		timing: ButtonTiming{
+			RepeatDelay:    500 * time.Millisecond,
+			RepeatInterval: 100 * time.Millisecond,
+			LongPress:      time.Second,
+			Release:        150 * time.Millisecond,
+			ChordWindow:    100 * time.Millisecond,
+		},
+		chords: []Chord{{ButtonUp, ButtonDown}},
+	}
+	ms := func(n int) time.Time { return time.Unix(0, 0).Add(time.Duration(n) * time.Millisecond) }
+
+	var got []ButtonEvent
+	// Up and Down held together: the panel alternates their codes
+	for at := 0; at <= 300; at += 20 {
+		b := ButtonUp
+		if at%40 != 0 {
+			b = ButtonDown
+		}
+		got = append(got, tr.feed(b, ms(at))...)
+	}
+	// Later, Down alone waits out the chord window before its Press
+	got = append(got, tr.feed(ButtonDown, ms(1000))...)
+	got = append(got, tr.flush(ms(1050))...)
+	got = append(got, tr.flush(ms(1101))...)
+	// Enter isn't part of a chord and isn't delayed
+	got = append(got, tr.feed(ButtonEnter, ms(1200))...)
+
+	want := []ButtonEvent{
+		{Button: ButtonUp, Kind: ChordPress, With: ButtonDown},
+		{Button: ButtonDown, Kind: Press},
+		{Button: ButtonEnter, Kind: Press},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
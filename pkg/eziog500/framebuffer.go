@@ -1,5 +1,12 @@
 package eziog500
 
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+)
+
 // FrameBuffer represents a 128x64 pixel graphics buffer for the EZIO-G500 display.
 //
 // The EZIO-G500 uses vertical byte encoding:
@@ -15,6 +22,11 @@ type FrameBuffer struct {
 	// data stores pixels in a simple linear format for manipulation
 	// Organized as [y][x] where y is 0-63 and x is 0-127
 	data [64][128]bool
+
+	// Clip rectangle as [x0, x1) x [y0, y1), active if clipped is set
+	clipped        bool
+	clipX0, clipY0 int
+	clipX1, clipY1 int
 }
 
 // Width of the display in pixels.
@@ -49,10 +61,41 @@ func (fb *FrameBuffer) Fill() {
 	}
 }
 
+// SetClip limits drawing to the w x h rectangle at (x, y) until ClearClip
+// is called. It applies to everything drawn pixel by pixel; Clear, Fill,
+// and InvertAll still cover the whole buffer.
+func (fb *FrameBuffer) SetClip(x, y, w, h int) {
+	fb.clipped = true
+	fb.clipX0, fb.clipY0 = x, y
+	fb.clipX1, fb.clipY1 = x+w, y+h
+}
+
+// ClearClip removes the clip rectangle.
+func (fb *FrameBuffer) ClearClip() {
+	fb.clipped = false
+}
+
+// Clip returns the clip rectangle, or ok false if none is set.
+func (fb *FrameBuffer) Clip() (x, y, w, h int, ok bool) {
+	if !fb.clipped {
+		return 0, 0, Width, Height, false
+	}
+	return fb.clipX0, fb.clipY0, fb.clipX1 - fb.clipX0, fb.clipY1 - fb.clipY0, true
+}
+
+// drawable reports whether (x, y) is on the display and inside the clip
+// rectangle.
+func (fb *FrameBuffer) drawable(x, y int) bool {
+	if x < 0 || x >= Width || y < 0 || y >= Height {
+		return false
+	}
+	return !fb.clipped || (x >= fb.clipX0 && x < fb.clipX1 && y >= fb.clipY0 && y < fb.clipY1)
+}
+
 // SetPixel sets a pixel at (x, y) to on or off.
-// Coordinates are clipped to display bounds.
+// Coordinates are clipped to display bounds and the clip rectangle.
 func (fb *FrameBuffer) SetPixel(x, y int, on bool) {
-	if x < 0 || x >= Width || y < 0 || y >= Height {
+	if !fb.drawable(x, y) {
 		return
 	}
 	fb.data[y][x] = on
@@ -69,7 +112,7 @@ func (fb *FrameBuffer) GetPixel(x, y int) bool {
 
 // Invert toggles the state of a pixel at (x, y).
 func (fb *FrameBuffer) Invert(x, y int) {
-	if x < 0 || x >= Width || y < 0 || y >= Height {
+	if !fb.drawable(x, y) {
 		return
 	}
 	fb.data[y][x] = !fb.data[y][x]
@@ -84,6 +127,24 @@ func (fb *FrameBuffer) InvertAll() {
 	}
 }
 
+// CountSetPixels returns the number of pixels that are on.
+func (fb *FrameBuffer) CountSetPixels() int {
+	count := 0
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			if fb.data[y][x] {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// Coverage returns the fraction of pixels that are on (0.0 to 1.0).
+func (fb *FrameBuffer) Coverage() float64 {
+	return float64(fb.CountSetPixels()) / float64(Width*Height)
+}
+
 // ToDeviceFormat converts the framebuffer to the EZIO-G500 wire format.
 //
 // The wire format is:
@@ -187,9 +248,46 @@ func (fb *FrameBuffer) Copy() *FrameBuffer {
 			newFB.data[y][x] = fb.data[y][x]
 		}
 	}
+	newFB.clipped = fb.clipped
+	newFB.clipX0, newFB.clipY0 = fb.clipX0, fb.clipY0
+	newFB.clipX1, newFB.clipY1 = fb.clipX1, fb.clipY1
 	return newFB
 }
 
+// String draws the framebuffer as text for quick inspection: 64 lines of
+// 128 characters, a full block for each lit pixel and a space otherwise.
+func (fb *FrameBuffer) String() string {
+	var sb strings.Builder
+	sb.Grow(Height * (Width*len("█") + 1))
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			if fb.data[y][x] {
+				sb.WriteString("█")
+			} else {
+				sb.WriteByte(' ')
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Equal reports whether fb and other have the same pixels. The clip
+// rectangles aren't compared.
+func (fb *FrameBuffer) Equal(other *FrameBuffer) bool {
+	return fb.data == other.data
+}
+
+// Hash returns an FNV-1a hash of the device-format pixels, so equal
+// buffers hash equal. Use it to tell whether a frame changed without
+// keeping a copy of the last one.
+func (fb *FrameBuffer) Hash() uint64 {
+	data := fb.ToDeviceFormat()
+	h := fnv.New64a()
+	h.Write(data[:])
+	return h.Sum64()
+}
+
 // DrawHLine draws a horizontal line from (x1, y) to (x2, y).
 func (fb *FrameBuffer) DrawHLine(x1, x2, y int, on bool) {
 	if x1 > x2 {
@@ -288,6 +386,56 @@ func (fb *FrameBuffer) DrawCircle(cx, cy, r int, on bool) {
 	}
 }
 
+// DrawArc draws part of a circle outline from startDeg to endDeg, sweeping
+// clockwise. Angles are in degrees with 0 pointing right and 90 pointing
+// down (screen coordinates), so DrawArc(cx, cy, r, 180, 360, on) draws the
+// top half. A sweep of 360 or more draws the full circle.
+func (fb *FrameBuffer) DrawArc(cx, cy, r int, startDeg, endDeg float64, on bool) {
+	sweep := endDeg - startDeg
+	if sweep >= 360 {
+		fb.DrawCircle(cx, cy, r, on)
+		return
+	}
+	start := math.Mod(startDeg, 360)
+	if start < 0 {
+		start += 360
+	}
+	sweep = math.Mod(sweep, 360)
+	if sweep < 0 {
+		sweep += 360
+	}
+
+	plot := func(dx, dy int) {
+		a := math.Atan2(float64(dy), float64(dx)) * 180 / math.Pi
+		offset := math.Mod(a-start+720, 360)
+		if offset <= sweep {
+			fb.SetPixel(cx+dx, cy+dy, on)
+		}
+	}
+
+	// Midpoint circle, keeping only points inside the sweep
+	x := r
+	y := 0
+	err := 0
+	for x >= y {
+		plot(x, y)
+		plot(y, x)
+		plot(-y, x)
+		plot(-x, y)
+		plot(-x, -y)
+		plot(-y, -x)
+		plot(y, -x)
+		plot(x, -y)
+
+		y++
+		err += 1 + 2*y
+		if 2*(err-x)+1 > 0 {
+			x--
+			err += 1 - 2*x
+		}
+	}
+}
+
 // FillCircle fills a circle.
 func (fb *FrameBuffer) FillCircle(cx, cy, r int, on bool) {
 	for y := -r; y <= r; y++ {
@@ -352,3 +500,147 @@ func (fb *FrameBuffer) DrawTriangle(x1, y1, x2, y2, x3, y3 int, on bool) {
 	fb.DrawLine(x2, y2, x3, y3, on)
 	fb.DrawLine(x3, y3, x1, y1, on)
 }
+
+// FillTriangle fills a triangle.
+func (fb *FrameBuffer) FillTriangle(x1, y1, x2, y2, x3, y3 int, on bool) {
+	fb.FillTriangleFunc(x1, y1, x2, y2, x3, y3, func(x, y int) bool { return on })
+}
+
+// FillTriangleFunc fills a triangle, setting each covered pixel to
+// pixel(x, y). This allows patterned or dithered fills.
+func (fb *FrameBuffer) FillTriangleFunc(x1, y1, x2, y2, x3, y3 int, pixel func(x, y int) bool) {
+	// Edge function: twice the signed area of (a, b, p)
+	edge := func(ax, ay, bx, by, px, py int) int {
+		return (bx-ax)*(py-ay) - (by-ay)*(px-ax)
+	}
+	area := edge(x1, y1, x2, y2, x3, y3)
+	if area == 0 {
+		return // Degenerate triangles cover no area
+	}
+
+	minX, maxX := min(x1, x2, x3), max(x1, x2, x3)
+	minY, maxY := min(y1, y2, y3), max(y1, y2, y3)
+	minX, maxX = max(minX, 0), min(maxX, Width-1)
+	minY, maxY = max(minY, 0), min(maxY, Height-1)
+
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			w1 := edge(x2, y2, x3, y3, x, y)
+			w2 := edge(x3, y3, x1, y1, x, y)
+			w3 := edge(x1, y1, x2, y2, x, y)
+			// Inside when all weights share the triangle's winding sign
+			if (area > 0 && w1 >= 0 && w2 >= 0 && w3 >= 0) ||
+				(area < 0 && w1 <= 0 && w2 <= 0 && w3 <= 0) {
+				fb.SetPixel(x, y, pixel(x, y))
+			}
+		}
+	}
+}
+
+// DrawPolygon draws a closed polygon outline through the given points.
+func (fb *FrameBuffer) DrawPolygon(points [][2]int, on bool) {
+	if len(points) == 1 {
+		fb.SetPixel(points[0][0], points[0][1], on)
+		return
+	}
+	for i, p := range points {
+		q := points[(i+1)%len(points)]
+		fb.DrawLine(p[0], p[1], q[0], q[1], on)
+	}
+}
+
+// FillPolygon fills a polygon using the even-odd rule, so self-intersecting
+// shapes such as stars get hollow centres. The outline is included, matching
+// DrawPolygon.
+func (fb *FrameBuffer) FillPolygon(points [][2]int, on bool) {
+	if len(points) < 3 {
+		fb.DrawPolygon(points, on)
+		return
+	}
+
+	minY, maxY := points[0][1], points[0][1]
+	for _, p := range points[1:] {
+		minY, maxY = min(minY, p[1]), max(maxY, p[1])
+	}
+	minY, maxY = max(minY, 0), min(maxY, Height-1)
+
+	var xs []int
+	for y := minY; y <= maxY; y++ {
+		// Intersect the scanline through pixel centres with every edge.
+		// Half-open spans avoid counting a shared vertex twice.
+		xs = xs[:0]
+		cy := float64(y) + 0.5
+		for i, p := range points {
+			q := points[(i+1)%len(points)]
+			y1, y2 := float64(p[1]), float64(q[1])
+			if (y1 <= cy) == (y2 <= cy) {
+				continue
+			}
+			t := (cy - y1) / (y2 - y1)
+			x := float64(p[0]) + t*float64(q[0]-p[0])
+			xs = append(xs, int(math.Round(x)))
+		}
+		sort.Ints(xs)
+
+		for i := 0; i+1 < len(xs); i += 2 {
+			x1, x2 := max(xs[i], 0), min(xs[i+1], Width-1)
+			for x := x1; x <= x2; x++ {
+				fb.SetPixel(x, y, on)
+			}
+		}
+	}
+
+	fb.DrawPolygon(points, on)
+}
+
+// FloodFill sets the 4-connected region around (x, y) that shares the seed
+// pixel's value to on. It uses an explicit queue, so filling the whole
+// display can't overflow the stack.
+func (fb *FrameBuffer) FloodFill(x, y int, on bool) {
+	if !fb.drawable(x, y) {
+		return
+	}
+	target := fb.data[y][x]
+	if target == on {
+		return // Already filled; nothing would change
+	}
+
+	queue := [][2]int{{x, y}}
+	fb.data[y][x] = on
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, d := range [4][2]int{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+			nx, ny := p[0]+d[0], p[1]+d[1]
+			if !fb.drawable(nx, ny) || fb.data[ny][nx] != target {
+				continue
+			}
+			fb.data[ny][nx] = on
+			queue = append(queue, [2]int{nx, ny})
+		}
+	}
+}
+
+// FlipHorizontal mirrors the framebuffer left to right in place.
+func (fb *FrameBuffer) FlipHorizontal() {
+	for y := 0; y < Height; y++ {
+		row := &fb.data[y]
+		for x := 0; x < Width/2; x++ {
+			row[x], row[Width-1-x] = row[Width-1-x], row[x]
+		}
+	}
+}
+
+// FlipVertical mirrors the framebuffer top to bottom in place.
+func (fb *FrameBuffer) FlipVertical() {
+	for y := 0; y < Height/2; y++ {
+		fb.data[y], fb.data[Height-1-y] = fb.data[Height-1-y], fb.data[y]
+	}
+}
+
+// Rotate180 rotates the framebuffer by 180 degrees in place, for displays
+// mounted upside-down.
+func (fb *FrameBuffer) Rotate180() {
+	fb.FlipHorizontal()
+	fb.FlipVertical()
+}
@@ -1,7 +1,9 @@
 package eziog500
 
 import (
+	"strings"
 	"testing"
+	"unicode/utf8"
 )
 
 func TestFrameBuffer_SetGetPixel(t *testing.T) {
@@ -83,6 +85,29 @@ func TestFrameBuffer_Invert(t *testing.T) {
 	}
 }
 
+func TestFrameBuffer_CountSetPixels(t *testing.T) {
+	fb := NewFrameBuffer()
+
+	if fb.CountSetPixels() != 0 || fb.Coverage() != 0 {
+		t.Error("Empty framebuffer should have no set pixels")
+	}
+
+	// Fill the left half
+	fb.FillRect(0, 0, Width/2, Height, true)
+
+	if n := fb.CountSetPixels(); n != Width*Height/2 {
+		t.Errorf("Expected %d set pixels, got %d", Width*Height/2, n)
+	}
+	if c := fb.Coverage(); c < 0.499 || c > 0.501 {
+		t.Errorf("Expected coverage ~0.5, got %f", c)
+	}
+
+	fb.Fill()
+	if fb.Coverage() != 1 {
+		t.Errorf("Full framebuffer should have coverage 1, got %f", fb.Coverage())
+	}
+}
+
 func TestFrameBuffer_ToDeviceFormat(t *testing.T) {
 	fb := NewFrameBuffer()
 
@@ -206,6 +231,35 @@ func TestFrameBuffer_FillRect(t *testing.T) {
 	}
 }
 
+func TestFrameBuffer_Clip(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.SetClip(10, 20, 30, 15)
+
+	fb.FillRect(0, 0, Width, Height, true)
+	if got, want := fb.CountSetPixels(), 30*15; got != want {
+		t.Errorf("Expected %d pixels set inside the clip, got %d", want, got)
+	}
+	if !fb.GetPixel(10, 20) || !fb.GetPixel(39, 34) {
+		t.Error("Clip corners should be filled")
+	}
+	if fb.GetPixel(9, 20) || fb.GetPixel(40, 20) || fb.GetPixel(10, 19) || fb.GetPixel(10, 35) {
+		t.Error("Pixels outside the clip should stay off")
+	}
+
+	// Flood fill stops at the clip edge too
+	fb.Clear()
+	fb.FloodFill(15, 25, true)
+	if got := fb.CountSetPixels(); got != 30*15 {
+		t.Errorf("Expected flood fill to cover only the clip, got %d pixels", got)
+	}
+
+	fb.ClearClip()
+	fb.FillRect(0, 0, Width, Height, true)
+	if got := fb.CountSetPixels(); got != Width*Height {
+		t.Errorf("Expected a full fill after ClearClip, got %d pixels", got)
+	}
+}
+
 func TestFrameBuffer_DrawLine(t *testing.T) {
 	fb := NewFrameBuffer()
 
@@ -230,6 +284,26 @@ func TestFrameBuffer_DrawLine(t *testing.T) {
 	}
 }
 
+func TestFrameBuffer_DrawArc(t *testing.T) {
+	fb := NewFrameBuffer()
+
+	// Top half only: 180° (left) through 270° (up) to 360° (right)
+	fb.DrawArc(64, 32, 10, 180, 360, true)
+	if !fb.GetPixel(54, 32) || !fb.GetPixel(64, 22) || !fb.GetPixel(74, 32) {
+		t.Error("Arc should include the left, top, and right points")
+	}
+	if fb.GetPixel(64, 42) {
+		t.Error("Arc should not include the bottom point")
+	}
+
+	// Sweeps past 360° wrap around: 315° to 45° covers the right side
+	fb.Clear()
+	fb.DrawArc(64, 32, 10, 315, 405, true)
+	if !fb.GetPixel(74, 32) || fb.GetPixel(54, 32) {
+		t.Error("Wrapped arc should include only the right side")
+	}
+}
+
 func TestFrameBuffer_Copy(t *testing.T) {
 	fb := NewFrameBuffer()
 	fb.SetPixel(50, 30, true)
@@ -244,3 +318,209 @@ func TestFrameBuffer_Copy(t *testing.T) {
 		t.Error("Copy should be independent of original")
 	}
 }
+
+func TestFrameBuffer_HashAndEqual(t *testing.T) {
+	a, b := NewFrameBuffer(), NewFrameBuffer()
+	a.DrawRect(10, 10, 20, 20, true)
+	b.DrawRect(10, 10, 20, 20, true)
+	if !a.Equal(b) || a.Hash() != b.Hash() {
+		t.Error("Expected identical buffers to be equal and hash equal")
+	}
+
+	b.SetPixel(60, 40, true)
+	if a.Equal(b) || a.Hash() == b.Hash() {
+		t.Error("Expected a one-pixel change to make buffers differ and hash differently")
+	}
+
+	// The clip rectangle isn't part of the picture
+	b.SetPixel(60, 40, false)
+	b.SetClip(0, 0, 8, 8)
+	if !a.Equal(b) || a.Hash() != b.Hash() {
+		t.Error("Expected the clip rectangle to be ignored")
+	}
+}
+
+func TestFrameBuffer_String(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.SetPixel(0, 0, true)
+	fb.SetPixel(127, 63, true)
+
+	lines := strings.Split(strings.TrimSuffix(fb.String(), "\n"), "\n")
+	if len(lines) != Height {
+		t.Fatalf("Got %d lines, want %d", len(lines), Height)
+	}
+	for i, line := range lines {
+		if n := utf8.RuneCountInString(line); n != Width {
+			t.Fatalf("Line %d has %d columns, want %d", i, n, Width)
+		}
+	}
+	if !strings.HasPrefix(lines[0], "█ ") || !strings.HasSuffix(lines[63], " █") {
+		t.Error("Expected lit corners drawn as blocks")
+	}
+}
+
+func TestFrameBuffer_FillTriangle(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.FillTriangle(0, 0, 9, 0, 0, 9, true)
+
+	// Right triangle with legs of 10 pixels covers 55 pixels
+	if got := fb.CountSetPixels(); got != 55 {
+		t.Errorf("Expected 55 pixels, got %d", got)
+	}
+	if !fb.GetPixel(2, 2) || fb.GetPixel(8, 8) {
+		t.Error("Expected (2,2) inside and (8,8) outside")
+	}
+
+	// Winding order doesn't matter
+	other := NewFrameBuffer()
+	other.FillTriangle(0, 9, 9, 0, 0, 0, true)
+	if other.ToDeviceFormat() != fb.ToDeviceFormat() {
+		t.Error("Expected both windings to fill the same pixels")
+	}
+
+	// Pattern fill: checkerboard covers about half
+	fb.Clear()
+	fb.FillTriangleFunc(0, 0, 9, 0, 0, 9, func(x, y int) bool { return (x+y)%2 == 0 })
+	if got := fb.CountSetPixels(); got == 0 || got >= 55 {
+		t.Errorf("Expected a partial pattern fill, got %d pixels", got)
+	}
+}
+
+func TestFrameBuffer_FillPolygon(t *testing.T) {
+	fb := NewFrameBuffer()
+	// Convex quadrilateral (a kite)
+	quad := [][2]int{{20, 5}, {35, 20}, {20, 40}, {5, 20}}
+	fb.FillPolygon(quad, true)
+
+	inside := [][2]int{{20, 20}, {20, 8}, {30, 20}, {10, 20}, {20, 35}}
+	for _, p := range inside {
+		if !fb.GetPixel(p[0], p[1]) {
+			t.Errorf("Expected (%d,%d) inside the polygon", p[0], p[1])
+		}
+	}
+	outside := [][2]int{{6, 6}, {34, 6}, {6, 38}, {34, 38}, {40, 20}, {20, 45}}
+	for _, p := range outside {
+		if fb.GetPixel(p[0], p[1]) {
+			t.Errorf("Expected (%d,%d) outside the polygon", p[0], p[1])
+		}
+	}
+	// Every vertex is covered by the outline
+	for _, p := range quad {
+		if !fb.GetPixel(p[0], p[1]) {
+			t.Errorf("Expected vertex (%d,%d) set", p[0], p[1])
+		}
+	}
+}
+
+func TestFrameBuffer_FillPolygon_EvenOdd(t *testing.T) {
+	fb := NewFrameBuffer()
+	// Five-pointed star drawn as one self-intersecting path
+	star := [][2]int{{64, 2}, {76, 60}, {34, 22}, {94, 22}, {52, 60}}
+	fb.FillPolygon(star, true)
+
+	if fb.GetPixel(64, 34) {
+		t.Error("Expected the star's centre to stay empty under even-odd")
+	}
+	if !fb.GetPixel(64, 12) {
+		t.Error("Expected the top point to be filled")
+	}
+}
+
+func TestFrameBuffer_FillPolygon_Clipped(t *testing.T) {
+	fb := NewFrameBuffer()
+	// Larger than the display on every side
+	fb.FillPolygon([][2]int{{-50, -50}, {200, -50}, {200, 200}, {-50, 200}}, true)
+	if got := fb.CountSetPixels(); got != Width*Height {
+		t.Errorf("Expected the whole display filled, got %d pixels", got)
+	}
+}
+
+func TestFrameBuffer_DrawPolygon(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.DrawPolygon([][2]int{{10, 10}, {20, 10}, {20, 20}, {10, 20}}, true)
+
+	// Closing edge back to the first point is drawn
+	if !fb.GetPixel(10, 15) {
+		t.Error("Expected the closing edge to be drawn")
+	}
+	if fb.GetPixel(15, 15) {
+		t.Error("Expected the interior to stay empty")
+	}
+}
+
+func TestFrameBuffer_FloodFill(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.DrawRect(10, 10, 20, 10, true)
+	border := fb.CountSetPixels()
+
+	fb.FloodFill(15, 15, true)
+
+	// Interior of a 20x10 outline is 18x8
+	if got := fb.CountSetPixels(); got != border+18*8 {
+		t.Errorf("Expected %d pixels after fill, got %d", border+18*8, got)
+	}
+	if fb.GetPixel(5, 5) || fb.GetPixel(31, 15) {
+		t.Error("Expected the fill to stop at the border")
+	}
+
+	// Filling a set region back to off clears border and interior together
+	fb.FloodFill(10, 10, false)
+	if got := fb.CountSetPixels(); got != 0 {
+		t.Errorf("Expected the connected shape cleared, got %d pixels", got)
+	}
+}
+
+func TestFrameBuffer_FloodFill_WholeDisplay(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.FloodFill(0, 0, true)
+	if got := fb.CountSetPixels(); got != Width*Height {
+		t.Errorf("Expected %d pixels, got %d", Width*Height, got)
+	}
+
+	// Seeds off the display are ignored
+	fb.FloodFill(-1, 200, false)
+	if got := fb.CountSetPixels(); got != Width*Height {
+		t.Errorf("Expected out-of-bounds seed to do nothing, got %d pixels", got)
+	}
+}
+
+func TestFrameBuffer_Rotate180(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.SetPixel(0, 0, true)
+	fb.Rotate180()
+
+	if fb.GetPixel(0, 0) || !fb.GetPixel(Width-1, Height-1) {
+		t.Error("Expected the top-left pixel to move to the bottom-right corner")
+	}
+	if fb.CountSetPixels() != 1 {
+		t.Errorf("Expected 1 pixel set, got %d", fb.CountSetPixels())
+	}
+
+	// Rotating twice is the identity
+	fb.Clear()
+	fb.DrawLine(3, 5, 40, 20, true)
+	fb.FillRect(100, 50, 7, 3, true)
+	before := fb.ToDeviceFormat()
+	fb.Rotate180()
+	if fb.ToDeviceFormat() == before {
+		t.Error("Expected a single rotation to change an asymmetric image")
+	}
+	fb.Rotate180()
+	if fb.ToDeviceFormat() != before {
+		t.Error("Expected two rotations to restore the original image")
+	}
+}
+
+func TestFrameBuffer_Flip(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.SetPixel(10, 5, true)
+
+	fb.FlipHorizontal()
+	if !fb.GetPixel(Width-11, 5) {
+		t.Error("Expected FlipHorizontal to mirror x")
+	}
+	fb.FlipVertical()
+	if !fb.GetPixel(Width-11, Height-6) {
+		t.Error("Expected FlipVertical to mirror y")
+	}
+}
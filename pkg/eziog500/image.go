@@ -0,0 +1,120 @@
+package eziog500
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// FitMode controls how an image is scaled to the 128x64 display.
+type FitMode int
+
+const (
+	FitStretch FitMode = iota // Scale to fill the display, ignoring aspect ratio
+	FitCenter                 // Scale to fit inside the display, preserving aspect ratio
+	FitCrop                   // Scale to cover the display, cropping the overflow
+)
+
+// DefaultThreshold is the luminance (0-255) at or above which a pixel is lit.
+const DefaultThreshold = 128
+
+// ParseFitMode converts a fit mode name (stretch, center, crop) to a FitMode.
+func ParseFitMode(name string) (FitMode, error) {
+	switch name {
+	case "stretch":
+		return FitStretch, nil
+	case "center":
+		return FitCenter, nil
+	case "crop":
+		return FitCrop, nil
+	default:
+		return FitStretch, fmt.Errorf("unknown fit mode: %s (use stretch, center, crop)", name)
+	}
+}
+
+// String returns the fit mode name.
+func (m FitMode) String() string {
+	switch m {
+	case FitCenter:
+		return "center"
+	case FitCrop:
+		return "crop"
+	default:
+		return "stretch"
+	}
+}
+
+// ScaleImage converts an image to a 128x64 grayscale image using
+// nearest-neighbour sampling. Areas not covered by the source image
+// (FitCenter letterboxing) are left black.
+func ScaleImage(src image.Image, mode FitMode) *image.Gray {
+	dst := image.NewGray(image.Rect(0, 0, Width, Height))
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+	if srcW <= 0 || srcH <= 0 {
+		return dst
+	}
+
+	// Size and offset of the scaled image on the display
+	dstW, dstH := Width, Height
+	switch mode {
+	case FitCenter, FitCrop:
+		scaleX := float64(Width) / float64(srcW)
+		scaleY := float64(Height) / float64(srcH)
+		scale := scaleX
+		if (mode == FitCenter && scaleY < scaleX) || (mode == FitCrop && scaleY > scaleX) {
+			scale = scaleY
+		}
+		dstW = int(float64(srcW)*scale + 0.5)
+		dstH = int(float64(srcH)*scale + 0.5)
+	}
+	offX := (Width - dstW) / 2
+	offY := (Height - dstH) / 2
+
+	for y := 0; y < Height; y++ {
+		sy := y - offY
+		if sy < 0 || sy >= dstH {
+			continue
+		}
+		for x := 0; x < Width; x++ {
+			sx := x - offX
+			if sx < 0 || sx >= dstW {
+				continue
+			}
+			px := b.Min.X + sx*srcW/dstW
+			py := b.Min.Y + sy*srcH/dstH
+			dst.SetGray(x, y, color.GrayModel.Convert(src.At(px, py)).(color.Gray))
+		}
+	}
+	return dst
+}
+
+// FromImage creates a framebuffer from an image, lighting every pixel whose
+// luminance is at or above threshold. The image is sampled 1:1 from its
+// top-left corner; use ScaleImage first to fit arbitrary sizes.
+func FromImage(img image.Image, threshold uint8) *FrameBuffer {
+	fb := NewFrameBuffer()
+	b := img.Bounds()
+	for y := 0; y < Height && b.Min.Y+y < b.Max.Y; y++ {
+		for x := 0; x < Width && b.Min.X+x < b.Max.X; x++ {
+			g := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			fb.data[y][x] = g.Y >= threshold
+		}
+	}
+	return fb
+}
+
+// ToImage returns the framebuffer as a 128x64 grayscale image, lit pixels
+// white and the rest black, so FromImage(fb.ToImage(), DefaultThreshold)
+// gives back the same pixels. Encode it with image/png to save a frame.
+func (fb *FrameBuffer) ToImage() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, Width, Height))
+	for y := 0; y < Height; y++ {
+		for x := 0; x < Width; x++ {
+			if fb.data[y][x] {
+				img.Pix[y*img.Stride+x] = 0xFF
+			}
+		}
+	}
+	return img
+}
@@ -0,0 +1,69 @@
+package eziog500
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestParseFitMode(t *testing.T) {
+	for _, name := range []string{"stretch", "center", "crop"} {
+		mode, err := ParseFitMode(name)
+		if err != nil {
+			t.Errorf("ParseFitMode(%q) returned error: %v", name, err)
+		}
+		if mode.String() != name {
+			t.Errorf("Expected %q, got %q", name, mode.String())
+		}
+	}
+
+	if _, err := ParseFitMode("zoom"); err == nil {
+		t.Error("Expected error for unknown fit mode")
+	}
+}
+
+func TestScaleImage_Center(t *testing.T) {
+	// A white square should be letterboxed into a 64x64 area in the middle
+	src := image.NewGray(image.Rect(0, 0, 32, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			src.SetGray(x, y, color.Gray{Y: 255})
+		}
+	}
+
+	fb := FromImage(ScaleImage(src, FitCenter), DefaultThreshold)
+
+	if fb.GetPixel(0, 32) || fb.GetPixel(127, 32) {
+		t.Error("Letterbox area should be off")
+	}
+	if !fb.GetPixel(32, 0) || !fb.GetPixel(95, 63) || !fb.GetPixel(64, 32) {
+		t.Error("Scaled image area should be on")
+	}
+}
+
+func TestScaleImage_Stretch(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 2, 2))
+	src.SetGray(0, 0, color.Gray{Y: 255})
+
+	fb := FromImage(ScaleImage(src, FitStretch), DefaultThreshold)
+
+	if !fb.GetPixel(0, 0) || !fb.GetPixel(63, 31) {
+		t.Error("Top-left quadrant should be on")
+	}
+	if fb.GetPixel(64, 0) || fb.GetPixel(0, 32) || fb.GetPixel(127, 63) {
+		t.Error("Other quadrants should be off")
+	}
+}
+
+func TestFrameBuffer_ToImage(t *testing.T) {
+	fb := NewFrameBuffer()
+	fb.DrawCircle(64, 32, 20, true)
+
+	img := fb.ToImage()
+	if img.Bounds() != image.Rect(0, 0, Width, Height) {
+		t.Fatalf("Image bounds %v, want 128x64", img.Bounds())
+	}
+	if !FromImage(img, DefaultThreshold).Equal(fb) {
+		t.Error("Expected the image to convert back to the same pixels")
+	}
+}
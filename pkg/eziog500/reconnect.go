@@ -0,0 +1,130 @@
+package eziog500
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Reconnect backoff bounds. The delay before each reopen attempt starts at
+// ReconnectMinDelay and doubles after every failure up to ReconnectMaxDelay.
+const (
+	ReconnectMinDelay = 250 * time.Millisecond
+	ReconnectMaxDelay = 30 * time.Second
+)
+
+// ErrDisconnected is returned while the serial port is down and the next
+// reconnect attempt is not yet due.
+var ErrDisconnected = errors.New("serial port disconnected")
+
+// ConnState is the state of the device's serial connection.
+type ConnState int
+
+const (
+	Connected    ConnState = iota // The port is open
+	Reconnecting                  // The port failed and is being reopened
+)
+
+// String returns the state name.
+func (s ConnState) String() string {
+	switch s {
+	case Connected:
+		return "connected"
+	case Reconnecting:
+		return "reconnecting"
+	default:
+		return fmt.Sprintf("ConnState(%d)", int(s))
+	}
+}
+
+// reconnect holds the device's reconnect state. Its fields are guarded by
+// Device.mu.
+type reconnect struct {
+	open        func() (serialPort, error) // Reopens the port
+	now         func() time.Time           // Overridden in tests
+	onState     func(ConnState)
+	closed      bool
+	failed      bool // The port failed and hasn't been reopened yet
+	backoff     time.Duration
+	nextAttempt time.Time
+	pending     []ConnState // State changes to report once unlocked
+}
+
+// SetStateCallback registers fn to be called when the connection fails
+// (Reconnecting) and when it is reopened (Connected). fn is called without
+// the device lock held, on the goroutine whose I/O changed the state.
+func (d *Device) SetStateCallback(fn func(ConnState)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onState = fn
+}
+
+// State returns the current connection state.
+func (d *Device) State() ConnState {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.failed {
+		return Reconnecting
+	}
+	return Connected
+}
+
+// disconnect closes a failed port and schedules the first reopen attempt.
+// Must be called with d.mu held.
+func (d *Device) disconnect(cause error) {
+	if d.port != nil {
+		d.port.Close()
+		d.port = nil
+	}
+	if d.failed || d.closed {
+		return
+	}
+	debugf("Port failed: %v; reconnecting", cause)
+	d.failed = true
+	d.backoff = ReconnectMinDelay
+	d.nextAttempt = d.now().Add(d.backoff)
+	d.pending = append(d.pending, Reconnecting)
+}
+
+// ensureConnected reopens a failed port once its backoff delay has passed.
+// Must be called with d.mu held.
+func (d *Device) ensureConnected() error {
+	if d.port != nil {
+		return nil
+	}
+	if d.closed || !d.failed || d.open == nil {
+		return fmt.Errorf("serial port not open")
+	}
+	if d.now().Before(d.nextAttempt) {
+		return ErrDisconnected
+	}
+
+	port, err := d.open()
+	if err != nil {
+		d.backoff = min(d.backoff*2, ReconnectMaxDelay)
+		d.nextAttempt = d.now().Add(d.backoff)
+		debugf("Reconnect failed: %v; retrying in %v", err, d.backoff)
+		return fmt.Errorf("%w: %v", ErrDisconnected, err)
+	}
+
+	debugf("Reconnected to %s", d.portPath)
+	d.port = port
+	d.failed = false
+	d.backoff = 0
+	d.pending = append(d.pending, Connected)
+	return nil
+}
+
+// unlockAndNotify releases d.mu and then reports any state changes.
+func (d *Device) unlockAndNotify() {
+	pending, fn := d.pending, d.onState
+	d.pending = nil
+	d.mu.Unlock()
+
+	if fn == nil {
+		return
+	}
+	for _, s := range pending {
+		fn(s)
+	}
+}
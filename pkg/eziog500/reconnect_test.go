@@ -0,0 +1,226 @@
+package eziog500
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+// mockPort records writes and fails them while broken is set.
+type mockPort struct {
+	buf    bytes.Buffer
+	broken bool
+	closed bool
+}
+
+func (p *mockPort) Read(b []byte) (int, error) {
+	if p.broken {
+		return 0, errors.New("read failed")
+	}
+	return 0, nil
+}
+
+func (p *mockPort) Write(b []byte) (int, error) {
+	if p.broken {
+		return 0, errors.New("write failed")
+	}
+	return p.buf.Write(b)
+}
+
+func (p *mockPort) Close() error {
+	p.closed = true
+	return nil
+}
+
+func TestReconnectWithBackoff(t *testing.T) {
+	const failures = 3
+
+	first := &mockPort{}
+	var reopened *mockPort
+	opened, attempts := false, 0
+	open := func() (serialPort, error) {
+		if !opened {
+			opened = true
+			return first, nil
+		}
+		attempts++
+		if attempts <= failures {
+			return nil, errors.New("no such device")
+		}
+		reopened = &mockPort{}
+		return reopened, nil
+	}
+
+	d, err := newDevice("/dev/mock", open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetCommandDelay(0)
+	clock := time.Unix(0, 0)
+	d.now = func() time.Time { return clock }
+
+	var states []ConnState
+	d.SetStateCallback(func(s ConnState) { states = append(states, s) })
+
+	// Healthy write
+	d.Write([]byte("a"))
+	if err := d.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The port fails
+	first.broken = true
+	d.Write([]byte("b"))
+	if err := d.Flush(); err == nil {
+		t.Fatal("Flush on a broken port succeeded")
+	}
+	if !first.closed {
+		t.Error("failed port was not closed")
+	}
+	if d.State() != Reconnecting {
+		t.Fatalf("State() = %v, want reconnecting", d.State())
+	}
+
+	// Before the backoff expires no reopen is attempted
+	d.Write([]byte("c"))
+	if err := d.Flush(); !errors.Is(err, ErrDisconnected) {
+		t.Fatalf("Flush during backoff = %v, want ErrDisconnected", err)
+	}
+	if attempts != 0 {
+		t.Fatalf("reopened %d times during backoff", attempts)
+	}
+
+	// Each failed attempt doubles the delay
+	want := ReconnectMinDelay
+	for i := 1; i <= failures; i++ {
+		clock = clock.Add(want)
+		d.Write([]byte("d"))
+		if err := d.Flush(); !errors.Is(err, ErrDisconnected) {
+			t.Fatalf("attempt %d: Flush = %v, want ErrDisconnected", i, err)
+		}
+		if attempts != i {
+			t.Fatalf("attempt %d: open called %d times", i, attempts)
+		}
+		want *= 2
+		if d.backoff != want {
+			t.Fatalf("attempt %d: backoff = %v, want %v", i, d.backoff, want)
+		}
+	}
+
+	// The next attempt succeeds and the write goes through
+	clock = clock.Add(want)
+	d.Write([]byte("e"))
+	if err := d.Flush(); err != nil {
+		t.Fatalf("Flush after reconnect: %v", err)
+	}
+	if got := reopened.buf.String(); got != "e" {
+		t.Errorf("reopened port got %q, want %q", got, "e")
+	}
+	if d.State() != Connected {
+		t.Errorf("State() = %v, want connected", d.State())
+	}
+	if len(states) != 2 || states[0] != Reconnecting || states[1] != Connected {
+		t.Errorf("state callbacks = %v, want [reconnecting connected]", states)
+	}
+}
+
+func TestReconnectBackoffCap(t *testing.T) {
+	port := &mockPort{}
+	opened := false
+	d, err := newDevice("/dev/mock", func() (serialPort, error) {
+		if !opened {
+			opened = true
+			return port, nil
+		}
+		return nil, errors.New("no such device")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	clock := time.Unix(0, 0)
+	d.now = func() time.Time { return clock }
+
+	port.broken = true
+	buf := make([]byte, 1)
+	if _, err := d.Read(buf); err == nil {
+		t.Fatal("Read on a broken port succeeded")
+	}
+	if d.State() != Reconnecting {
+		t.Fatalf("read failure: State() = %v, want reconnecting", d.State())
+	}
+
+	for i := 0; i < 20; i++ {
+		clock = clock.Add(ReconnectMaxDelay)
+		d.Flush()
+		d.Write([]byte("x"))
+		d.Flush()
+	}
+	if d.backoff != ReconnectMaxDelay {
+		t.Errorf("backoff = %v, want cap %v", d.backoff, ReconnectMaxDelay)
+	}
+}
+
+func TestCloseStopsReconnecting(t *testing.T) {
+	port := &mockPort{}
+	opens := 0
+	d, err := newDevice("/dev/mock", func() (serialPort, error) {
+		opens++
+		return port, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Close()
+
+	if _, err := d.Read(make([]byte, 1)); err == nil {
+		t.Error("Read after Close succeeded")
+	}
+	d.Write([]byte("x"))
+	if err := d.Flush(); err == nil {
+		t.Error("Flush after Close succeeded")
+	}
+	if opens != 1 {
+		t.Errorf("port opened %d times, want 1", opens)
+	}
+}
+
+func TestSessionFollowsReconnect(t *testing.T) {
+	first, second := &mockPort{}, &mockPort{}
+	ports := []*mockPort{first, second}
+	d, err := newDevice("/dev/mock", func() (serialPort, error) {
+		p := ports[0]
+		ports = ports[1:]
+		return p, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetCommandDelay(0)
+	clock := time.Unix(0, 0)
+	d.now = func() time.Time { return clock }
+
+	session, err := d.StartSession()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer session.Close()
+
+	first.broken = true
+	if _, err := session.Write([]byte("a")); err == nil {
+		t.Fatal("Write on a broken port succeeded")
+	}
+
+	clock = clock.Add(ReconnectMinDelay)
+	if _, err := session.Write([]byte("b")); err != nil {
+		t.Fatalf("Write after reconnect: %v", err)
+	}
+	if got := second.buf.String(); got != "b" {
+		t.Errorf("reopened port got %q, want %q", got, "b")
+	}
+
+	d.Close()
+	if _, err := d.StartSession(); err == nil {
+		t.Error("StartSession after Close succeeded")
+	}
+}
@@ -0,0 +1,46 @@
+package eziog500
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// Native text mode grid. The panel's built-in font uses 6x8 pixel character
+// cells, so its 128x64 pixels hold 21 columns by 8 rows of text. Text mode
+// is much cheaper than graphics: a row is at most a few dozen bytes, where
+// every frame upload is over a kilobyte.
+const (
+	TextColumns = Width / 6
+	TextRows    = Height / 8
+)
+
+// TextAt writes text in native text mode starting at row and col, both
+// counted from 0. The panel has no absolute cursor positioning, so the
+// cursor is homed (ESC [ H) then moved down row times (ESC [ B) and right
+// col times (ESC [ C). Text past the end of the row is cut off rather than
+// wrapping onto the next one.
+func (d *Device) TextAt(row, col int, text string) error {
+	if row < 0 || row >= TextRows || col < 0 || col >= TextColumns {
+		return fmt.Errorf("text position %d,%d outside the %dx%d grid", row, col, TextRows, TextColumns)
+	}
+	if len(text) > TextColumns-col {
+		text = text[:TextColumns-col]
+	}
+
+	var buf bytes.Buffer
+	buf.Write([]byte{ESC, cmdCursorPrefix, cmdCursorHome})
+	for i := 0; i < row; i++ {
+		buf.Write([]byte{ESC, cmdCursorPrefix, cmdCursorDown})
+	}
+	for i := 0; i < col; i++ {
+		buf.Write([]byte{ESC, cmdCursorPrefix, cmdCursorRight})
+	}
+	buf.WriteString(text)
+	return d.Write(buf.Bytes())
+}
+
+// TextClearLine blanks a row in native text mode by overwriting it with
+// spaces.
+func (d *Device) TextClearLine(row int) error {
+	return d.TextAt(row, 0, string(bytes.Repeat([]byte{' '}, TextColumns)))
+}
@@ -0,0 +1,51 @@
+package eziog500
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDevice_TextAt(t *testing.T) {
+	d, written := newTestDevice(t)
+
+	if err := d.TextAt(2, 3, "CPU 5%"); err != nil {
+		t.Fatal(err)
+	}
+
+	home := []byte{ESC, '[', 'H'}
+	down := []byte{ESC, '[', 'B'}
+	right := []byte{ESC, '[', 'C'}
+	want := bytes.Join([][]byte{home, down, down, right, right, right, []byte("CPU 5%")}, nil)
+	if got := written(); !bytes.Equal(got, want) {
+		t.Errorf("Expected % 02X, got % 02X", want, got)
+	}
+}
+
+func TestDevice_TextAt_Clips(t *testing.T) {
+	d, written := newTestDevice(t)
+
+	if err := d.TextAt(0, TextColumns-2, "ABCDEF"); err != nil {
+		t.Fatal(err)
+	}
+	if got := written(); !bytes.HasSuffix(got, []byte{'C', 'A', 'B'}) || bytes.Contains(got, []byte("ABC")) {
+		t.Errorf("Expected the text cut to 2 columns, got % 02X", got)
+	}
+
+	for _, pos := range [][2]int{{-1, 0}, {TextRows, 0}, {0, TextColumns}} {
+		if err := d.TextAt(pos[0], pos[1], "X"); err == nil {
+			t.Errorf("Expected an error at %v", pos)
+		}
+	}
+}
+
+func TestDevice_TextClearLine(t *testing.T) {
+	d, written := newTestDevice(t)
+
+	if err := d.TextClearLine(1); err != nil {
+		t.Fatal(err)
+	}
+	want := append([]byte{ESC, '[', 'H', ESC, '[', 'B'}, bytes.Repeat([]byte{' '}, TextColumns)...)
+	if got := written(); !bytes.Equal(got, want) {
+		t.Errorf("Expected % 02X, got % 02X", want, got)
+	}
+}
@@ -0,0 +1,61 @@
+package font
+
+import "github.com/sagostin/ezio-g500/pkg/eziog500"
+
+// Align positions text along one axis of a rectangle.
+type Align int
+
+const (
+	AlignStart  Align = iota // Left or top
+	AlignCenter              // Centred
+	AlignEnd                 // Right or bottom
+
+	AlignLeft   = AlignStart
+	AlignTop    = AlignStart
+	AlignRight  = AlignEnd
+	AlignBottom = AlignEnd
+)
+
+// offset returns where content of the given size starts within space
+// pixels. Content too big for the space starts at 0, so its beginning stays
+// visible.
+func (a Align) offset(space, size int) int {
+	if size >= space {
+		return 0
+	}
+	switch a {
+	case AlignCenter:
+		return (space - size) / 2
+	case AlignEnd:
+		return space - size
+	}
+	return 0
+}
+
+// AlignText returns where RenderTextAligned draws a single line of text
+// within the w x h rectangle at (x, y).
+func AlignText(f Font, x, y, w, h int, text string, halign, valign Align) (tx, ty int) {
+	return x + halign.offset(w, MeasureText(f, text)), y + valign.offset(h, f.Height())
+}
+
+// RenderTextAligned renders a single line of text within the w x h
+// rectangle at (x, y), clipping anything that doesn't fit. Returns the X
+// position after the last character, which is past the rectangle if the
+// text was clipped.
+func RenderTextAligned(fb *eziog500.FrameBuffer, f Font, x, y, w, h int, text string, halign, valign Align) int {
+	// Clip to the rectangle, within any clip already set
+	cx, cy, cw, ch, clipped := fb.Clip()
+	x0, y0 := max(x, cx), max(y, cy)
+	x1, y1 := min(x+w, cx+cw), min(y+h, cy+ch)
+	fb.SetClip(x0, y0, max(0, x1-x0), max(0, y1-y0))
+	defer func() {
+		if clipped {
+			fb.SetClip(cx, cy, cw, ch)
+		} else {
+			fb.ClearClip()
+		}
+	}()
+
+	tx, ty := AlignText(f, x, y, w, h, text, halign, valign)
+	return RenderText(fb, f, tx, ty, text)
+}
@@ -0,0 +1,55 @@
+package font
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestAlignText(t *testing.T) {
+	text := "HI"
+	tw := MeasureText(BuiltinFont, text)
+	for _, tt := range []struct {
+		name           string
+		halign, valign Align
+		wantX, wantY   int
+	}{
+		{"top left", AlignLeft, AlignTop, 10, 20},
+		{"centre", AlignCenter, AlignCenter, 10 + (40-tw)/2, 20 + (20-8)/2},
+		{"bottom right", AlignRight, AlignBottom, 50 - tw, 40 - 8},
+	} {
+		x, y := AlignText(BuiltinFont, 10, 20, 40, 20, text, tt.halign, tt.valign)
+		if x != tt.wantX || y != tt.wantY {
+			t.Errorf("%s: got (%d, %d), want (%d, %d)", tt.name, x, y, tt.wantX, tt.wantY)
+		}
+	}
+
+	// Text wider than the box starts at its left edge
+	if x, _ := AlignText(BuiltinFont, 10, 20, tw-1, 8, text, AlignRight, AlignTop); x != 10 {
+		t.Errorf("Overflowing text starts at x=%d, want 10", x)
+	}
+}
+
+func TestRenderTextAligned_Clips(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	RenderTextAligned(fb, BuiltinFont, 10, 10, 12, 4, "WWWWWW", AlignCenter, AlignCenter)
+
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			inside := x >= 10 && x < 22 && y >= 10 && y < 14
+			if fb.GetPixel(x, y) && !inside {
+				t.Fatalf("Pixel drawn outside the rectangle at %d,%d", x, y)
+			}
+		}
+	}
+	if fb.CountSetPixels() == 0 {
+		t.Error("Expected the visible part of the text to be drawn")
+	}
+
+	// A clip set by the caller is restored
+	fb.SetClip(0, 0, 64, 64)
+	RenderTextAligned(fb, BuiltinFont, 0, 0, 128, 8, "OK", AlignRight, AlignTop)
+	if x, y, w, h, ok := fb.Clip(); !ok || x != 0 || y != 0 || w != 64 || h != 64 {
+		t.Errorf("Clip changed to %d,%d %dx%d (%v)", x, y, w, h, ok)
+	}
+}
@@ -12,6 +12,17 @@ func (f *builtinFont) Height() int {
 	return 8
 }
 
+// LineHeight is the height: the blank top row and the descender row
+// already space the lines apart.
+func (f *builtinFont) LineHeight() int {
+	return 8
+}
+
+// Baseline is below the capitals, which fill rows 1 to 6.
+func (f *builtinFont) Baseline() int {
+	return 7
+}
+
 func (f *builtinFont) GetWidth(r rune) int {
 	glyph := f.GetGlyph(r)
 	if glyph != nil {
@@ -173,6 +184,39 @@ func (f *builtinFont) GetGlyph(r rune) []byte {
 	case '°':
 		return []byte{0x06, 0x09, 0x06, 0x00}
 
+	// Arrows for tx/rx, and plus-minus
+	case '↑':
+		return []byte{0x08, 0x04, 0x7E, 0x04, 0x08, 0x00}
+	case '↓':
+		return []byte{0x10, 0x20, 0x7E, 0x20, 0x10, 0x00}
+	case '±':
+		return []byte{0x48, 0x48, 0x5E, 0x48, 0x48, 0x00}
+
+	// Box drawing: lines run through the middle of a 5-pixel cell and reach
+	// its edges, with no spacing column, so neighbours join up
+	case '─':
+		return []byte{0x08, 0x08, 0x08, 0x08, 0x08}
+	case '│':
+		return []byte{0x00, 0x00, 0xFF, 0x00, 0x00}
+	case '┌':
+		return []byte{0x00, 0x00, 0xF8, 0x08, 0x08}
+	case '┐':
+		return []byte{0x08, 0x08, 0xF8, 0x00, 0x00}
+	case '└':
+		return []byte{0x00, 0x00, 0x0F, 0x08, 0x08}
+	case '┘':
+		return []byte{0x08, 0x08, 0x0F, 0x00, 0x00}
+	case '├':
+		return []byte{0x00, 0x00, 0xFF, 0x08, 0x08}
+	case '┤':
+		return []byte{0x08, 0x08, 0xFF, 0x00, 0x00}
+	case '┬':
+		return []byte{0x08, 0x08, 0xF8, 0x08, 0x08}
+	case '┴':
+		return []byte{0x08, 0x08, 0x0F, 0x08, 0x08}
+	case '┼':
+		return []byte{0x08, 0x08, 0xFF, 0x08, 0x08}
+
 	default:
 		// Return empty space for unknown characters
 		return []byte{0x00, 0x00}
@@ -188,6 +232,16 @@ func (f *smallFont) Height() int {
 	return 6
 }
 
+// LineHeight is the height: the blank bottom row spaces the lines apart.
+func (f *smallFont) LineHeight() int {
+	return 6
+}
+
+// Baseline is below the capitals, which fill rows 0 to 4.
+func (f *smallFont) Baseline() int {
+	return 5
+}
+
 func (f *smallFont) GetWidth(r rune) int {
 	glyph := f.GetGlyph(r)
 	if glyph != nil {
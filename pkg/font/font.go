@@ -2,6 +2,8 @@
 package font
 
 import (
+	"strings"
+
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 )
 
@@ -15,6 +17,14 @@ type Font interface {
 	// Height returns the font height in pixels.
 	Height() int
 
+	// LineHeight returns the distance between the tops of consecutive
+	// lines: Height plus any gap the font wants between lines.
+	LineHeight() int
+
+	// Baseline returns the row, counted from the top, that capital letters
+	// sit on. Descenders such as ',' and '_' reach below it.
+	Baseline() int
+
 	// GetWidth returns the width of a character in pixels.
 	// Returns 0 if the character is not supported.
 	GetWidth(r rune) int
@@ -44,6 +54,31 @@ func RenderText(fb *eziog500.FrameBuffer, f Font, x, y int, text string) int {
 	return curX
 }
 
+// RenderTextScaled renders text with each font pixel drawn as a
+// scale x scale block, e.g. scale 2 for large readouts.
+// Returns the x position after the last character.
+func RenderTextScaled(fb *eziog500.FrameBuffer, f Font, x, y int, text string, scale int) int {
+	if scale <= 1 {
+		return RenderText(fb, f, x, y, text)
+	}
+	curX := x
+	for _, r := range text {
+		glyph := f.GetGlyph(r)
+		if glyph == nil {
+			continue
+		}
+		for col, b := range glyph {
+			for bit := 0; bit < 8; bit++ {
+				if (b & (1 << bit)) != 0 {
+					fb.FillRect(curX+col*scale, y+bit*scale, scale, scale, true)
+				}
+			}
+		}
+		curX += len(glyph) * scale
+	}
+	return curX
+}
+
 // RenderTextInverted renders inverted text (white on black background).
 func RenderTextInverted(fb *eziog500.FrameBuffer, f Font, x, y int, text string) int {
 	// First, calculate width
@@ -85,6 +120,89 @@ func MeasureText(f Font, text string) int {
 	return width
 }
 
+// TruncateText shortens text to fit within maxWidth pixels, replacing the
+// cut-off tail with "..". Text that already fits is returned unchanged.
+func TruncateText(f Font, text string, maxWidth int) string {
+	if MeasureText(f, text) <= maxWidth {
+		return text
+	}
+	const marker = ".."
+	avail := maxWidth - MeasureText(f, marker)
+	width := 0
+	for i, r := range text {
+		width += f.GetWidth(r)
+		if width > avail {
+			return text[:i] + marker
+		}
+	}
+	return text
+}
+
+// RenderTextClipped renders text at (x, y) within maxWidth pixels, cutting
+// off the tail with ".." as TruncateText does if it doesn't fit. Returns the
+// X position after the last character.
+func RenderTextClipped(fb *eziog500.FrameBuffer, f Font, x, y, maxWidth int, text string) int {
+	return RenderText(fb, f, x, y, TruncateText(f, text, maxWidth))
+}
+
+// WrapText splits text into lines no wider than maxWidth pixels, breaking
+// at spaces. Words wider than a line are broken wherever they reach the
+// edge.
+func WrapText(f Font, text string, maxWidth int) []string {
+	var lines []string
+	line := ""
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if MeasureText(f, candidate) <= maxWidth {
+			line = candidate
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+		line = word
+		for line != "" && MeasureText(f, line) > maxWidth {
+			head := breakWord(f, line, maxWidth)
+			lines = append(lines, head)
+			line = line[len(head):]
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// RenderTextWrapped renders text wrapped to maxWidth pixels as WrapText
+// does, one line every f.LineHeight() pixels. Returns the Y position below
+// the last line.
+func RenderTextWrapped(fb *eziog500.FrameBuffer, f Font, x, y, maxWidth int, text string) int {
+	for _, line := range WrapText(f, text, maxWidth) {
+		RenderText(fb, f, x, y, line)
+		y += f.LineHeight()
+	}
+	return y
+}
+
+// breakWord returns the longest prefix of word that fits in maxWidth, or its
+// first character if none does.
+func breakWord(f Font, word string, maxWidth int) string {
+	width := 0
+	for i, r := range word {
+		width += f.GetWidth(r)
+		if width > maxWidth {
+			if i == 0 {
+				return string(r)
+			}
+			return word[:i]
+		}
+	}
+	return word
+}
+
 // MeasureTextRunes returns the width for a slice of runes.
 func MeasureTextRunes(f Font, runes []rune) int {
 	width := 0
@@ -1,6 +1,7 @@
 package font
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
@@ -52,6 +53,78 @@ func TestBuiltinFont_Height(t *testing.T) {
 	}
 }
 
+func TestFont_Metrics(t *testing.T) {
+	for _, tt := range []struct {
+		name                 string
+		f                    Font
+		lineHeight, baseline int
+	}{
+		{"builtin", BuiltinFont, 8, 7},
+		{"small", SmallFont, 6, 5},
+	} {
+		if got := tt.f.LineHeight(); got != tt.lineHeight {
+			t.Errorf("%s: LineHeight() = %d, want %d", tt.name, got, tt.lineHeight)
+		}
+		if got := tt.f.Baseline(); got != tt.baseline {
+			t.Errorf("%s: Baseline() = %d, want %d", tt.name, got, tt.baseline)
+		}
+
+		// Capitals end on the row above the baseline, inside the line
+		fb := eziog500.NewFrameBuffer()
+		RenderText(fb, tt.f, 0, 0, "E")
+		if !fb.GetPixel(0, tt.baseline-1) || fb.GetPixel(0, tt.baseline) {
+			t.Errorf("%s: 'E' doesn't sit on the baseline", tt.name)
+		}
+		if tt.f.LineHeight() < tt.f.Height() {
+			t.Errorf("%s: lines overlap", tt.name)
+		}
+	}
+}
+
+func TestRenderTextWrapped(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	text := "THE QUICK BROWN FOX JUMPS"
+	lines := WrapText(SmallFont, text, 40)
+	if got, want := RenderTextWrapped(fb, SmallFont, 0, 2, 40, text), 2+len(lines)*SmallFont.LineHeight(); got != want {
+		t.Errorf("RenderTextWrapped returned y=%d, want %d", got, want)
+	}
+	if fb.CountSetPixels() == 0 {
+		t.Error("Expected text to be drawn")
+	}
+}
+
+func TestBuiltinFont_ExtendedGlyphs(t *testing.T) {
+	glyph := BuiltinFont.GetGlyph('°')
+	if glyph == nil {
+		t.Fatal("Expected a degree glyph")
+	}
+	fb := eziog500.NewFrameBuffer()
+	RenderText(fb, BuiltinFont, 0, 0, "°")
+	if fb.CountSetPixels() == 0 {
+		t.Error("Expected the degree sign to draw pixels")
+	}
+
+	space := BuiltinFont.GetGlyph('\uFFFD') // Unknown runes draw as a blank
+	for _, r := range "°↑↓±─│┌┐└┘├┤┬┴┼" {
+		g := BuiltinFont.GetGlyph(r)
+		if string(g) == string(space) {
+			t.Errorf("No glyph for %q", r)
+		}
+		if w := MeasureText(BuiltinFont, string(r)); w != len(g) || w != BuiltinFont.GetWidth(r) {
+			t.Errorf("%q: MeasureText = %d, glyph is %d wide", r, w, len(g))
+		}
+	}
+
+	// Box-drawing lines join across characters
+	fb.Clear()
+	end := RenderText(fb, BuiltinFont, 0, 0, "┌──┐")
+	for x := 2; x < end-2; x++ {
+		if !fb.GetPixel(x, 3) {
+			t.Fatalf("Gap in the box top at x=%d", x)
+		}
+	}
+}
+
 func TestBuiltinFont_GetWidth(t *testing.T) {
 	font := BuiltinFont
 
@@ -163,3 +236,113 @@ func TestSmallFont_BasicOperation(t *testing.T) {
 		t.Error("SmallFont should be shorter than BuiltinFont")
 	}
 }
+
+func TestTruncateText(t *testing.T) {
+	f := BuiltinFont
+
+	if got := TruncateText(f, "short", 100); got != "short" {
+		t.Errorf("Text that fits should be unchanged, got %q", got)
+	}
+
+	long := "php-fpm: pool www"
+	width := MeasureText(f, "php-fpm")
+	got := TruncateText(f, long, width)
+	if !strings.HasSuffix(got, "..") {
+		t.Errorf("Expected truncation marker, got %q", got)
+	}
+	if MeasureText(f, got) > width {
+		t.Errorf("Truncated text %q is wider than %d", got, width)
+	}
+}
+
+func TestRenderTextClipped(t *testing.T) {
+	f := BuiltinFont
+	text := "igb0.100 OPT1"
+	width := MeasureText(f, text)
+
+	render := func(s string) *eziog500.FrameBuffer {
+		fb := eziog500.NewFrameBuffer()
+		RenderText(fb, f, 0, 0, s)
+		return fb
+	}
+	endsInDots := func(fb *eziog500.FrameBuffer, maxWidth int) bool {
+		// Dots sit on the baseline row just before the end
+		return fb.GetPixel(maxWidth-1, 6) || fb.GetPixel(maxWidth-2, 6) || fb.GetPixel(maxWidth-3, 6)
+	}
+
+	for _, maxWidth := range []int{width + 10, width, width - 1, width / 2} {
+		fb := eziog500.NewFrameBuffer()
+		end := RenderTextClipped(fb, f, 0, 0, maxWidth, text)
+
+		truncated := maxWidth < width
+		want := text
+		if truncated {
+			want = TruncateText(f, text, maxWidth)
+			if !strings.HasSuffix(want, "..") {
+				t.Fatalf("width %d: expected %q to end in the marker", maxWidth, want)
+			}
+		}
+		if fb.ToDeviceFormat() != render(want).ToDeviceFormat() {
+			t.Errorf("width %d: expected %q to be drawn", maxWidth, want)
+		}
+		if end > maxWidth {
+			t.Errorf("width %d: text ended at %d", maxWidth, end)
+		}
+		if truncated && !endsInDots(fb, end) {
+			t.Errorf("width %d: expected the marker at the end of the text", maxWidth)
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	f := BuiltinFont
+	lines := WrapText(f, "Restart the unbound DNS resolver service now?", 60)
+	if len(lines) < 2 {
+		t.Fatalf("Expected wrapping, got %q", lines)
+	}
+	for _, l := range lines {
+		if MeasureText(f, l) > 60 {
+			t.Errorf("Line %q exceeds the width", l)
+		}
+	}
+
+	// Words too long for a line are broken, losing nothing
+	long := "/var/log/filter.log"
+	lines = WrapText(f, "see "+long, 40)
+	if strings.Join(lines[1:], "") != long {
+		t.Errorf("Expected %q broken across lines, got %q", long, lines)
+	}
+	for _, l := range lines {
+		if MeasureText(f, l) > 40 {
+			t.Errorf("Line %q exceeds the width", l)
+		}
+	}
+
+	// A negative width gives one character per line instead of looping
+	lines = WrapText(f, "hello", -5)
+	if strings.Join(lines, "") != "hello" || len(lines) != 5 {
+		t.Errorf("WrapText(-5) = %q, want one line per character", lines)
+	}
+}
+
+func TestRenderTextScaled(t *testing.T) {
+	f := BuiltinFont
+	fb := eziog500.NewFrameBuffer()
+
+	end := RenderTextScaled(fb, f, 0, 0, "1", 2)
+	if end != 2*MeasureText(f, "1") {
+		t.Errorf("Expected end x %d, got %d", 2*MeasureText(f, "1"), end)
+	}
+
+	// Every lit pixel of the 1x glyph becomes a lit 2x2 block
+	small := eziog500.NewFrameBuffer()
+	RenderText(small, f, 0, 0, "1")
+	for y := 0; y < 8; y++ {
+		for x := 0; x < MeasureText(f, "1"); x++ {
+			want := small.GetPixel(x, y)
+			if fb.GetPixel(2*x, 2*y) != want || fb.GetPixel(2*x+1, 2*y+1) != want {
+				t.Fatalf("Scaled pixel block (%d, %d) doesn't match", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package menu
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+)
+
+// AppMode is what an App is showing.
+type AppMode int32
+
+const (
+	StatusMode AppMode = iota // The daemon's rotating status screens
+	MenuMode                  // The menu
+)
+
+// String returns the mode name.
+func (m AppMode) String() string {
+	if m == MenuMode {
+		return "menu"
+	}
+	return "status"
+}
+
+// App is the whole panel UI for an appliance: a StatusDaemon's rotating
+// screens, where Enter opens the menu, and Esc at the menu's root returns
+// to the screens. The daemon and the menu share one display and button
+// source; the source is read for as long as Run runs and each press goes
+// to whichever of the two is showing.
+type App struct {
+	daemon *pfsense.StatusDaemon
+	menu   *MenuController
+	router *buttonRouter
+	mode   atomic.Int32
+	err    error // Menu failure that stopped the daemon
+}
+
+// NewApp creates an app showing daemon on d, with root as the menu. It
+// takes over the daemon's buttons and OnEnter handler.
+func NewApp(d *display.Display, buttons ButtonSource, daemon *pfsense.StatusDaemon, root *Menu) *App {
+	r := &buttonRouter{src: buttons}
+	a := &App{
+		daemon: daemon,
+		menu:   NewMenuController(d, routedEvents{routedButtons{r}}, root),
+		router: r,
+	}
+	daemon.SetButtons(routedButtons{r})
+	daemon.SetOnEnter(a.openMenu)
+	return a
+}
+
+// Menu returns the app's menu controller, e.g. to pass its Buttons to a
+// PfSenseMenuBuilder.
+func (a *App) Menu() *MenuController {
+	return a.menu
+}
+
+// Mode returns what the app is showing. It is safe to call from any
+// goroutine.
+func (a *App) Mode() AppMode {
+	return AppMode(a.mode.Load())
+}
+
+// Run shows the status screens and blocks until Stop is called or the menu
+// fails to draw.
+func (a *App) Run() error {
+	stop := a.router.start()
+	defer stop()
+
+	if err := a.daemon.Run(); err != nil {
+		return err
+	}
+	return a.err
+}
+
+// Stop makes Run return, closing the menu if it is open.
+func (a *App) Stop() {
+	a.menu.Stop()
+	a.daemon.Stop()
+}
+
+// openMenu runs the menu from its root until it is exited. It is the
+// daemon's OnEnter handler, so the daemon is paused meanwhile.
+func (a *App) openMenu() {
+	a.mode.Store(int32(MenuMode))
+	defer a.mode.Store(int32(StatusMode))
+
+	a.menu.GoToRoot()
+	if err := a.menu.Run(); err != nil {
+		a.err = err
+		a.daemon.Stop()
+	}
+}
+
+// routeBuffer is how many button events wait for the daemon or the menu.
+const routeBuffer = 10
+
+// buttonRouter reads one button source for the life of an App and hands
+// each event to whichever of the daemon and the menu is reading. A stopped
+// ButtonReader channel leaves its goroutine blocked in Read, where it takes
+// the next press for nobody, so the source is never stopped and reopened
+// when switching.
+type buttonRouter struct {
+	src     ButtonSource
+	mu      sync.Mutex
+	sink    *routeSink
+	pending []eziog500.ButtonEvent // Arrived while no one was reading
+}
+
+// routeSink is the channel a reader attached to a buttonRouter; one of
+// events and buttons is set.
+type routeSink struct {
+	events  chan eziog500.ButtonEvent
+	buttons chan eziog500.Button // Presses only
+}
+
+// start reads the source until the returned func is called.
+func (r *buttonRouter) start() func() {
+	events, stop := buttonEvents(r.src)
+	go func() {
+		for ev := range events {
+			r.deliver(ev)
+		}
+	}()
+	return stop
+}
+
+// deliver passes ev to the current sink, or keeps it for the next one.
+func (r *buttonRouter) deliver(ev eziog500.ButtonEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sink != nil && r.sink.send(ev) {
+		return
+	}
+	if len(r.pending) < routeBuffer {
+		r.pending = append(r.pending, ev)
+	}
+}
+
+// send queues ev without blocking, reporting whether s took it. A buttons
+// sink takes everything but presses without queueing them.
+func (s *routeSink) send(ev eziog500.ButtonEvent) bool {
+	if s.events != nil {
+		select {
+		case s.events <- ev:
+			return true
+		default:
+			return false
+		}
+	}
+	if ev.Kind != eziog500.Press {
+		return true
+	}
+	select {
+	case s.buttons <- ev.Button:
+		return true
+	default:
+		return false
+	}
+}
+
+// attach makes s the sink, first handing it the events that arrived while
+// no one was reading, and returns the func that detaches it.
+func (r *buttonRouter) attach(s *routeSink) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, ev := range r.pending {
+		s.send(ev)
+	}
+	r.pending = nil
+	r.sink = s
+	return func() { r.detach(s) }
+}
+
+// detach stops feeding s and keeps what it hadn't read for the next sink.
+// Its reader must have stopped reading.
+func (r *buttonRouter) detach(s *routeSink) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sink != s {
+		return
+	}
+	r.sink = nil
+
+	// Only deliver sends, under r.mu, so the lengths hold
+	var unread []eziog500.ButtonEvent
+	for len(s.events) > 0 {
+		unread = append(unread, <-s.events)
+	}
+	for len(s.buttons) > 0 {
+		unread = append(unread, eziog500.ButtonEvent{Button: <-s.buttons, Kind: eziog500.Press})
+	}
+	r.pending = append(unread, r.pending...)
+}
+
+// routedButtons is the daemon's ButtonSource on a buttonRouter.
+type routedButtons struct{ r *buttonRouter }
+
+func (b routedButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	s := &routeSink{buttons: make(chan eziog500.Button, routeBuffer)}
+	return s.buttons, b.r.attach(s)
+}
+
+// routedEvents is the menu's EventSource on a buttonRouter, so held
+// buttons still scroll when the app's source reports them.
+type routedEvents struct{ routedButtons }
+
+func (e routedEvents) EventChannel() (<-chan eziog500.ButtonEvent, func()) {
+	s := &routeSink{events: make(chan eziog500.ButtonEvent, routeBuffer)}
+	return s.events, e.r.attach(s)
+}
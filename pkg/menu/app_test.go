@@ -0,0 +1,123 @@
+package menu
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+)
+
+// pipeButtons reads button codes from a pipe the way eziog500.ButtonReader
+// reads the serial port: each channel's goroutine blocks in Read and only
+// notices it was stopped after taking the next byte, which is then lost.
+type pipeButtons struct {
+	r *io.PipeReader
+}
+
+func (p pipeButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	ch := make(chan eziog500.Button, 10)
+	stop := make(chan struct{})
+	go func() {
+		defer close(ch)
+		buf := make([]byte, 1)
+		for {
+			if _, err := p.r.Read(buf); err != nil {
+				return
+			}
+			select {
+			case <-stop:
+				return
+			case ch <- eziog500.Button(buf[0]):
+			}
+		}
+	}()
+	return ch, func() { close(stop) }
+}
+
+func TestApp_EnterOpensMenuEscReturns(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := pfsense.NewStatusDaemon(d, time.Second, time.Hour)
+	root := NewMenu("MAIN", []MenuItem{{Label: "Status"}})
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { pw.Close() })
+	app := NewApp(d, pipeButtons{pr}, daemon, root)
+	press := func(b eziog500.Button) {
+		t.Helper()
+		if _, err := pw.Write([]byte{byte(b)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	errc := make(chan error, 1)
+	go func() { errc <- app.Run() }()
+
+	waitMode := func(want AppMode) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for app.Mode() != want {
+			if time.Now().After(deadline) {
+				t.Fatalf("Mode = %s, want %s", app.Mode(), want)
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+	}
+
+	if app.Mode() != StatusMode {
+		t.Fatalf("Starting mode = %s, want status", app.Mode())
+	}
+	// Every switch keeps the first press after it, both ways
+	for i := 0; i < 2; i++ {
+		press(eziog500.ButtonEnter)
+		waitMode(MenuMode)
+
+		// Esc at the root leaves the menu
+		press(eziog500.ButtonEsc)
+		waitMode(StatusMode)
+	}
+
+	app.Stop()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run didn't return after Stop")
+	}
+}
+
+func TestApp_StopWithMenuOpen(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := pfsense.NewStatusDaemon(d, time.Second, time.Hour)
+	root := NewMenu("MAIN", []MenuItem{{Label: "Status"}})
+	pr, pw := io.Pipe()
+	t.Cleanup(func() { pw.Close() })
+	app := NewApp(d, pipeButtons{pr}, daemon, root)
+
+	errc := make(chan error, 1)
+	go func() { errc <- app.Run() }()
+	if _, err := pw.Write([]byte{byte(eziog500.ButtonEnter)}); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for app.Mode() != MenuMode {
+		if time.Now().After(deadline) {
+			t.Fatal("Enter didn't open the menu")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// No Esc: Stop alone closes the menu and ends Run
+	app.Stop()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Errorf("Run() = %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run didn't return after Stop with the menu open")
+	}
+}
@@ -0,0 +1,79 @@
+package menu
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// Confirm shows a yes/no prompt and waits for a choice. Left/Right move
+// between the buttons, Enter selects, and Esc answers no. No is selected
+// initially so an accidental Enter doesn't confirm a destructive action.
+//
+// Inside a MenuItem.Action, pass MenuController.Buttons() as the source so
+// the dialog shares the controller's button stream. The framebuffer is
+// restored on return; the caller's next Update shows it again.
+func Confirm(d *display.Display, src ButtonSource, prompt string) (bool, error) {
+	buttons, stop := src.ButtonChannel()
+	defer stop()
+
+	d.Push()
+	defer d.Pop()
+
+	choice := false // Start on No
+	if err := renderConfirm(d, prompt, choice); err != nil {
+		return false, err
+	}
+
+	for btn := range buttons {
+		switch btn {
+		case eziog500.ButtonLeft, eziog500.ButtonRight, eziog500.ButtonUp, eziog500.ButtonDown:
+			choice = !choice
+		case eziog500.ButtonEnter:
+			return choice, nil
+		case eziog500.ButtonEsc:
+			return false, nil
+		default:
+			continue
+		}
+		if err := renderConfirm(d, prompt, choice); err != nil {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// renderConfirm draws the prompt in a dialog with Yes/No buttons over
+// whatever was on screen.
+func renderConfirm(d *display.Display, prompt string, yes bool) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		dlg := ui.NewDialog("", prompt, "YES", "NO")
+		if !yes {
+			dlg.SetFocus(1)
+		}
+		dlg.RenderCentered(fb)
+	})
+}
+
+// Alert shows a message in a dialog with an OK button and waits for Enter
+// or Esc. Like Confirm, it draws over the screen and restores it on return.
+func Alert(d *display.Display, src ButtonSource, title, message string) error {
+	buttons, stop := src.ButtonChannel()
+	defer stop()
+
+	d.Push()
+	defer d.Pop()
+
+	err := d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		ui.NewDialog(title, message, "OK").RenderCentered(fb)
+	})
+	if err != nil {
+		return err
+	}
+	for btn := range buttons {
+		if btn == eziog500.ButtonEnter || btn == eziog500.ButtonEsc {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name    string
+		presses []eziog500.Button
+		want    bool
+	}{
+		{"enter defaults to no", []eziog500.Button{eziog500.ButtonEnter}, false},
+		{"left then enter is yes", []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonEnter}, true},
+		{"toggle back to no", []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonRight, eziog500.ButtonEnter}, false},
+		{"esc is no", []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonEsc}, false},
+	}
+	for _, tt := range tests {
+		disp, _ := testutil.NewDisplay(t)
+		got, err := Confirm(disp, &fakeButtons{presses: tt.presses}, "Reboot the firewall now?")
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfirm_FromMenuAction(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	confirmed := false
+	var mc *MenuController
+	root := NewMenu("ROOT", []MenuItem{{
+		Label: "Reboot",
+		Action: func() error {
+			ok, err := Confirm(d, mc.Buttons(), "Reboot?")
+			confirmed = ok
+			return err
+		},
+	}})
+
+	buttons := &fakeButtons{presses: []eziog500.Button{
+		eziog500.ButtonEnter, // Run the action
+		eziog500.ButtonLeft,  // Yes
+		eziog500.ButtonEnter, // Confirm
+	}}
+	mc = NewMenuController(d, buttons, root)
+	if err := mc.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if !confirmed {
+		t.Error("Expected the action's dialog to receive the controller's presses")
+	}
+}
+
+func TestAlert(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	d.FrameBuffer().SetPixel(0, 0, true)
+
+	buttons := &fakeButtons{presses: []eziog500.Button{eziog500.ButtonDown, eziog500.ButtonEnter}}
+	if err := Alert(d, buttons, "GATEWAY", "WAN_DHCP is down"); err != nil {
+		t.Fatal(err)
+	}
+	if fb := d.FrameBuffer(); !fb.GetPixel(0, 0) || fb.CountSetPixels() != 1 {
+		t.Error("Expected the screen restored after the alert")
+	}
+}
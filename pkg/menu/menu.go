@@ -2,18 +2,41 @@
 package menu
 
 import (
+	"sync"
+	"time"
+
 	"github.com/sagostin/ezio-g500/pkg/display"
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
 )
 
 // MenuItem represents a single menu item.
 type MenuItem struct {
 	Label    string
-	Action   func() error  // Action to execute when selected
-	SubMenu  *Menu         // Optional submenu
-	Value    func() string // Optional dynamic value display
-	Disabled bool          // If true, item cannot be selected
+	Action   func() error     // Action to execute when selected
+	SubMenu  *Menu            // Optional submenu
+	Value    func() string    // Optional dynamic value display
+	TwoLine  bool             // If true, Value is shown indented on a second line
+	Slider   *Slider          // Optional adjustable value (Left/Right change it)
+	Toggle   func() bool      // Optional boolean state, shown as a checkbox
+	OnToggle func(bool) error // Called with the new state when a toggle is selected
+	Input    *TextInput       // Optional on-screen keyboard opened when selected
+	Disabled bool             // If true, item cannot be selected
+}
+
+// valueIndent is the pixel indent of the value line for two-line items.
+const valueIndent = 12
+
+// checkboxX is the left edge of a toggle item's checkbox.
+const checkboxX = 2
+
+// rows returns the number of display rows the item occupies.
+func (item *MenuItem) rows() int {
+	if item.TwoLine && item.Value != nil {
+		return 2
+	}
+	return 1
 }
 
 // Menu represents an interactive menu.
@@ -23,7 +46,7 @@ type Menu struct {
 	Parent       *Menu
 	selected     int
 	scrollOffset int
-	maxVisible   int
+	maxVisible   int // Visible rows below the title (two-line items use two)
 }
 
 // NewMenu creates a new menu with the given title and items.
@@ -35,7 +58,7 @@ func NewMenu(title string, items []MenuItem) *Menu {
 	}
 }
 
-// SetMaxVisible sets the maximum number of visible items.
+// SetMaxVisible sets the maximum number of visible rows.
 func (m *Menu) SetMaxVisible(n int) {
 	m.maxVisible = n
 }
@@ -105,14 +128,58 @@ func (m *Menu) allDisabled() bool {
 }
 
 func (m *Menu) updateScroll() {
-	// Scroll down if selected is below visible area
-	if m.selected >= m.scrollOffset+m.maxVisible {
-		m.scrollOffset = m.selected - m.maxVisible + 1
-	}
 	// Scroll up if selected is above visible area
 	if m.selected < m.scrollOffset {
 		m.scrollOffset = m.selected
 	}
+	// Scroll down until the selected item fits in the visible rows
+	for m.scrollOffset < m.selected && m.rowsBetween(m.scrollOffset, m.selected) > m.maxVisible {
+		m.scrollOffset++
+	}
+}
+
+// rowsBetween returns the rows used by items from..to inclusive.
+func (m *Menu) rowsBetween(from, to int) int {
+	rows := 0
+	for i := from; i <= to && i < len(m.Items); i++ {
+		rows += m.Items[i].rows()
+	}
+	return rows
+}
+
+// visibleEnd returns the index after the last item that fits on screen.
+func (m *Menu) visibleEnd() int {
+	rows := 0
+	end := m.scrollOffset
+	for end < len(m.Items) {
+		rows += m.Items[end].rows()
+		if rows > m.maxVisible && end > m.scrollOffset {
+			break
+		}
+		end++
+	}
+	return end
+}
+
+// SelectedItem returns the currently selected item, or nil if there is none.
+func (m *Menu) SelectedItem() *MenuItem {
+	if m.selected < 0 || m.selected >= len(m.Items) {
+		return nil
+	}
+	return &m.Items[m.selected]
+}
+
+// AdjustSelected changes the selected item's slider by steps. It reports
+// whether the selected item is an enabled slider.
+func (m *Menu) AdjustSelected(steps int) (bool, error) {
+	if m.selected < 0 || m.selected >= len(m.Items) {
+		return false, nil
+	}
+	item := m.Items[m.selected]
+	if item.Slider == nil || item.Disabled {
+		return false, nil
+	}
+	return true, item.Slider.Adjust(steps)
 }
 
 // Execute runs the action of the currently selected item.
@@ -126,6 +193,12 @@ func (m *Menu) Execute() (*Menu, error) {
 		if item.SubMenu != nil {
 			return item.SubMenu, nil
 		}
+		if item.Toggle != nil {
+			if item.OnToggle == nil {
+				return nil, nil
+			}
+			return nil, item.OnToggle(!item.Toggle())
+		}
 		if item.Action != nil {
 			return nil, item.Action()
 		}
@@ -135,151 +208,471 @@ func (m *Menu) Execute() (*Menu, error) {
 
 // Render draws the menu to the display.
 func (m *Menu) Render(d *display.Display) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
+	// Call the items' callbacks before locking the display, so they can
+	// use it
+	endIdx := m.visibleEnd()
+	values := make([]string, endIdx-m.scrollOffset)
+	checked := make([]bool, len(values))
+	for i := range values {
+		item := m.Items[m.scrollOffset+i]
+		if item.Value != nil {
+			values[i] = item.Value()
+		}
+		if item.Toggle != nil {
+			checked[i] = item.Toggle()
+		}
+	}
 
-	f := font.BuiltinFont
-	lineHeight := f.Height()
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
 
-	// Draw title bar (inverted)
-	font.RenderTextInverted(fb, f, 0, 0, m.Title)
+		f := font.BuiltinFont
+		lineHeight := f.LineHeight()
 
-	// Draw menu items
-	y := lineHeight
-	endIdx := m.scrollOffset + m.maxVisible
-	if endIdx > len(m.Items) {
-		endIdx = len(m.Items)
-	}
+		// Draw title bar (inverted)
+		font.RenderTextInverted(fb, f, 0, 0, m.Title)
+
+		// Draw menu items, keeping text clear of the scrollbar
+		y := lineHeight
+		scrolls := m.scrollOffset > 0 || endIdx < len(m.Items)
+		right := eziog500.Width
+		if scrolls {
+			right = scrollbarX - 1
+		}
 
-	for i := m.scrollOffset; i < endIdx; i++ {
-		item := m.Items[i]
+		for i := m.scrollOffset; i < endIdx; i++ {
+			item := m.Items[i]
 
-		text := item.Label
-		if item.Value != nil {
-			val := item.Value()
-			if val != "" {
+			text := item.Label
+			val := values[i-m.scrollOffset]
+			if val != "" && !item.TwoLine {
 				text = item.Label + ": " + val
 			}
-		}
+			if item.Slider != nil {
+				// Leave room for the bar on the right
+				text = font.TruncateText(f, item.Label+": "+item.Slider.String(), sliderBarX-2-font.MeasureText(f, "  "))
+			}
+			height := item.rows() * lineHeight
+
+			// Toggles draw a checkbox in place of the text prefix
+			textX := 2
+			var box *ui.Checkbox
+			if item.Toggle != nil {
+				box = ui.NewCheckbox("")
+				box.Checked = checked[i-m.scrollOffset]
+				textX = checkboxX + box.BoxSize() + 3
+			}
 
-		if i == m.selected {
-			// Draw selected item inverted
-			fb.FillRect(0, y, eziog500.Width, lineHeight, true)
-			// Render text in "off" pixels
-			curX := 2
-			for _, r := range text {
-				glyph := f.GetGlyph(r)
-				if glyph == nil {
-					continue
+			if i == m.selected {
+				// Draw selected item inverted
+				fb.FillRect(0, y, eziog500.Width, height, true)
+				if box != nil {
+					box.RenderBox(fb, checkboxX, y, false)
 				}
-				for col, b := range glyph {
-					for bit := 0; bit < 8; bit++ {
-						if (b & (1 << bit)) != 0 {
-							fb.SetPixel(curX+col, y+bit, false)
-						}
-					}
+				renderTextOff(fb, f, textX, y, font.TruncateText(f, text, right-textX))
+				if item.rows() == 2 {
+					renderTextOff(fb, f, valueIndent, y+lineHeight, font.TruncateText(f, val, right-valueIndent))
+				}
+				if item.Slider != nil {
+					item.Slider.renderBar(fb, y, lineHeight, false)
+				}
+			} else {
+				// Normal item
+				prefix := "  "
+				if item.Disabled {
+					prefix = "- "
+				}
+				if box != nil {
+					box.RenderBox(fb, checkboxX, y, true)
+					font.RenderTextClipped(fb, f, textX, y, right-textX, text)
+				} else {
+					font.RenderTextClipped(fb, f, 0, y, right, prefix+text)
+				}
+				if item.rows() == 2 {
+					font.RenderTextClipped(fb, f, valueIndent, y+lineHeight, right-valueIndent, val)
+				}
+				if item.Slider != nil {
+					item.Slider.renderBar(fb, y, lineHeight, true)
 				}
-				curX += len(glyph)
 			}
-		} else {
-			// Normal item
-			prefix := "  "
-			if item.Disabled {
-				prefix = "- "
+			y += height
+		}
+
+		// Draw scroll position if not all items fit
+		if scrolls {
+			trackY := lineHeight
+			trackH := m.maxVisible * lineHeight
+			if trackH >= scrollbarMinTrack {
+				m.renderScrollbar(fb, trackY, trackH, endIdx-m.scrollOffset)
+			} else {
+				m.renderScrollArrows(fb, trackY, trackH, endIdx)
 			}
-			font.RenderText(fb, f, 0, y, prefix+text)
 		}
-		y += lineHeight
+	})
+}
+
+// Scrollbar placement on the right edge, and the shortest track worth drawing.
+const (
+	scrollbarX        = eziog500.Width - 3
+	scrollbarW        = 3
+	scrollbarMinTrack = 16
+	scrollbarMinThumb = 3
+)
+
+// renderScrollbar draws a dotted track with a thumb showing which part of
+// the item list is visible. The track is cleared first so it stays visible
+// over a selected (inverted) row.
+func (m *Menu) renderScrollbar(fb *eziog500.FrameBuffer, trackY, trackH, visible int) {
+	fb.FillRect(scrollbarX-1, trackY, scrollbarW+1, trackH, false)
+	for y := trackY; y < trackY+trackH; y += 2 {
+		fb.SetPixel(scrollbarX+1, y, true)
 	}
+	pos, size := scrollThumb(trackH, len(m.Items), visible, m.scrollOffset)
+	fb.FillRect(scrollbarX, trackY+pos, scrollbarW, size, true)
+}
 
-	// Draw scroll indicators if needed
+// renderScrollArrows draws small up/down arrows for menus too short for a
+// scrollbar.
+func (m *Menu) renderScrollArrows(fb *eziog500.FrameBuffer, trackY, trackH, endIdx int) {
 	if m.scrollOffset > 0 {
 		// Up arrow indicator
-		fb.SetPixel(124, lineHeight+2, true)
-		fb.SetPixel(125, lineHeight+1, true)
-		fb.SetPixel(126, lineHeight+2, true)
+		fb.SetPixel(124, trackY+2, true)
+		fb.SetPixel(125, trackY+1, true)
+		fb.SetPixel(126, trackY+2, true)
 	}
 	if endIdx < len(m.Items) {
 		// Down arrow indicator
-		lastY := lineHeight + m.maxVisible*lineHeight - 3
+		lastY := trackY + trackH - 3
 		fb.SetPixel(124, lastY, true)
 		fb.SetPixel(125, lastY+1, true)
 		fb.SetPixel(126, lastY, true)
 	}
+}
+
+// scrollThumb returns the offset and height of the scrollbar thumb within a
+// track of trackH pixels, for total items of which visible are shown
+// starting at offset.
+func scrollThumb(trackH, total, visible, offset int) (pos, size int) {
+	if total <= 0 || visible >= total {
+		return 0, trackH
+	}
+	size = trackH * visible / total
+	if size < scrollbarMinThumb {
+		size = scrollbarMinThumb
+	}
+	maxOffset := total - visible
+	if offset > maxOffset {
+		offset = maxOffset
+	}
+	pos = (trackH - size) * offset / maxOffset
+	return pos, size
+}
+
+// renderTextOff renders text as "off" pixels, for use on a filled background.
+func renderTextOff(fb *eziog500.FrameBuffer, f font.Font, x, y int, text string) {
+	curX := x
+	for _, r := range text {
+		glyph := f.GetGlyph(r)
+		if glyph == nil {
+			continue
+		}
+		for col, b := range glyph {
+			for bit := 0; bit < 8; bit++ {
+				if (b & (1 << bit)) != 0 {
+					fb.SetPixel(curX+col, y+bit, false)
+				}
+			}
+		}
+		curX += len(glyph)
+	}
+}
 
-	return d.Update()
+// ButtonSource provides button presses to a MenuController.
+// Both eziog500.ButtonReader and eziog500.SessionButtonReader implement it.
+type ButtonSource interface {
+	ButtonChannel() (<-chan eziog500.Button, func())
+}
+
+// EventSource is a ButtonSource that also reports held buttons. Both
+// eziog500 readers implement it. A MenuController given an EventSource
+// scrolls while Up or Down is held and returns to the root menu when Esc is
+// held.
+type EventSource interface {
+	ButtonSource
+	EventChannel() (<-chan eziog500.ButtonEvent, func())
 }
 
 // MenuController manages menu navigation with button input.
 type MenuController struct {
 	display      *display.Display
-	buttonReader *eziog500.ButtonReader
+	buttonReader ButtonSource
 	currentMenu  *Menu
 	rootMenu     *Menu
+	input        *TextInput // Active keyboard, if any
+	events       <-chan eziog500.ButtonEvent
+	idleTimeout  time.Duration
+	onIdle       func()
+	idle         bool // OnIdle has run and no button has been pressed since
+	done         chan struct{}
+	stopOnce     sync.Once
 }
 
 // NewMenuController creates a menu controller.
-func NewMenuController(d *display.Display, br *eziog500.ButtonReader, rootMenu *Menu) *MenuController {
+func NewMenuController(d *display.Display, br ButtonSource, rootMenu *Menu) *MenuController {
 	return &MenuController{
 		display:      d,
 		buttonReader: br,
 		currentMenu:  rootMenu,
 		rootMenu:     rootMenu,
+		done:         make(chan struct{}),
 	}
 }
 
+// Stop makes Run return, including a Run that is in progress, once the
+// current action, if any, finishes. A stopped controller stays stopped.
+func (mc *MenuController) Stop() {
+	mc.stopOnce.Do(func() { close(mc.done) })
+}
+
+// SetIdleTimeout calls onIdle after d without button presses (0 disables).
+// onIdle typically calls GoToRoot and draws a status screen. The next button
+// press after going idle only redraws the menu, so it isn't acted on blindly.
+func (mc *MenuController) SetIdleTimeout(d time.Duration, onIdle func()) {
+	mc.idleTimeout = d
+	mc.onIdle = onIdle
+}
+
 // Run starts the menu controller loop.
-// It blocks until the menu is exited (by returning from root menu).
+// It blocks until the menu is exited (by returning from root menu) or Stop
+// is called.
 func (mc *MenuController) Run() error {
-	buttons, stop := mc.buttonReader.ButtonChannel()
+	events, stop := buttonEvents(mc.buttonReader)
 	defer stop()
+	mc.events = events
+
+	// Idle timer is only armed when a timeout is set (nil channel never fires)
+	var idleTimer *time.Timer
+	var idleC <-chan time.Time
+	if mc.idleTimeout > 0 {
+		idleTimer = time.NewTimer(mc.idleTimeout)
+		defer idleTimer.Stop()
+		idleC = idleTimer.C
+	}
 
 	// Initial render
-	if err := mc.currentMenu.Render(mc.display); err != nil {
+	if err := mc.Refresh(); err != nil {
 		return err
 	}
 
-	for btn := range buttons {
-		needsRender := true
-
-		switch btn {
-		case eziog500.ButtonUp:
-			mc.currentMenu.SelectPrevious()
-
-		case eziog500.ButtonDown:
-			mc.currentMenu.SelectNext()
+	for {
+		select {
+		case <-mc.done:
+			return nil
+
+		case <-idleC:
+			mc.idle = true
+			if mc.onIdle != nil {
+				mc.onIdle()
+			}
 
-		case eziog500.ButtonEnter, eziog500.ButtonRight:
-			subMenu, err := mc.currentMenu.Execute()
-			if err != nil {
-				// Could display error, for now just continue
-				needsRender = false
+		case ev, ok := <-events:
+			if !ok {
+				return nil
 			}
-			if subMenu != nil {
-				mc.currentMenu = subMenu
+			if idleTimer != nil {
+				if !idleTimer.Stop() {
+					// Drain a fire that raced with this button
+					select {
+					case <-idleTimer.C:
+					default:
+					}
+				}
+				idleTimer.Reset(mc.idleTimeout)
 			}
 
-		case eziog500.ButtonLeft, eziog500.ButtonEsc:
-			// Go back to parent menu
-			if mc.currentMenu.Parent != nil {
-				mc.currentMenu = mc.currentMenu.Parent
+			needsRender, exit := true, false
+			if mc.idle {
+				// Wake up: show the menu again without acting on the press
+				mc.idle = false
 			} else {
-				// At root menu, exit
+				needsRender, exit = mc.handleEvent(ev)
+			}
+			if exit {
 				return nil
 			}
 
-		default:
-			needsRender = false
+			if needsRender {
+				if err := mc.Refresh(); err != nil {
+					return err
+				}
+			}
 		}
+	}
+}
 
-		if needsRender {
-			if err := mc.currentMenu.Render(mc.display); err != nil {
-				return err
+// handleEvent applies a button event to the menu state. Repeats only act
+// where holding a button makes sense, and never enter or leave a menu.
+func (mc *MenuController) handleEvent(ev eziog500.ButtonEvent) (needsRender, exit bool) {
+	switch ev.Kind {
+	case eziog500.Press:
+		return mc.handleButton(ev.Button)
+
+	case eziog500.Repeat:
+		switch ev.Button {
+		case eziog500.ButtonUp, eziog500.ButtonDown:
+			return mc.handleButton(ev.Button)
+		case eziog500.ButtonLeft, eziog500.ButtonRight:
+			item := mc.currentMenu.SelectedItem()
+			if mc.input != nil || (item != nil && item.Slider != nil && !item.Disabled) {
+				return mc.handleButton(ev.Button)
 			}
 		}
+
+	case eziog500.LongPress:
+		if ev.Button == eziog500.ButtonEsc && mc.input == nil && mc.currentMenu != mc.rootMenu {
+			mc.GoToRoot()
+			return true, false
+		}
+	}
+	return false, false
+}
+
+// handleButton applies a button press to the menu state. It reports whether
+// the menu needs re-rendering and whether the root menu was exited.
+func (mc *MenuController) handleButton(btn eziog500.Button) (needsRender, exit bool) {
+	// An open keyboard takes all buttons until it finishes
+	if mc.input != nil {
+		if done, _ := mc.input.HandleButton(btn); done {
+			mc.input = nil
+		}
+		return true, false
 	}
 
-	return nil
+	needsRender = true
+
+	switch btn {
+	case eziog500.ButtonUp:
+		mc.currentMenu.SelectPrevious()
+
+	case eziog500.ButtonDown:
+		mc.currentMenu.SelectNext()
+
+	case eziog500.ButtonRight:
+		// Right adjusts a focused slider, otherwise it acts like Enter
+		if ok, _ := mc.currentMenu.AdjustSelected(1); ok {
+			break
+		}
+		needsRender = mc.execute()
+
+	case eziog500.ButtonEnter:
+		needsRender = mc.execute()
+
+	case eziog500.ButtonLeft:
+		// Left adjusts a focused slider, otherwise it acts like Esc
+		if ok, _ := mc.currentMenu.AdjustSelected(-1); ok {
+			break
+		}
+		return mc.back()
+
+	case eziog500.ButtonEsc:
+		return mc.back()
+
+	default:
+		needsRender = false
+	}
+	return needsRender, false
+}
+
+// execute runs the selected item, entering its submenu if it has one.
+// It reports whether the menu needs re-rendering.
+func (mc *MenuController) execute() bool {
+	if item := mc.currentMenu.SelectedItem(); item != nil && item.Input != nil && !item.Disabled {
+		mc.input = item.Input
+		mc.input.reset()
+		return true
+	}
+
+	subMenu, err := mc.currentMenu.Execute()
+	if err != nil {
+		// Could display error, for now just continue
+		return false
+	}
+	if subMenu != nil {
+		mc.currentMenu = subMenu
+	}
+	return true
+}
+
+// back goes to the parent menu, or reports exit at the root menu.
+func (mc *MenuController) back() (needsRender, exit bool) {
+	if mc.currentMenu.Parent == nil {
+		return false, true
+	}
+	mc.currentMenu = mc.currentMenu.Parent
+	return true, false
+}
+
+// Buttons returns a ButtonSource that reads from the controller's own button
+// stream, for dialogs like Confirm run from a MenuItem.Action. Actions run on
+// the controller's loop, so the dialog receives every press until it returns.
+// Presses and repeats are passed on; long presses and chords are not.
+func (mc *MenuController) Buttons() ButtonSource {
+	return controllerButtons{mc}
+}
+
+type controllerButtons struct{ mc *MenuController }
+
+func (c controllerButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	ch := make(chan eziog500.Button)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ev, ok := <-c.mc.events:
+				if !ok {
+					return
+				}
+				if ev.Kind != eziog500.Press && ev.Kind != eziog500.Repeat {
+					continue
+				}
+				select {
+				case ch <- ev.Button:
+				case <-done:
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ch, func() { close(done) }
+}
+
+// buttonEvents returns src's event stream, or its presses as Press events
+// if it can't report held buttons.
+func buttonEvents(src ButtonSource) (<-chan eziog500.ButtonEvent, func()) {
+	if es, ok := src.(EventSource); ok {
+		return es.EventChannel()
+	}
+
+	buttons, stop := src.ButtonChannel()
+	ch := make(chan eziog500.ButtonEvent)
+	done := make(chan struct{})
+	go func() {
+		defer close(ch)
+		for btn := range buttons {
+			select {
+			case ch <- eziog500.ButtonEvent{Button: btn, Kind: eziog500.Press}:
+			case <-done:
+				return
+			}
+		}
+	}()
+	return ch, func() {
+		close(done)
+		stop()
+	}
 }
 
 // CurrentMenu returns the currently active menu.
@@ -290,9 +683,13 @@ func (mc *MenuController) CurrentMenu() *Menu {
 // GoToRoot navigates back to the root menu.
 func (mc *MenuController) GoToRoot() {
 	mc.currentMenu = mc.rootMenu
+	mc.input = nil
 }
 
-// Refresh re-renders the current menu.
+// Refresh re-renders the current menu, or the keyboard if one is open.
 func (mc *MenuController) Refresh() error {
+	if mc.input != nil {
+		return mc.input.Render(mc.display)
+	}
 	return mc.currentMenu.Render(mc.display)
 }
@@ -0,0 +1,371 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestMenu_TwoLineItemRows(t *testing.T) {
+	value := func() string { return "192.168.100.254" }
+	m := NewMenu("TEST", []MenuItem{
+		{Label: "em0", Value: value, TwoLine: true},
+		{Label: "em1", Value: value},
+		{Label: "Plain"},
+	})
+
+	if rows := m.Items[0].rows(); rows != 2 {
+		t.Errorf("Two-line item should use 2 rows, got %d", rows)
+	}
+	if rows := m.Items[1].rows(); rows != 1 {
+		t.Errorf("Single-line value item should use 1 row, got %d", rows)
+	}
+	if rows := m.rowsBetween(0, 2); rows != 4 {
+		t.Errorf("Expected 4 rows for the whole menu, got %d", rows)
+	}
+}
+
+func TestMenu_TwoLineScrolling(t *testing.T) {
+	value := func() string { return "10.0.0.1" }
+	var items []MenuItem
+	for i := 0; i < 5; i++ {
+		items = append(items, MenuItem{Label: "iface", Value: value, TwoLine: true})
+	}
+	m := NewMenu("NETWORK", items)
+	m.SetMaxVisible(6)
+
+	// Three two-line items fit in six rows
+	if end := m.visibleEnd(); end != 3 {
+		t.Fatalf("Expected 3 visible items, got %d", end)
+	}
+
+	m.SelectNext()
+	m.SelectNext()
+	if m.scrollOffset != 0 {
+		t.Errorf("Third item is visible, scroll offset should stay 0, got %d", m.scrollOffset)
+	}
+
+	m.SelectNext()
+	if m.scrollOffset != 1 {
+		t.Errorf("Fourth item should scroll by one item, got offset %d", m.scrollOffset)
+	}
+	if end := m.visibleEnd(); end != 4 {
+		t.Errorf("Expected items 1-3 visible, got end %d", end)
+	}
+
+	// Wrap back to the top
+	m.SelectNext()
+	m.SelectNext()
+	if m.selected != 0 || m.scrollOffset != 0 {
+		t.Errorf("Expected wrap to top, got selected=%d offset=%d", m.selected, m.scrollOffset)
+	}
+}
+
+func TestMenu_RenderTwoLineSelection(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	m := NewMenu("NETWORK", []MenuItem{
+		{Label: "em0", Value: func() string { return "10.0.0.1" }, TwoLine: true},
+		{Label: "Back"},
+	})
+
+	if err := m.Render(d); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	fb := d.FrameBuffer()
+	lh := 8
+	// The selection highlight covers both rows of the selected item
+	if !fb.GetPixel(127, lh) || !fb.GetPixel(127, 2*lh+lh-1) {
+		t.Error("Selection highlight should cover both rows of a two-line item")
+	}
+	// The next item starts after two rows and is not highlighted
+	if fb.GetPixel(127, 3*lh) {
+		t.Error("Item after a two-line item should not be highlighted")
+	}
+}
+
+// fakeButtons is a ButtonSource fed from a slice of synthetic presses.
+type fakeButtons struct {
+	presses []eziog500.Button
+}
+
+func (f *fakeButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	ch := make(chan eziog500.Button, len(f.presses))
+	for _, b := range f.presses {
+		ch <- b
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func TestSlider_AdjustClamps(t *testing.T) {
+	var changes []int
+	s := &Slider{Value: 250, Min: 0, Max: 255, Step: 15, OnChange: func(v int) error {
+		changes = append(changes, v)
+		return nil
+	}}
+
+	s.Adjust(1)
+	if s.Value != 255 {
+		t.Errorf("Expected value clamped to 255, got %d", s.Value)
+	}
+	s.Adjust(1) // Already at max, no change
+	s.Adjust(-20)
+	if s.Value != 0 {
+		t.Errorf("Expected value clamped to 0, got %d", s.Value)
+	}
+	if len(changes) != 2 || changes[0] != 255 || changes[1] != 0 {
+		t.Errorf("Expected OnChange(255), OnChange(0), got %v", changes)
+	}
+}
+
+func TestSlider_AdjustRestoresOnError(t *testing.T) {
+	s := &Slider{Value: 10, Min: 0, Max: 20, Step: 5, OnChange: func(int) error {
+		return errors.New("write failed")
+	}}
+	if err := s.Adjust(1); err == nil {
+		t.Fatal("Expected error from OnChange")
+	}
+	if s.Value != 10 {
+		t.Errorf("Expected value restored to 10, got %d", s.Value)
+	}
+}
+
+func TestMenuController_SliderButtons(t *testing.T) {
+	var last int
+	slider := &Slider{Value: 100, Min: 0, Max: 255, Step: 15, OnChange: func(v int) error {
+		last = v
+		return nil
+	}}
+	root := NewMenu("ROOT", nil)
+	sub := NewMenu("DISPLAY", []MenuItem{{Label: "Backlight", Slider: slider}})
+	root.AddSubMenu("Display", sub)
+
+	buttons := &fakeButtons{presses: []eziog500.Button{
+		eziog500.ButtonEnter, // Open the submenu
+		eziog500.ButtonRight, // 115
+		eziog500.ButtonRight, // 130
+		eziog500.ButtonLeft,  // 115, stays in the submenu
+	}}
+	disp, _ := testutil.NewDisplay(t)
+	mc := NewMenuController(disp, buttons, root)
+	if err := mc.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	if mc.CurrentMenu() != sub {
+		t.Error("Left on a slider should not navigate back")
+	}
+	if slider.Value != 115 || last != 115 {
+		t.Errorf("Expected slider at 115, got value %d, last change %d", slider.Value, last)
+	}
+
+	// Left on a non-slider item still goes back, and Esc at the root exits
+	mc.currentMenu = sub
+	sub.Items = append(sub.Items, MenuItem{Label: "Other"})
+	sub.SelectNext()
+	if _, exit := mc.handleButton(eziog500.ButtonLeft); exit || mc.CurrentMenu() != root {
+		t.Error("Left on a plain item should return to the parent menu")
+	}
+	if _, exit := mc.handleButton(eziog500.ButtonEsc); !exit {
+		t.Error("Esc at the root menu should exit")
+	}
+}
+
+func TestMenu_ToggleItem(t *testing.T) {
+	enabled := false
+	var calls []bool
+	m := NewMenu("SETTINGS", []MenuItem{{
+		Label:  "Night mode",
+		Toggle: func() bool { return enabled },
+		OnToggle: func(v bool) error {
+			calls = append(calls, v)
+			enabled = v
+			return nil
+		},
+	}})
+	d, _ := testutil.NewDisplay(t)
+
+	// The check mark's middle pixel sits inside the box (drawn off on the selected row)
+	checkPixel := func() bool {
+		if err := m.Render(d); err != nil {
+			t.Fatal(err)
+		}
+		return !d.FrameBuffer().GetPixel(checkboxX+3, 8+6)
+	}
+
+	if checkPixel() {
+		t.Error("Unchecked box should have no check mark")
+	}
+
+	mc := NewMenuController(d, &fakeButtons{}, m)
+	mc.handleButton(eziog500.ButtonEnter)
+	if len(calls) != 1 || !calls[0] {
+		t.Fatalf("Expected OnToggle(true), got %v", calls)
+	}
+	if !checkPixel() {
+		t.Error("Checked box should draw a check mark")
+	}
+
+	mc.handleButton(eziog500.ButtonEnter)
+	if len(calls) != 2 || calls[1] || checkPixel() {
+		t.Errorf("Second Enter should toggle back off, got %v", calls)
+	}
+}
+
+// chanButtons is a ButtonSource backed by a channel the test controls.
+type chanButtons chan eziog500.Button
+
+func (c chanButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	return c, func() {}
+}
+
+func TestMenuController_IdleTimeout(t *testing.T) {
+	root := NewMenu("ROOT", []MenuItem{{Label: "A"}, {Label: "B"}})
+	sub := NewMenu("SUB", []MenuItem{{Label: "C"}})
+	root.AddSubMenu("Sub", sub)
+
+	buttons := make(chanButtons)
+	disp, _ := testutil.NewDisplay(t)
+	mc := NewMenuController(disp, buttons, root)
+	mc.currentMenu = sub
+
+	idle := make(chan struct{}, 1)
+	mc.SetIdleTimeout(20*time.Millisecond, func() {
+		mc.GoToRoot()
+		idle <- struct{}{}
+	})
+
+	done := make(chan error)
+	go func() { done <- mc.Run() }()
+
+	select {
+	case <-idle:
+	case <-time.After(time.Second):
+		t.Fatal("OnIdle did not fire")
+	}
+
+	// The first press after idling only wakes the menu
+	buttons <- eziog500.ButtonDown
+	close(buttons)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if mc.CurrentMenu() != root {
+		t.Error("Expected OnIdle to return to the root menu")
+	}
+	if root.Selected() != 0 {
+		t.Error("Wake-up press should not move the selection")
+	}
+}
+
+// fakeEvents is an EventSource that replays a fixed list of events.
+type fakeEvents struct {
+	events []eziog500.ButtonEvent
+}
+
+func (f *fakeEvents) ButtonChannel() (<-chan eziog500.Button, func()) {
+	panic("MenuController should use EventChannel")
+}
+
+func (f *fakeEvents) EventChannel() (<-chan eziog500.ButtonEvent, func()) {
+	ch := make(chan eziog500.ButtonEvent, len(f.events))
+	for _, ev := range f.events {
+		ch <- ev
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func TestMenuController_HeldButtons(t *testing.T) {
+	items := make([]MenuItem, 6)
+	for i := range items {
+		items[i].Label = fmt.Sprintf("Item %d", i)
+	}
+	root := NewMenu("ROOT", nil)
+	sub := NewMenu("SUB", items)
+	root.AddSubMenu("Sub", sub)
+
+	ev := func(b eziog500.Button, k eziog500.ButtonEventKind) eziog500.ButtonEvent {
+		return eziog500.ButtonEvent{Button: b, Kind: k}
+	}
+	src := &fakeEvents{events: []eziog500.ButtonEvent{
+		ev(eziog500.ButtonEnter, eziog500.Press),  // Open the submenu
+		ev(eziog500.ButtonEnter, eziog500.Repeat), // Ignored, doesn't run an item
+		ev(eziog500.ButtonDown, eziog500.Press),   // Item 1
+		ev(eziog500.ButtonDown, eziog500.Repeat),  // Item 2
+		ev(eziog500.ButtonDown, eziog500.Repeat),  // Item 3
+		ev(eziog500.ButtonRight, eziog500.Repeat), // Ignored, not a slider
+	}}
+	disp, _ := testutil.NewDisplay(t)
+	mc := NewMenuController(disp, src, root)
+	if err := mc.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if mc.CurrentMenu() != sub {
+		t.Fatal("Repeats should not navigate between menus")
+	}
+	if sub.Selected() != 3 {
+		t.Errorf("Expected repeats to scroll to item 3, got %d", sub.Selected())
+	}
+
+	// Holding Esc jumps back to the root
+	if needsRender, exit := mc.handleEvent(ev(eziog500.ButtonEsc, eziog500.LongPress)); !needsRender || exit {
+		t.Error("Long-press Esc should re-render without exiting")
+	}
+	if mc.CurrentMenu() != root {
+		t.Error("Expected long-press Esc to return to the root menu")
+	}
+}
+
+func TestScrollThumb(t *testing.T) {
+	// 12 items, 6 visible, 48px track: thumb is half the track
+	pos, size := scrollThumb(48, 12, 6, 0)
+	if pos != 0 || size != 24 {
+		t.Errorf("Top: expected 0/24, got %d/%d", pos, size)
+	}
+	pos, size = scrollThumb(48, 12, 6, 3)
+	if pos != 12 || size != 24 {
+		t.Errorf("Middle: expected 12/24, got %d/%d", pos, size)
+	}
+	pos, _ = scrollThumb(48, 12, 6, 6)
+	if pos != 24 {
+		t.Errorf("Bottom: expected thumb at 24, got %d", pos)
+	}
+
+	// Very long menus keep a minimum thumb size that still reaches the end
+	pos, size = scrollThumb(48, 200, 6, 194)
+	if size != scrollbarMinThumb || pos+size != 48 {
+		t.Errorf("Long menu: expected %d px thumb ending at 48, got %d/%d", scrollbarMinThumb, pos, size)
+	}
+}
+
+func TestMenu_RenderScrollbar(t *testing.T) {
+	var items []MenuItem
+	for i := 0; i < 12; i++ {
+		items = append(items, MenuItem{Label: "Item"})
+	}
+	m := NewMenu("LONG", items)
+	d, _ := testutil.NewDisplay(t)
+
+	for i := 0; i < 11; i++ {
+		m.SelectNext()
+	}
+	if err := m.Render(d); err != nil {
+		t.Fatal(err)
+	}
+
+	// Scrolled to the end: thumb fills the bottom of the track, top is clear
+	fb := d.FrameBuffer()
+	bottom := 8 + 6*8 - 1
+	if !fb.GetPixel(scrollbarX, bottom) {
+		t.Error("Expected the thumb at the bottom of the track")
+	}
+	if fb.GetPixel(scrollbarX, 8) {
+		t.Error("Expected no thumb at the top of the track")
+	}
+}
@@ -13,17 +13,36 @@ import (
 // PfSenseMenuBuilder creates a pre-built menu for pfSense systems.
 type PfSenseMenuBuilder struct {
 	display *display.Display
-	metrics *pfsense.SystemMetrics
+	metrics pfsense.MetricsProvider
+	buttons ButtonSource  // For confirmation dialogs
+	power   bool          // Offer Reboot and Halt
+	run     CommandRunner // Runs the power commands
+
+	svc      pfsense.ServiceController // Restarts services; nil hides the Services submenu
+	svcNames []string
 }
 
 // NewPfSenseMenuBuilder creates a new pfSense menu builder.
 func NewPfSenseMenuBuilder(d *display.Display) *PfSenseMenuBuilder {
 	return &PfSenseMenuBuilder{
 		display: d,
-		metrics: pfsense.NewSystemMetrics(),
+		metrics: pfsense.NewMetricsProvider(),
+		run:     runCommand,
 	}
 }
 
+// peakResetter is a metrics provider that tracks peak traffic rates, such as
+// pfsense.StatusDaemon.
+type peakResetter interface {
+	ResetPeaks()
+}
+
+// SetMetricsProvider replaces where the menu reads metrics from, e.g. a
+// running StatusDaemon. Call it before Build.
+func (b *PfSenseMenuBuilder) SetMetricsProvider(p pfsense.MetricsProvider) {
+	b.metrics = p
+}
+
 // Build creates the complete pfSense menu structure.
 func (b *PfSenseMenuBuilder) Build() *Menu {
 	// Main Menu
@@ -41,6 +60,14 @@ func (b *PfSenseMenuBuilder) Build() *Menu {
 	displayMenu := b.buildDisplayMenu()
 	mainMenu.AddSubMenu("Display", displayMenu)
 
+	// Service restarts and reboot and halt, when enabled
+	if b.svc != nil {
+		mainMenu.AddSubMenu("Services", b.buildServicesMenu())
+	}
+	if b.power {
+		mainMenu.AddSubMenu("System", b.buildSystemMenu())
+	}
+
 	// Actions
 	mainMenu.AddItem(MenuItem{
 		Label: "Refresh",
@@ -111,12 +138,16 @@ func (b *PfSenseMenuBuilder) buildStatusMenu() *Menu {
 func (b *PfSenseMenuBuilder) buildNetworkMenu() *Menu {
 	menu := NewMenu("NETWORK", []MenuItem{})
 
-	// Add interface items dynamically
-	m, _ := b.metrics.GetMetrics()
-	for _, iface := range m.Interfaces {
+	// Add interface items dynamically (a daemon may have no sample yet)
+	var ifaces []pfsense.InterfaceMetrics
+	if m, _ := b.metrics.GetMetrics(); m != nil {
+		ifaces = m.Interfaces
+	}
+	for _, iface := range ifaces {
 		ifaceCopy := iface // Capture for closure
 		menu.AddItem(MenuItem{
-			Label: ifaceCopy.Name,
+			Label:   ifaceCopy.Name,
+			TwoLine: true,
 			Value: func() string {
 				if ifaceCopy.IP != "" {
 					return ifaceCopy.IP
@@ -133,6 +164,16 @@ func (b *PfSenseMenuBuilder) buildNetworkMenu() *Menu {
 		},
 	})
 
+	if r, ok := b.metrics.(peakResetter); ok {
+		menu.AddItem(MenuItem{
+			Label: "Reset Peaks",
+			Action: func() error {
+				r.ResetPeaks()
+				return nil
+			},
+		})
+	}
+
 	return menu
 }
 
@@ -140,26 +181,18 @@ func (b *PfSenseMenuBuilder) buildDisplayMenu() *Menu {
 	menu := NewMenu("DISPLAY", []MenuItem{})
 
 	// Backlight control
-	backlightLevels := []struct {
-		name  string
-		level byte
-	}{
-		{"Backlight: Off", 0},
-		{"Backlight: Low", 64},
-		{"Backlight: Medium", 128},
-		{"Backlight: High", 200},
-		{"Backlight: Max", 255},
-	}
-
-	for _, bl := range backlightLevels {
-		level := bl.level
-		menu.AddItem(MenuItem{
-			Label: bl.name,
-			Action: func() error {
-				return b.display.SetBacklight(level)
+	menu.AddItem(MenuItem{
+		Label: "Backlight",
+		Slider: &Slider{
+			Value: int(b.display.Backlight()),
+			Min:   0,
+			Max:   255,
+			Step:  15,
+			OnChange: func(level int) error {
+				return b.display.SetBacklight(byte(level))
 			},
-		})
-	}
+		},
+	})
 
 	// LED controls
 	ledMenu := NewMenu("LED CONTROL", []MenuItem{})
@@ -189,6 +222,11 @@ func (b *PfSenseMenuBuilder) buildDisplayMenu() *Menu {
 	return menu
 }
 
+// ShowStatus draws the system status screen, e.g. when the menu goes idle.
+func (b *PfSenseMenuBuilder) ShowStatus() error {
+	return b.showStatus()
+}
+
 func (b *PfSenseMenuBuilder) showStatus() error {
 	m, err := b.metrics.GetMetrics()
 	if err != nil {
@@ -205,7 +243,7 @@ func (b *PfSenseMenuBuilder) showStatus() error {
 	}
 
 	for _, iface := range m.Interfaces {
-		if iface.IP != "" && iface.Status == "up" {
+		if iface.IP != "" && iface.Status == pfsense.InterfaceUp {
 			status.IPAddress = iface.IP
 			break
 		}
@@ -254,14 +292,14 @@ func formatUptime(d time.Duration) string {
 // QuickInfoScreen shows a quick info screen with key metrics.
 type QuickInfoScreen struct {
 	display *display.Display
-	metrics *pfsense.SystemMetrics
+	metrics pfsense.MetricsProvider
 }
 
 // NewQuickInfoScreen creates a quick info screen.
 func NewQuickInfoScreen(d *display.Display) *QuickInfoScreen {
 	return &QuickInfoScreen{
 		display: d,
-		metrics: pfsense.NewSystemMetrics(),
+		metrics: pfsense.NewMetricsProvider(),
 	}
 }
 
@@ -272,46 +310,45 @@ func (q *QuickInfoScreen) Show() error {
 		return err
 	}
 
-	fb := q.display.FrameBuffer()
-	fb.Clear()
+	return q.display.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
 
-	f := font.BuiltinFont
-	lh := f.Height()
+		f := font.BuiltinFont
+		lh := f.LineHeight()
 
-	// Title
-	font.RenderTextInverted(fb, f, 0, 0, m.Hostname)
+		// Title
+		font.RenderTextInverted(fb, f, 0, 0, m.Hostname)
 
-	// Metrics with progress bars
-	y := lh
+		// Metrics with progress bars
+		y := lh
 
-	// CPU bar
-	font.RenderText(fb, f, 0, y, fmt.Sprintf("CPU: %.0f%%", m.CPU))
-	drawMiniBar(fb, 70, y, 54, lh-2, m.CPU/100)
-	y += lh
+		// CPU bar
+		font.RenderText(fb, f, 0, y, fmt.Sprintf("CPU: %.0f%%", m.CPU))
+		drawMiniBar(fb, 70, y, 54, lh-2, m.CPU/100)
+		y += lh
 
-	// Memory bar
-	memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
-	font.RenderText(fb, f, 0, y, fmt.Sprintf("MEM: %.0f%%", memPct))
-	drawMiniBar(fb, 70, y, 54, lh-2, memPct/100)
-	y += lh
+		// Memory bar
+		memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
+		font.RenderText(fb, f, 0, y, fmt.Sprintf("MEM: %.0f%%", memPct))
+		drawMiniBar(fb, 70, y, 54, lh-2, memPct/100)
+		y += lh
 
-	// Load
-	font.RenderText(fb, f, 0, y, fmt.Sprintf("LOAD: %.2f %.2f %.2f", m.LoadAvg[0], m.LoadAvg[1], m.LoadAvg[2]))
-	y += lh
+		// Load
+		font.RenderText(fb, f, 0, y, fmt.Sprintf("LOAD: %.2f %.2f %.2f", m.LoadAvg[0], m.LoadAvg[1], m.LoadAvg[2]))
+		y += lh
 
-	// Uptime
-	font.RenderText(fb, f, 0, y, fmt.Sprintf("UP: %s", formatUptime(m.Uptime)))
-	y += lh
+		// Uptime
+		font.RenderText(fb, f, 0, y, fmt.Sprintf("UP: %s", formatUptime(m.Uptime)))
+		y += lh
 
-	// IP Address
-	for _, iface := range m.Interfaces {
-		if iface.IP != "" && iface.Status == "up" {
-			font.RenderText(fb, f, 0, y, fmt.Sprintf("IP: %s", iface.IP))
-			break
+		// IP Address
+		for _, iface := range m.Interfaces {
+			if iface.IP != "" && iface.Status == pfsense.InterfaceUp {
+				font.RenderText(fb, f, 0, y, fmt.Sprintf("IP: %s", iface.IP))
+				break
+			}
 		}
-	}
-
-	return q.display.Update()
+	})
 }
 
 func drawMiniBar(fb *eziog500.FrameBuffer, x, y, w, h int, pct float64) {
@@ -0,0 +1,100 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+)
+
+// CommandRunner runs a system command to completion, like
+// exec.Command(name, args...).Run.
+type CommandRunner func(name string, args ...string) error
+
+func runCommand(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
+
+// errNoButtons is returned by actions that need a confirmation dialog when
+// SetButtons hasn't been called.
+var errNoButtons = errors.New("no buttons set for confirmation")
+
+// powerAction is a System menu item that runs a command once confirmed.
+type powerAction struct {
+	label    string
+	prompt   string
+	splash   string
+	script   string   // pfSense's own script, used if it exists
+	fallback []string // Otherwise this command
+}
+
+var powerActions = []powerAction{
+	{"Reboot", "Reboot the firewall now?", "REBOOTING", "/etc/rc.reboot", []string{"shutdown", "-r", "now"}},
+	{"Halt", "Halt the firewall now?", "HALTING", "/etc/rc.halt", []string{"shutdown", "-h", "now"}},
+}
+
+// command returns the command to run: pfSense's script where it exists, so
+// the system shuts down the way its web UI would, or else the fallback.
+func (a powerAction) command() []string {
+	if _, err := os.Stat(a.script); err == nil {
+		return []string{a.script}
+	}
+	return a.fallback
+}
+
+// SetPowerActions adds a System submenu with Reboot and Halt, each asking
+// for confirmation first. It is off by default; call it before Build, and
+// SetButtons once the controller exists.
+func (b *PfSenseMenuBuilder) SetPowerActions(enabled bool) {
+	b.power = enabled
+}
+
+// SetButtons sets where confirmation dialogs read buttons from, normally
+// MenuController.Buttons.
+func (b *PfSenseMenuBuilder) SetButtons(src ButtonSource) {
+	b.buttons = src
+}
+
+// SetCommandRunner replaces how the power commands are run, e.g. to log
+// them instead.
+func (b *PfSenseMenuBuilder) SetCommandRunner(run CommandRunner) {
+	b.run = run
+}
+
+func (b *PfSenseMenuBuilder) buildSystemMenu() *Menu {
+	menu := NewMenu("SYSTEM", []MenuItem{})
+	for _, a := range powerActions {
+		a := a
+		menu.AddItem(MenuItem{
+			Label: a.label,
+			Action: func() error {
+				return b.runPowerAction(a)
+			},
+		})
+	}
+	return menu
+}
+
+// runPowerAction asks to confirm a, then shows its splash and runs its
+// command. If the command fails it says so in an alert, and the menu
+// carries on.
+func (b *PfSenseMenuBuilder) runPowerAction(a powerAction) error {
+	if b.buttons == nil {
+		return errNoButtons
+	}
+	ok, err := Confirm(b.display, b.buttons, a.prompt)
+	if err != nil || !ok {
+		return err
+	}
+
+	if err := display.Splash(b.display, a.splash, "Please wait", nil); err != nil {
+		return err
+	}
+	cmd := a.command()
+	if err := b.run(cmd[0], cmd[1:]...); err != nil {
+		return Alert(b.display, b.buttons, "ERROR", fmt.Sprintf("%s failed: %v", a.label, err))
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// systemItem builds the pfSense menu with power actions and returns the
+// System submenu's item with the given label.
+func systemItem(t *testing.T, b *PfSenseMenuBuilder, label string) *MenuItem {
+	t.Helper()
+	for _, item := range b.Build().Items {
+		if item.SubMenu == nil || item.SubMenu.Title != "SYSTEM" {
+			continue
+		}
+		for i := range item.SubMenu.Items {
+			if sub := &item.SubMenu.Items[i]; sub.Label == label {
+				return sub
+			}
+		}
+	}
+	t.Fatalf("No %s item under System", label)
+	return nil
+}
+
+func TestPfSenseMenu_PowerActions(t *testing.T) {
+	disp, _ := testutil.NewDisplay(t)
+	b := NewPfSenseMenuBuilder(disp)
+	for _, item := range b.Build().Items {
+		if strings.HasPrefix(item.Label, "System >") {
+			t.Fatal("Expected no System submenu unless power actions are enabled")
+		}
+	}
+
+	var ran []string
+	b.SetPowerActions(true)
+	b.SetCommandRunner(func(name string, args ...string) error {
+		ran = append(ran, strings.Join(append([]string{name}, args...), " "))
+		return nil
+	})
+	reboot := systemItem(t, b, "Reboot")
+
+	// No is selected first, so Enter alone cancels
+	b.SetButtons(&fakeButtons{presses: []eziog500.Button{eziog500.ButtonEnter}})
+	if err := reboot.Action(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ran) != 0 {
+		t.Fatalf("Ran %q without a Yes", ran)
+	}
+
+	b.SetButtons(&fakeButtons{presses: []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonEnter}})
+	if err := reboot.Action(); err != nil {
+		t.Fatal(err)
+	}
+	want := powerActions[0].command()
+	if len(ran) != 1 || ran[0] != strings.Join(want, " ") {
+		t.Errorf("Ran %q, want %q once", ran, want)
+	}
+}
+
+func TestPfSenseMenu_PowerActionFails(t *testing.T) {
+	disp, _ := testutil.NewDisplay(t)
+	b := NewPfSenseMenuBuilder(disp)
+	b.SetPowerActions(true)
+	b.SetCommandRunner(func(string, ...string) error { return errors.New("not permitted") })
+	halt := systemItem(t, b, "Halt")
+
+	// Yes, then dismiss the error
+	b.SetButtons(&fakeButtons{presses: []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonEnter, eziog500.ButtonEnter}})
+	if err := halt.Action(); err != nil {
+		t.Errorf("Expected the failure shown in an alert, got %v", err)
+	}
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+)
+
+// toastDuration is how long the result of a restart is shown. Overridden
+// in tests.
+var toastDuration = 2 * time.Second
+
+// SetServiceControl adds a Services submenu listing names with their
+// running state, where selecting one offers to restart it with ctl, e.g. a
+// pfsense.CommandServiceController. Call it before Build, and SetButtons
+// once the controller exists; a nil ctl leaves the submenu out. The
+// metrics provider is asked to check the same services, if it can.
+func (b *PfSenseMenuBuilder) SetServiceControl(ctl pfsense.ServiceController, names []string) {
+	b.svc, b.svcNames = ctl, names
+	if p, ok := b.metrics.(interface{ SetServices([]string) }); ok {
+		p.SetServices(names)
+	}
+}
+
+func (b *PfSenseMenuBuilder) buildServicesMenu() *Menu {
+	menu := NewMenu("SERVICES", []MenuItem{})
+	for _, name := range b.svcNames {
+		name := name
+		menu.AddItem(MenuItem{
+			Label: name,
+			Value: func() string {
+				return b.serviceState(name)
+			},
+			Action: func() error {
+				return b.restartService(name)
+			},
+		})
+	}
+	return menu
+}
+
+// serviceState returns "running" or "stopped" from the latest metrics, or
+// "" if the provider doesn't report the service.
+func (b *PfSenseMenuBuilder) serviceState(name string) string {
+	m, _ := b.metrics.GetMetrics()
+	if m == nil {
+		return ""
+	}
+	for _, s := range m.Services {
+		if s.Name != name {
+			continue
+		}
+		if s.Running {
+			return "running"
+		}
+		return "stopped"
+	}
+	return ""
+}
+
+// restartService asks to confirm, restarts the service and toasts the
+// outcome.
+func (b *PfSenseMenuBuilder) restartService(name string) error {
+	if b.buttons == nil {
+		return errNoButtons
+	}
+	ok, err := Confirm(b.display, b.buttons, "Restart "+name+"?")
+	if err != nil || !ok {
+		return err
+	}
+
+	msg := name + " restarted"
+	if err := b.svc.Restart(name); err != nil {
+		msg = "Restart failed"
+	}
+	return display.Toast(b.display, msg, toastDuration)
+}
@@ -0,0 +1,74 @@
+package menu
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/pfsense"
+)
+
+// fakeServices records restarts and fails them with err.
+type fakeServices struct {
+	restarted []string
+	err       error
+}
+
+func (f *fakeServices) Restart(name string) error {
+	f.restarted = append(f.restarted, name)
+	return f.err
+}
+
+// metricsFunc adapts a function to pfsense.MetricsProvider.
+type metricsFunc func() (*pfsense.Metrics, error)
+
+func (f metricsFunc) GetMetrics() (*pfsense.Metrics, error) { return f() }
+
+func TestPfSenseMenu_RestartService(t *testing.T) {
+	orig := toastDuration
+	toastDuration = 0
+	t.Cleanup(func() { toastDuration = orig })
+	disp, _ := testutil.NewDisplay(t)
+	b := NewPfSenseMenuBuilder(disp)
+	b.SetMetricsProvider(metricsFunc(func() (*pfsense.Metrics, error) {
+		return &pfsense.Metrics{Services: []pfsense.ServiceStatus{{Name: "unbound", Running: true}}}, nil
+	}))
+	ctl := &fakeServices{}
+	b.SetServiceControl(ctl, []string{"unbound", "dpinger"})
+
+	var services *Menu
+	for _, item := range b.Build().Items {
+		if item.SubMenu != nil && item.SubMenu.Title == "SERVICES" {
+			services = item.SubMenu
+		}
+	}
+	if services == nil || len(services.Items) != 2 {
+		t.Fatal("Expected a Services submenu with an item per service")
+	}
+	if got := services.Items[0].Value(); got != "running" {
+		t.Errorf("unbound shows %q, want running", got)
+	}
+	if got := services.Items[1].Value(); got != "" {
+		t.Errorf("dpinger shows %q, want nothing without a status", got)
+	}
+
+	// Cancelled, then confirmed
+	b.SetButtons(&fakeButtons{presses: []eziog500.Button{eziog500.ButtonEsc}})
+	if err := services.Items[1].Action(); err != nil {
+		t.Fatal(err)
+	}
+	b.SetButtons(&fakeButtons{presses: []eziog500.Button{eziog500.ButtonLeft, eziog500.ButtonEnter}})
+	if err := services.Items[1].Action(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ctl.restarted) != 1 || ctl.restarted[0] != "dpinger" {
+		t.Errorf("Restarted %q, want dpinger once", ctl.restarted)
+	}
+
+	// A failed restart is reported in the toast, not as an error
+	ctl.err = errors.New("exit status 1")
+	if err := services.Items[0].Action(); err != nil {
+		t.Errorf("Got %v, want the failure shown in a toast", err)
+	}
+}
@@ -0,0 +1,76 @@
+package menu
+
+import (
+	"fmt"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Slider holds an adjustable integer for a menu item. Left/Right change the
+// value by Step while the item is selected.
+type Slider struct {
+	Value    int
+	Min      int
+	Max      int
+	Step     int
+	OnChange func(int) error // Called after each change with the new value
+}
+
+// Slider bar placement within a menu row.
+const (
+	sliderBarX = 90 // Clear of the scrollbar
+	sliderBarW = 32
+)
+
+// Adjust moves the value by steps (negative to decrease), clamped to
+// Min..Max, and calls OnChange if it changed. On error the value is restored.
+func (s *Slider) Adjust(steps int) error {
+	step := s.Step
+	if step <= 0 {
+		step = 1
+	}
+	v := s.Value + steps*step
+	if v < s.Min {
+		v = s.Min
+	}
+	if v > s.Max {
+		v = s.Max
+	}
+	if v == s.Value {
+		return nil
+	}
+
+	prev := s.Value
+	s.Value = v
+	if s.OnChange != nil {
+		if err := s.OnChange(v); err != nil {
+			s.Value = prev
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the current value.
+func (s *Slider) String() string {
+	return fmt.Sprintf("%d", s.Value)
+}
+
+// percent returns the value's position between Min and Max (0-100).
+func (s *Slider) percent() float64 {
+	if s.Max <= s.Min {
+		return 0
+	}
+	return float64(s.Value-s.Min) / float64(s.Max-s.Min) * 100
+}
+
+// renderBar draws the slider's position bar at the right of a menu row.
+// Pass on=false to draw on an inverted (selected) row.
+func (s *Slider) renderBar(fb *eziog500.FrameBuffer, y, height int, on bool) {
+	barY, barH := y+1, height-2
+	fb.DrawRect(sliderBarX, barY, sliderBarW, barH, on)
+	fill := int(float64(sliderBarW-4) * s.percent() / 100)
+	if fill > 0 {
+		fb.FillRect(sliderBarX+2, barY+2, fill, barH-4, on)
+	}
+}
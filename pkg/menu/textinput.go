@@ -0,0 +1,224 @@
+package menu
+
+import (
+	"unicode"
+
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// keyRows are the character rows of the on-screen keyboard (unshifted).
+var keyRows = []string{
+	"abcdefghijklm",
+	"nopqrstuvwxyz",
+	"0123456789.-_",
+	"@:/+=!?#$%&*,",
+}
+
+// Special keys on the bottom row of the keyboard.
+const (
+	keyShift = iota
+	keySpace
+	keyDelete
+	keyDone
+)
+
+var specialKeys = []string{"SHF", "SPC", "DEL", "OK"}
+
+// Keyboard layout in pixels.
+const (
+	keyCellW    = 9
+	keyGridY    = 20 // Top of the first character row
+	keyRowH     = 9
+	specialKeyW = 32
+	inputValueY = 10
+)
+
+// TextInput is an on-screen keyboard for entering text with the arrow keys.
+// Arrows move around the grid, Enter types the highlighted key, and Esc
+// deletes the last character (or cancels when the text is empty).
+//
+// The built-in fonts only have uppercase glyphs, so shift affects the entered
+// text but is shown by outlining the shift key rather than by the key labels.
+type TextInput struct {
+	Title  string
+	MaxLen int                // Maximum length in runes (0 for no limit)
+	OnDone func(string) error // Called with the text when OK is pressed
+
+	value     []rune
+	shift     bool
+	row, col  int
+	cancelled bool
+}
+
+// NewTextInput creates a keyboard screen that passes the entered text to onDone.
+func NewTextInput(title string, onDone func(string) error) *TextInput {
+	return &TextInput{Title: title, OnDone: onDone}
+}
+
+// SetValue sets the text being edited.
+func (t *TextInput) SetValue(s string) { t.value = []rune(s) }
+
+// Value returns the text entered so far.
+func (t *TextInput) Value() string { return string(t.value) }
+
+// Cancelled reports whether the last session ended with Esc instead of OK.
+func (t *TextInput) Cancelled() bool { return t.cancelled }
+
+// reset moves the highlight to the first key for a new editing session.
+func (t *TextInput) reset() {
+	t.row, t.col = 0, 0
+	t.shift = false
+	t.cancelled = false
+}
+
+// HandleButton applies a button press. It reports whether editing finished,
+// either by pressing OK (OnDone is called) or by cancelling with Esc.
+func (t *TextInput) HandleButton(btn eziog500.Button) (bool, error) {
+	specialRow := len(keyRows)
+
+	switch btn {
+	case eziog500.ButtonUp:
+		t.moveRow(-1)
+	case eziog500.ButtonDown:
+		t.moveRow(1)
+	case eziog500.ButtonLeft:
+		t.col = (t.col - 1 + t.rowLen()) % t.rowLen()
+	case eziog500.ButtonRight:
+		t.col = (t.col + 1) % t.rowLen()
+
+	case eziog500.ButtonEsc:
+		if len(t.value) == 0 {
+			t.cancelled = true
+			return true, nil
+		}
+		t.value = t.value[:len(t.value)-1]
+
+	case eziog500.ButtonEnter:
+		if t.row < specialRow {
+			r := rune(keyRows[t.row][t.col])
+			if t.shift {
+				r = unicode.ToUpper(r)
+			}
+			t.insert(r)
+			break
+		}
+		switch t.col {
+		case keyShift:
+			t.shift = !t.shift
+		case keySpace:
+			t.insert(' ')
+		case keyDelete:
+			if len(t.value) > 0 {
+				t.value = t.value[:len(t.value)-1]
+			}
+		case keyDone:
+			if t.OnDone != nil {
+				return true, t.OnDone(t.Value())
+			}
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *TextInput) insert(r rune) {
+	if t.MaxLen > 0 && len(t.value) >= t.MaxLen {
+		return
+	}
+	t.value = append(t.value, r)
+}
+
+// rowLen returns the number of keys in the highlighted row.
+func (t *TextInput) rowLen() int {
+	if t.row == len(keyRows) {
+		return len(specialKeys)
+	}
+	return len(keyRows[t.row])
+}
+
+// moveRow moves the highlight up or down, keeping it near the same column
+// when moving between character rows and the wider special keys.
+func (t *TextInput) moveRow(delta int) {
+	rows := len(keyRows) + 1
+	specialRow := len(keyRows)
+	next := (t.row + delta + rows) % rows
+
+	if next == specialRow && t.row != specialRow {
+		t.col = t.col * len(specialKeys) / len(keyRows[0])
+	} else if t.row == specialRow && next != specialRow {
+		t.col = t.col*len(keyRows[0])/len(specialKeys) + 1
+	}
+	t.row = next
+}
+
+// Render draws the keyboard and the text entered so far.
+func (t *TextInput) Render(d *display.Display) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, t.Title)
+
+		// Show the tail of the text if it's too long, followed by a cursor
+		text := string(t.value) + "_"
+		for font.MeasureText(f, text) > eziog500.Width-4 {
+			text = string([]rune(text)[1:])
+		}
+		font.RenderText(fb, f, 2, inputValueY, text)
+
+		for r, keys := range keyRows {
+			y := keyGridY + r*keyRowH
+			for c, k := range keys {
+				x := 1 + c*keyCellW
+				label := string(k)
+				lx := x + (keyCellW-font.MeasureText(f, label))/2
+				if r == t.row && c == t.col {
+					fb.FillRect(x, y-1, keyCellW, keyRowH, true)
+					renderTextOff(fb, f, lx, y, label)
+				} else {
+					font.RenderText(fb, f, lx, y, label)
+				}
+			}
+		}
+
+		y := keyGridY + len(keyRows)*keyRowH
+		for i, label := range specialKeys {
+			x := i * specialKeyW
+			lx := x + (specialKeyW-font.MeasureText(f, label))/2
+			if t.row == len(keyRows) && t.col == i {
+				fb.FillRect(x, y-1, specialKeyW, keyRowH, true)
+				renderTextOff(fb, f, lx, y, label)
+			} else {
+				font.RenderText(fb, f, lx, y, label)
+			}
+			if i == keyShift && t.shift {
+				fb.DrawRect(x+1, y-1, specialKeyW-2, keyRowH, true)
+			}
+		}
+
+	})
+}
+
+// Run shows the keyboard and handles buttons until OK or cancel.
+// Use MenuItem.Input instead when running inside a MenuController.
+func (t *TextInput) Run(d *display.Display, src ButtonSource) error {
+	buttons, stop := src.ButtonChannel()
+	defer stop()
+
+	t.reset()
+	if err := t.Render(d); err != nil {
+		return err
+	}
+	for btn := range buttons {
+		done, err := t.HandleButton(btn)
+		if done {
+			return err
+		}
+		if err := t.Render(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
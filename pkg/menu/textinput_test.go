@@ -0,0 +1,102 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// press returns n copies of a button.
+func press(btn eziog500.Button, n int) []eziog500.Button {
+	out := make([]eziog500.Button, n)
+	for i := range out {
+		out[i] = btn
+	}
+	return out
+}
+
+func presses(groups ...[]eziog500.Button) []eziog500.Button {
+	var all []eziog500.Button
+	for _, g := range groups {
+		all = append(all, g...)
+	}
+	return all
+}
+
+func TestTextInput_SpellWord(t *testing.T) {
+	var result string
+	ti := NewTextInput("NAME", func(s string) error {
+		result = s
+		return nil
+	})
+
+	buttons := &fakeButtons{presses: presses(
+		press(eziog500.ButtonRight, 6), press(eziog500.ButtonEnter, 1), // g
+		press(eziog500.ButtonDown, 1), press(eziog500.ButtonLeft, 5), press(eziog500.ButtonEnter, 1), // o
+		press(eziog500.ButtonDown, 1), press(eziog500.ButtonRight, 1), press(eziog500.ButtonEnter, 1), // 2
+		press(eziog500.ButtonDown, 2), press(eziog500.ButtonRight, 3), press(eziog500.ButtonEnter, 1), // OK
+	)}
+	disp, _ := testutil.NewDisplay(t)
+	if err := ti.Run(disp, buttons); err != nil {
+		t.Fatal(err)
+	}
+	if result != "go2" {
+		t.Errorf("Expected %q, got %q", "go2", result)
+	}
+}
+
+func TestTextInput_ShiftAndBackspace(t *testing.T) {
+	ti := NewTextInput("NAME", nil)
+	ti.reset()
+
+	for _, btn := range presses(
+		press(eziog500.ButtonUp, 1), press(eziog500.ButtonEnter, 1), // Shift on (wraps to the special row)
+		press(eziog500.ButtonDown, 1), press(eziog500.ButtonEnter, 1), // A (column 1 maps back near the left)
+		press(eziog500.ButtonUp, 1), press(eziog500.ButtonEnter, 1), // Shift off
+		press(eziog500.ButtonDown, 1), press(eziog500.ButtonEnter, 1), // b
+		press(eziog500.ButtonEnter, 1), // b
+		press(eziog500.ButtonEsc, 1),   // Backspace
+	) {
+		if done, _ := ti.HandleButton(btn); done {
+			t.Fatal("Input should not finish before OK")
+		}
+	}
+	if got := ti.Value(); got != "Bb" {
+		t.Errorf("Expected %q, got %q", "Bb", got)
+	}
+
+	// Esc on empty text cancels without calling OnDone
+	ti.SetValue("")
+	if done, _ := ti.HandleButton(eziog500.ButtonEsc); !done || !ti.Cancelled() {
+		t.Error("Esc with no text should cancel")
+	}
+}
+
+func TestMenuController_TextInputItem(t *testing.T) {
+	name := ""
+	input := NewTextInput("HOSTNAME", func(s string) error {
+		name = s
+		return nil
+	})
+	root := NewMenu("ROOT", []MenuItem{{Label: "Hostname", Input: input}})
+
+	buttons := &fakeButtons{presses: presses(
+		press(eziog500.ButtonEnter, 1), // Open the keyboard
+		press(eziog500.ButtonEnter, 1), // a
+		press(eziog500.ButtonLeft, 1),  // Wraps to the last column instead of leaving the menu
+		press(eziog500.ButtonUp, 1),    // Special row, rightmost key
+		press(eziog500.ButtonEnter, 1), // OK
+	)}
+	disp, _ := testutil.NewDisplay(t)
+	mc := NewMenuController(disp, buttons, root)
+	if err := mc.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if name != "a" {
+		t.Errorf("Expected %q, got %q", "a", name)
+	}
+	if mc.input != nil {
+		t.Error("Keyboard should close after OK")
+	}
+}
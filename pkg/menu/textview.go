@@ -0,0 +1,59 @@
+package menu
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// NewTextView returns a TextArea sized to fit under a ShowText title bar.
+func NewTextView() *ui.TextArea {
+	return ui.NewTextArea(eziog500.Width, eziog500.Height-font.BuiltinFont.Height())
+}
+
+// ShowText shows ta under a title bar until Esc, Enter, or Left is pressed.
+// Up and Down scroll. Create ta with NewTextView and fill it first; for a
+// log, call ScrollToEnd to start at the newest lines.
+//
+// Inside a MenuItem.Action, pass MenuController.Buttons() as the source.
+// The framebuffer is restored on return.
+func ShowText(d *display.Display, src ButtonSource, title string, ta *ui.TextArea) error {
+	buttons, stop := src.ButtonChannel()
+	defer stop()
+
+	d.Push()
+	defer d.Pop()
+
+	if err := renderText(d, title, ta); err != nil {
+		return err
+	}
+	for btn := range buttons {
+		moved := false
+		switch btn {
+		case eziog500.ButtonUp:
+			moved = ta.ScrollUp()
+		case eziog500.ButtonDown:
+			moved = ta.ScrollDown()
+		case eziog500.ButtonEsc, eziog500.ButtonEnter, eziog500.ButtonLeft:
+			return nil
+		}
+		if moved {
+			if err := renderText(d, title, ta); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderText draws the title bar and the visible part of ta.
+func renderText(d *display.Display, title string, ta *ui.TextArea) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " "+font.TruncateText(f, title, eziog500.Width-8)+" ")
+		ta.Render(fb, 0, f.Height())
+	})
+}
@@ -0,0 +1,30 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestShowText(t *testing.T) {
+	ta := NewTextView()
+	ta.SetText("1\n2\n3\n4\n5\n6\n7\n8\n9\n10")
+	ta.ScrollToEnd()
+	end := ta.Offset()
+
+	buttons := &fakeButtons{presses: []eziog500.Button{
+		eziog500.ButtonDown, // Already at the end
+		eziog500.ButtonUp,
+		eziog500.ButtonUp,
+		eziog500.ButtonEsc,
+		eziog500.ButtonUp, // Not read after Esc
+	}}
+	disp, _ := testutil.NewDisplay(t)
+	if err := ShowText(disp, buttons, "filter.log", ta); err != nil {
+		t.Fatal(err)
+	}
+	if ta.Offset() != end-2 {
+		t.Errorf("Expected offset %d, got %d", end-2, ta.Offset())
+	}
+}
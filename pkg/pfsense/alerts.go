@@ -0,0 +1,207 @@
+package pfsense
+
+import (
+	"sync"
+	"time"
+)
+
+// Comparator selects how an AlertRule compares a metric to its threshold.
+type Comparator int
+
+const (
+	Above Comparator = iota // Alert while the value is greater than the threshold
+	Below                   // Alert while the value is less than the threshold
+)
+
+// AlertSeverity describes how serious an alert is.
+type AlertSeverity string
+
+const (
+	SeverityWarning  AlertSeverity = "warning"
+	SeverityCritical AlertSeverity = "critical"
+)
+
+// AlertRule fires when a metric crosses a threshold.
+type AlertRule struct {
+	Name      string                           // Unique rule name, e.g. "cpu_critical"
+	Metric    string                           // Metric reported in alerts, e.g. "cpu"
+	Value     func(m *Metrics) (float64, bool) // Extracts the metric; false if unavailable
+	Compare   Comparator
+	Threshold float64
+	Severity  AlertSeverity
+	Debounce  int // Consecutive samples needed to fire or clear (minimum 1)
+}
+
+// triggered reports whether value breaches the rule's threshold.
+func (r AlertRule) triggered(value float64) bool {
+	if r.Compare == Below {
+		return value < r.Threshold
+	}
+	return value > r.Threshold
+}
+
+// Alert describes a rule that fired or cleared.
+type Alert struct {
+	Rule      string
+	Metric    string
+	Hostname  string
+	Value     float64
+	Threshold float64
+	Severity  AlertSeverity
+	Time      time.Time
+}
+
+// DefaultAlertRules returns the built-in rules: CPU and memory above the
+// policy's critical thresholds for three samples, and any gateway down for
+// two samples.
+func DefaultAlertRules(p LEDPolicy) []AlertRule {
+	return []AlertRule{
+		{
+			Name:   "cpu_critical",
+			Metric: "cpu",
+			Value: func(m *Metrics) (float64, bool) {
+				return m.CPU, true
+			},
+			Threshold: p.CPUCritical,
+			Severity:  SeverityCritical,
+			Debounce:  3,
+		},
+		{
+			Name:   "memory_critical",
+			Metric: "memory",
+			Value: func(m *Metrics) (float64, bool) {
+				if m.MemTotal == 0 {
+					return 0, false
+				}
+				return float64(m.MemUsed) / float64(m.MemTotal) * 100, true
+			},
+			Threshold: p.MemCritical,
+			Severity:  SeverityCritical,
+			Debounce:  3,
+		},
+		{
+			Name:   "gateway_down",
+			Metric: "gateways_down",
+			Value: func(m *Metrics) (float64, bool) {
+				if len(m.Gateways) == 0 {
+					return 0, false
+				}
+				down := 0
+				for _, gw := range m.Gateways {
+					if gw.Status == GatewayDown {
+						down++
+					}
+				}
+				return float64(down), true
+			},
+			Threshold: 0,
+			Severity:  SeverityCritical,
+			Debounce:  2,
+		},
+	}
+}
+
+// AlertManager evaluates rules against each metrics sample and calls OnAlert
+// when a rule starts firing and OnClear when it recovers. A firing rule does
+// not fire again until it has cleared. Callbacks run on the goroutine that
+// calls Feed, so they should not block for long.
+type AlertManager struct {
+	OnAlert func(Alert)
+	OnClear func(Alert)
+
+	mu    sync.Mutex
+	rules []AlertRule
+	state []alertState
+	now   func() time.Time // Overridden in tests
+}
+
+// alertState tracks debouncing for one rule.
+type alertState struct {
+	active bool
+	streak int // Consecutive samples disagreeing with active
+}
+
+// NewAlertManager creates an AlertManager with the given rules.
+func NewAlertManager(rules ...AlertRule) *AlertManager {
+	am := &AlertManager{now: time.Now}
+	for _, r := range rules {
+		am.AddRule(r)
+	}
+	return am
+}
+
+// AddRule adds a rule. Rules start out clear.
+func (am *AlertManager) AddRule(r AlertRule) {
+	if r.Debounce < 1 {
+		r.Debounce = 1
+	}
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	am.rules = append(am.rules, r)
+	am.state = append(am.state, alertState{})
+}
+
+// Feed evaluates every rule against a metrics sample.
+func (am *AlertManager) Feed(m *Metrics) {
+	am.mu.Lock()
+	var fired, cleared []Alert
+	for i, r := range am.rules {
+		value, ok := r.Value(m)
+		if !ok {
+			continue // Unknown this sample; keep the current state
+		}
+		st := &am.state[i]
+		if r.triggered(value) == st.active {
+			st.streak = 0
+			continue
+		}
+		st.streak++
+		if st.streak < r.Debounce {
+			continue
+		}
+		st.active = !st.active
+		st.streak = 0
+
+		alert := Alert{
+			Rule:      r.Name,
+			Metric:    r.Metric,
+			Hostname:  m.Hostname,
+			Value:     value,
+			Threshold: r.Threshold,
+			Severity:  r.Severity,
+			Time:      am.now(),
+		}
+		if st.active {
+			fired = append(fired, alert)
+		} else {
+			cleared = append(cleared, alert)
+		}
+	}
+	onAlert, onClear := am.OnAlert, am.OnClear
+	am.mu.Unlock()
+
+	// Call back without the lock so handlers may query the manager
+	for _, a := range fired {
+		if onAlert != nil {
+			onAlert(a)
+		}
+	}
+	for _, a := range cleared {
+		if onClear != nil {
+			onClear(a)
+		}
+	}
+}
+
+// Active returns the names of rules that are currently firing.
+func (am *AlertManager) Active() []string {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+	var names []string
+	for i, st := range am.state {
+		if st.active {
+			names = append(names, am.rules[i].Name)
+		}
+	}
+	return names
+}
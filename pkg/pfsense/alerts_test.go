@@ -0,0 +1,136 @@
+package pfsense
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+)
+
+// recordAlerts returns a manager for rules that records fired and cleared rules.
+func recordAlerts(rules ...AlertRule) (*AlertManager, *[]string, *[]string) {
+	var fired, cleared []string
+	am := NewAlertManager(rules...)
+	am.OnAlert = func(a Alert) { fired = append(fired, a.Rule) }
+	am.OnClear = func(a Alert) { cleared = append(cleared, a.Rule) }
+	return am, &fired, &cleared
+}
+
+func TestAlertManager_Debounce(t *testing.T) {
+	rules := DefaultAlertRules(DefaultLEDPolicy())
+	am, fired, cleared := recordAlerts(rules[0]) // cpu_critical, 3 samples
+
+	am.Feed(&Metrics{CPU: 95})
+	am.Feed(&Metrics{CPU: 95})
+	if len(*fired) != 0 {
+		t.Fatalf("Fired after 2 samples, want 3: %v", *fired)
+	}
+
+	// A dip below the threshold resets the streak
+	am.Feed(&Metrics{CPU: 50})
+	am.Feed(&Metrics{CPU: 95})
+	am.Feed(&Metrics{CPU: 95})
+	if len(*fired) != 0 {
+		t.Fatalf("Fired without 3 consecutive samples: %v", *fired)
+	}
+
+	am.Feed(&Metrics{CPU: 95})
+	if len(*fired) != 1 || (*fired)[0] != "cpu_critical" {
+		t.Fatalf("fired = %v, want [cpu_critical]", *fired)
+	}
+
+	// No re-fire while still over the threshold
+	for i := 0; i < 10; i++ {
+		am.Feed(&Metrics{CPU: 99})
+	}
+	if len(*fired) != 1 {
+		t.Errorf("Re-fired while still critical: %v", *fired)
+	}
+	if len(*cleared) != 0 {
+		t.Errorf("Cleared while still critical: %v", *cleared)
+	}
+	if active := am.Active(); len(active) != 1 || active[0] != "cpu_critical" {
+		t.Errorf("Active() = %v, want [cpu_critical]", active)
+	}
+}
+
+func TestAlertManager_Clear(t *testing.T) {
+	rules := DefaultAlertRules(DefaultLEDPolicy())
+	am, fired, cleared := recordAlerts(rules[2]) // gateway_down, 2 samples
+	down := &Metrics{Gateways: []GatewayMetrics{{Name: "WAN_DHCP", Status: GatewayDown}}}
+	up := &Metrics{Gateways: []GatewayMetrics{{Name: "WAN_DHCP", Status: GatewayUp}}}
+
+	am.Feed(down)
+	am.Feed(down)
+	if len(*fired) != 1 {
+		t.Fatalf("fired = %v, want one gateway_down alert", *fired)
+	}
+
+	// Clearing is debounced too
+	am.Feed(up)
+	if len(*cleared) != 0 {
+		t.Fatal("Cleared after a single good sample")
+	}
+	am.Feed(up)
+	if len(*cleared) != 1 || (*cleared)[0] != "gateway_down" {
+		t.Fatalf("cleared = %v, want [gateway_down]", *cleared)
+	}
+	if len(am.Active()) != 0 {
+		t.Errorf("Active() = %v after clearing", am.Active())
+	}
+
+	// Samples without gateway data leave the state alone
+	am.Feed(down)
+	am.Feed(&Metrics{})
+	am.Feed(down)
+	if len(*fired) != 2 {
+		t.Errorf("fired = %v, want a second alert after 2 down samples", *fired)
+	}
+
+	// It can fire again after clearing
+	am.Feed(up)
+	am.Feed(up)
+	if len(*cleared) != 2 {
+		t.Errorf("cleared = %v, want a second clear", *cleared)
+	}
+}
+
+func TestAlertManager_AlertFields(t *testing.T) {
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	var got Alert
+	am := NewAlertManager(AlertRule{
+		Name:      "temp_low",
+		Metric:    "temp",
+		Value:     func(m *Metrics) (float64, bool) { return m.CPU, true },
+		Compare:   Below,
+		Threshold: 10,
+		Severity:  SeverityWarning,
+	})
+	am.now = func() time.Time { return when }
+	am.OnAlert = func(a Alert) { got = a }
+
+	am.Feed(&Metrics{Hostname: "fw1", CPU: 5})
+	want := Alert{Rule: "temp_low", Metric: "temp", Hostname: "fw1", Value: 5,
+		Threshold: 10, Severity: SeverityWarning, Time: when}
+	if got != want {
+		t.Errorf("alert = %+v, want %+v", got, want)
+	}
+}
+
+func TestStatusDaemon_FeedsAlerts(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.metrics = &staticProvider{m: &Metrics{CPU: 100}}
+
+	var fired int
+	am := NewAlertManager(DefaultAlertRules(DefaultLEDPolicy())[0])
+	am.OnAlert = func(Alert) { fired++ }
+	daemon.SetAlertManager(am)
+
+	for i := 0; i < 3; i++ {
+		daemon.fetchMetrics()
+	}
+	if fired != 1 {
+		t.Errorf("Expected one alert from the daemon's samples, got %d", fired)
+	}
+}
@@ -0,0 +1,92 @@
+package pfsense
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// AmbientReader reads an ambient light sensor, in lux.
+type AmbientReader interface {
+	Read() (lux float64, err error)
+}
+
+// ErrNoAmbientSensor is returned by NoAmbientSensor.
+var ErrNoAmbientSensor = errors.New("no ambient light sensor")
+
+// NoAmbientSensor is the AmbientReader for systems without a sensor; every
+// Read fails, so a BacklightController using it changes nothing.
+type NoAmbientSensor struct{}
+
+func (NoAmbientSensor) Read() (float64, error) { return 0, ErrNoAmbientSensor }
+
+// CurvePoint is a backlight level to use at an ambient light level.
+type CurvePoint struct {
+	Lux   float64
+	Level byte
+}
+
+// DefaultBacklightCurve runs from dim in a dark room to full brightness in
+// daylight. Lux is perceived roughly logarithmically, so the points are
+// spaced by factors of ten.
+var DefaultBacklightCurve = []CurvePoint{
+	{Lux: 1, Level: 10},
+	{Lux: 10, Level: 40},
+	{Lux: 100, Level: 110},
+	{Lux: 1000, Level: 200},
+	{Lux: 10000, Level: 255},
+}
+
+// BacklightController sets the backlight from an ambient light sensor.
+// Between curve points the level is interpolated on a log scale of lux;
+// outside them it holds the first or last point's level.
+type BacklightController struct {
+	Reader AmbientReader
+	Curve  []CurvePoint // Sorted by Lux; nil uses DefaultBacklightCurve
+}
+
+// NewBacklightController creates a controller reading r with the default
+// curve. A nil r uses NoAmbientSensor.
+func NewBacklightController(r AmbientReader) *BacklightController {
+	if r == nil {
+		r = NoAmbientSensor{}
+	}
+	return &BacklightController{Reader: r}
+}
+
+// Level returns the backlight level for lux.
+func (c *BacklightController) Level(lux float64) byte {
+	curve := c.Curve
+	if curve == nil {
+		curve = DefaultBacklightCurve
+	}
+	if len(curve) == 0 {
+		return 0
+	}
+
+	i := sort.Search(len(curve), func(i int) bool { return curve[i].Lux >= lux })
+	switch {
+	case i == 0:
+		return curve[0].Level
+	case i == len(curve):
+		return curve[len(curve)-1].Level
+	}
+
+	lo, hi := curve[i-1], curve[i]
+	pos := (logLux(lux) - logLux(lo.Lux)) / (logLux(hi.Lux) - logLux(lo.Lux))
+	return byte(math.Round(float64(lo.Level) + pos*(float64(hi.Level)-float64(lo.Level))))
+}
+
+// logLux is log10(1+lux), so 0 lux has a place on the scale.
+func logLux(lux float64) float64 {
+	return math.Log10(1 + max(0, lux))
+}
+
+// Update reads the sensor and returns the level for its reading.
+func (c *BacklightController) Update() (byte, error) {
+	lux, err := c.Reader.Read()
+	if err != nil {
+		return 0, err
+	}
+	return c.Level(lux), nil
+}
@@ -0,0 +1,73 @@
+package pfsense
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+)
+
+func TestBacklightController_MonotonicInLux(t *testing.T) {
+	c := NewBacklightController(nil)
+
+	prev := c.Level(0)
+	for lux := 0.0; lux <= 20000; lux += 0.5 + lux/50 {
+		level := c.Level(lux)
+		if level < prev {
+			t.Fatalf("Level(%g) = %d, below the %d of a darker reading", lux, level, prev)
+		}
+		prev = level
+	}
+
+	if dark, bright := c.Level(0), c.Level(20000); dark != 10 || bright != 255 {
+		t.Errorf("Curve ends = %d, %d, want 10, 255", dark, bright)
+	}
+	if got := c.Level(100); got != 110 {
+		t.Errorf("Level(100) = %d, want the curve point 110", got)
+	}
+}
+
+func TestBacklightController_NoSensor(t *testing.T) {
+	c := NewBacklightController(nil)
+	if _, err := c.Update(); !errors.Is(err, ErrNoAmbientSensor) {
+		t.Errorf("Update() error = %v, want ErrNoAmbientSensor", err)
+	}
+}
+
+type fakeAmbient struct {
+	lux float64
+	err error
+}
+
+func (f *fakeAmbient) Read() (float64, error) { return f.lux, f.err }
+
+func TestStatusDaemon_AmbientOverridesSchedule(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	schedule, err := NewBacklightSchedule(200, 20, "22:00", "07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	daemon.SetBacklightSchedule(schedule)
+	sensor := &fakeAmbient{lux: 1000}
+	daemon.SetBacklightController(NewBacklightController(sensor))
+
+	noon := at(12, 0)
+	daemon.applyAmbientBacklight(noon)
+	if got := d.Backlight(); got != 200 {
+		t.Fatalf("Backlight at 1000 lux = %d, want 200", got)
+	}
+	sensor.lux = 1
+	daemon.applyAmbientBacklight(noon)
+	daemon.applyBacklightSchedule(noon)
+	if got := d.Backlight(); got != 10 {
+		t.Fatalf("Backlight at 1 lux = %d, want 10 despite the schedule", got)
+	}
+
+	// A failing sensor hands the backlight back to the schedule
+	sensor.err = errors.New("i2c timeout")
+	daemon.applyAmbientBacklight(noon)
+	if got := d.Backlight(); got != 200 {
+		t.Errorf("Backlight after sensor failure = %d, want the scheduled 200", got)
+	}
+}
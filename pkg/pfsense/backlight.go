@@ -0,0 +1,66 @@
+package pfsense
+
+import (
+	"fmt"
+	"time"
+)
+
+// BacklightSchedule dims the backlight during a nightly time window.
+type BacklightSchedule struct {
+	DayLevel   byte          // Backlight level outside the night window
+	NightLevel byte          // Backlight level inside the night window
+	NightStart time.Duration // Start of the night window, as an offset from midnight
+	NightEnd   time.Duration // End of the night window; may be before NightStart to wrap past midnight
+}
+
+// NewBacklightSchedule creates a schedule from "HH:MM" start and end times.
+func NewBacklightSchedule(dayLevel, nightLevel byte, nightStart, nightEnd string) (*BacklightSchedule, error) {
+	start, err := ParseTimeOfDay(nightStart)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ParseTimeOfDay(nightEnd)
+	if err != nil {
+		return nil, err
+	}
+	return &BacklightSchedule{
+		DayLevel:   dayLevel,
+		NightLevel: nightLevel,
+		NightStart: start,
+		NightEnd:   end,
+	}, nil
+}
+
+// ParseTimeOfDay parses an "HH:MM" time into an offset from midnight.
+func ParseTimeOfDay(s string) (time.Duration, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(s, "%d:%d", &h, &m); err != nil {
+		return 0, fmt.Errorf("invalid time of day %q (use HH:MM)", s)
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("invalid time of day %q (use HH:MM)", s)
+	}
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+}
+
+// IsNight reports whether t falls inside the night window.
+// The window includes NightStart and excludes NightEnd.
+func (s *BacklightSchedule) IsNight(t time.Time) bool {
+	tod := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if s.NightStart == s.NightEnd {
+		return false
+	}
+	if s.NightStart < s.NightEnd {
+		return tod >= s.NightStart && tod < s.NightEnd
+	}
+	// Window wraps past midnight (e.g. 22:00-07:00)
+	return tod >= s.NightStart || tod < s.NightEnd
+}
+
+// LevelAt returns the backlight level for time t.
+func (s *BacklightSchedule) LevelAt(t time.Time) byte {
+	if s.IsNight(t) {
+		return s.NightLevel
+	}
+	return s.DayLevel
+}
@@ -0,0 +1,59 @@
+package pfsense
+
+import (
+	"testing"
+	"time"
+)
+
+func at(hour, min int) time.Time {
+	return time.Date(2024, 1, 15, hour, min, 0, 0, time.Local)
+}
+
+func TestBacklightSchedule_WrapsMidnight(t *testing.T) {
+	s, err := NewBacklightSchedule(200, 20, "22:00", "07:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		hour, min int
+		want      byte
+	}{
+		{21, 59, 200},
+		{22, 0, 20},
+		{23, 30, 20},
+		{0, 0, 20},
+		{6, 59, 20},
+		{7, 0, 200},
+		{12, 0, 200},
+	}
+	for _, tt := range tests {
+		if got := s.LevelAt(at(tt.hour, tt.min)); got != tt.want {
+			t.Errorf("LevelAt(%02d:%02d) = %d, want %d", tt.hour, tt.min, got, tt.want)
+		}
+	}
+}
+
+func TestBacklightSchedule_SameDayWindow(t *testing.T) {
+	s, err := NewBacklightSchedule(255, 0, "01:30", "05:00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.IsNight(at(1, 29)) || !s.IsNight(at(1, 30)) || !s.IsNight(at(4, 59)) || s.IsNight(at(5, 0)) {
+		t.Error("Unexpected night window boundaries for 01:30-05:00")
+	}
+}
+
+func TestParseTimeOfDay(t *testing.T) {
+	d, err := ParseTimeOfDay("22:15")
+	if err != nil || d != 22*time.Hour+15*time.Minute {
+		t.Errorf("ParseTimeOfDay(22:15) = %v, %v", d, err)
+	}
+
+	for _, bad := range []string{"", "24:00", "12:60", "noon"} {
+		if _, err := ParseTimeOfDay(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package pfsense
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// powerSupplyDir is where Linux lists batteries and AC adapters.
+// Overridden in tests.
+var powerSupplyDir = "/sys/class/power_supply"
+
+// BatteryStatus is the state of a built-in battery, as found on small
+// appliances and laptops running pfSense.
+type BatteryStatus struct {
+	Charge   float64 `json:"charge"` // Percent remaining
+	Charging bool    `json:"charging"`
+}
+
+// getBattery returns the first battery's status from acpiconf or apm
+// (FreeBSD), or from sysfs (Linux). ok is false if there is no battery.
+func (s *SystemMetrics) getBattery() (BatteryStatus, bool) {
+	// Try acpiconf (FreeBSD with ACPI)
+	if out, err := exec.Command("acpiconf", "-i", "0").Output(); err == nil {
+		if b, ok := parseAcpiconf(string(out)); ok {
+			return b, true
+		}
+	}
+
+	// Try apm (FreeBSD's older interface, also backed by ACPI)
+	if life, err := exec.Command("apm", "-l").Output(); err == nil {
+		status, _ := exec.Command("apm", "-b").Output()
+		if b, ok := parseApm(string(life), string(status)); ok {
+			return b, true
+		}
+	}
+
+	// Fall back to sysfs (Linux)
+	return readPowerSupply(powerSupplyDir)
+}
+
+// parseAcpiconf parses `acpiconf -i` output, e.g.
+//
+//	Remaining capacity:	87%
+//	State:			charging
+func parseAcpiconf(out string) (BatteryStatus, bool) {
+	var b BatteryStatus
+	found := false
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.TrimSpace(key) {
+		case "Remaining capacity":
+			pct, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				return BatteryStatus{}, false // "unknown" when not present
+			}
+			b.Charge, found = pct, true
+		case "State":
+			if value == "not present" {
+				return BatteryStatus{}, false
+			}
+			b.Charging = value == "charging"
+		}
+	}
+	return b, found
+}
+
+// parseApm parses `apm -l` (percent remaining, -1 or 255 if unknown) and
+// `apm -b` (battery status, 3 while charging).
+func parseApm(life, status string) (BatteryStatus, bool) {
+	pct, err := strconv.Atoi(strings.TrimSpace(life))
+	if err != nil || pct < 0 || pct > 100 {
+		return BatteryStatus{}, false
+	}
+	return BatteryStatus{
+		Charge:   float64(pct),
+		Charging: strings.TrimSpace(status) == "3",
+	}, true
+}
+
+// readPowerSupply returns the first battery under a Linux power_supply
+// directory.
+func readPowerSupply(dir string) (BatteryStatus, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return BatteryStatus{}, false
+	}
+	read := func(name, file string) string {
+		data, err := os.ReadFile(filepath.Join(dir, name, file))
+		if err != nil {
+			return ""
+		}
+		return strings.TrimSpace(string(data))
+	}
+
+	for _, e := range entries {
+		name := e.Name()
+		if read(name, "type") != "Battery" || read(name, "present") == "0" {
+			continue
+		}
+		pct, err := strconv.ParseFloat(read(name, "capacity"), 64)
+		if err != nil {
+			continue
+		}
+		return BatteryStatus{Charge: pct, Charging: read(name, "status") == "Charging"}, true
+	}
+	return BatteryStatus{}, false
+}
@@ -0,0 +1,64 @@
+package pfsense
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseAcpiconf(t *testing.T) {
+	out := "Design capacity:\t4400 mAh\nRemaining capacity:\t87%\nRemaining time:\tunknown\nState:\t\t\tcharging\n"
+	b, ok := parseAcpiconf(out)
+	if !ok || b.Charge != 87 || !b.Charging {
+		t.Errorf("parseAcpiconf = %+v, %v; want 87%% charging", b, ok)
+	}
+
+	absent := "Remaining capacity:\tunknown\nState:\t\t\tnot present\n"
+	if _, ok := parseAcpiconf(absent); ok {
+		t.Error("Expected no battery when not present")
+	}
+}
+
+func TestParseApm(t *testing.T) {
+	if b, ok := parseApm("64\n", "1\n"); !ok || b.Charge != 64 || b.Charging {
+		t.Errorf("parseApm = %+v, %v; want 64%% discharging", b, ok)
+	}
+	if b, ok := parseApm("100\n", "3\n"); !ok || !b.Charging {
+		t.Errorf("parseApm = %+v, %v; want charging", b, ok)
+	}
+	for _, life := range []string{"-1", "255", ""} {
+		if _, ok := parseApm(life, "255"); ok {
+			t.Errorf("parseApm(%q): expected no battery", life)
+		}
+	}
+}
+
+func TestReadPowerSupply(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, file, value string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, file), []byte(value+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("AC", "type", "Mains")
+	if _, ok := readPowerSupply(dir); ok {
+		t.Error("Expected no battery with only a mains adapter")
+	}
+
+	write("BAT0", "type", "Battery")
+	write("BAT0", "capacity", "42")
+	write("BAT0", "status", "Charging")
+	b, ok := readPowerSupply(dir)
+	if !ok || b.Charge != 42 || !b.Charging {
+		t.Errorf("readPowerSupply = %+v, %v; want 42%% charging", b, ok)
+	}
+
+	if _, ok := readPowerSupply(filepath.Join(dir, "missing")); ok {
+		t.Error("Expected no battery without the directory")
+	}
+}
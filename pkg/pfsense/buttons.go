@@ -0,0 +1,131 @@
+package pfsense
+
+import (
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// backlightStep is how much Up and Down change the backlight level.
+const backlightStep = 32
+
+// ButtonSource provides button presses to a StatusDaemon. Both
+// eziog500.ButtonReader and eziog500.SessionButtonReader implement it.
+type ButtonSource interface {
+	ButtonChannel() (<-chan eziog500.Button, func())
+}
+
+// SetButtons makes Run read presses from src alongside the render loop
+// (nil stops reading buttons). Left and Right switch screens, pausing the
+// rotation for a while so a screen picked by hand stays up; Up and Down
+// change the backlight until a schedule or an ambient sensor sets it again
+// at its next check; Enter calls the OnEnter handler. Without buttons the
+// screensaver can't be woken, so EnableScreensaver has no effect.
+func (sd *StatusDaemon) SetButtons(src ButtonSource) {
+	sd.buttons = src
+}
+
+// SetOnEnter sets the handler for the Enter button, e.g. to open a menu.
+// It runs on Run's goroutine with the daemon's button channel stopped, and
+// the screens are redrawn once it returns. A stopped eziog500.ButtonReader
+// channel still takes the next press, so a handler that reads the same
+// buttons should share one channel with the daemon, as menu.App does.
+func (sd *StatusDaemon) SetOnEnter(fn func()) {
+	sd.onEnter = fn
+}
+
+// SetRotationPause sets how long the rotation stays paused after Left or
+// Right. The default is display.DefaultRotationPause.
+func (sd *StatusDaemon) SetRotationPause(d time.Duration) {
+	sd.rotationPause = d
+}
+
+// EnableScreensaver turns the backlight off and blanks the panel once no
+// button has been pressed for idle, to save power and burn-in; any press
+// wakes it. Zero disables the screensaver, which is the default.
+func (sd *StatusDaemon) EnableScreensaver(idle time.Duration) {
+	sd.sleepAfter = idle
+}
+
+// handleButton acts on a press at now. A press that wakes the screensaver
+// or unfreezes a display frozen by the blank-frame check does nothing
+// else, and Enter is left to Run. It reports whether the press was used up
+// waking the display.
+func (sd *StatusDaemon) handleButton(b eziog500.Button, now time.Time) bool {
+	sd.lastInput = now
+	if sd.asleep {
+		sd.wake()
+		return true
+	}
+	if sd.display.Frozen() {
+		// The freeze-on-blank debug check stopped the display; resume it
+		sd.display.Unfreeze()
+		return true
+	}
+
+	switch b {
+	case eziog500.ButtonLeft:
+		sd.showScreen(sd.currentScreen-1, now)
+	case eziog500.ButtonRight:
+		sd.showScreen(sd.currentScreen+1, now)
+	case eziog500.ButtonUp:
+		sd.setBacklight(byte(min(255, int(sd.display.Backlight())+backlightStep)))
+	case eziog500.ButtonDown:
+		sd.setBacklight(byte(max(0, int(sd.display.Backlight())-backlightStep)))
+	}
+	return false
+}
+
+// showScreen switches to screen i, wrapping around, and pauses the
+// rotation from now.
+func (sd *StatusDaemon) showScreen(i int, now time.Time) {
+	n := len(sd.screens)
+	sd.currentScreen = (i%n + n) % n
+	sd.navigated = now
+}
+
+// rotate moves to the next screen unless one was picked by hand within the
+// rotation pause.
+func (sd *StatusDaemon) rotate(now time.Time) {
+	if !sd.navigated.IsZero() && now.Sub(sd.navigated) < sd.rotationPause {
+		return
+	}
+	sd.currentScreen = (sd.currentScreen + 1) % len(sd.screens)
+}
+
+// checkIdle starts the screensaver once no button was pressed for the idle
+// period.
+func (sd *StatusDaemon) checkIdle(now time.Time) {
+	if sd.buttons == nil || sd.sleepAfter <= 0 || sd.asleep {
+		return
+	}
+	if now.Sub(sd.lastInput) >= sd.sleepAfter {
+		sd.sleep()
+	}
+}
+
+// sleep turns the backlight off and blanks the panel. Backlight changes
+// from the schedule or an ambient sensor are held until wake.
+func (sd *StatusDaemon) sleep() {
+	sd.asleep = true
+	sd.wakeBacklight = sd.display.Backlight()
+	sd.blank()
+}
+
+// blank turns the backlight off and shows an empty frame.
+func (sd *StatusDaemon) blank() error {
+	if err := sd.display.SetBacklight(0); err != nil {
+		return err
+	}
+	return sd.display.ClearAndUpdate()
+}
+
+// wake restores the backlight; the next frame redraws the screen. The
+// blank frame trips the freeze-on-blank debug check, so that freeze is
+// lifted too.
+func (sd *StatusDaemon) wake() {
+	sd.asleep = false
+	sd.display.SetBacklight(sd.wakeBacklight)
+	sd.display.Unfreeze()
+	sd.display.Invalidate()
+}
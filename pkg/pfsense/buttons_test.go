@@ -0,0 +1,175 @@
+package pfsense
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/display"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+type fakeButtons []eziog500.Button
+
+func (f fakeButtons) ButtonChannel() (<-chan eziog500.Button, func()) {
+	ch := make(chan eziog500.Button, len(f))
+	for _, b := range f {
+		ch <- b
+	}
+	close(ch)
+	return ch, func() {}
+}
+
+func TestStatusDaemon_Screensaver(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetButtons(fakeButtons{})
+	daemon.EnableScreensaver(time.Minute)
+	d.SetBacklight(150)
+
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	daemon.lastInput = start
+
+	daemon.checkIdle(start.Add(59 * time.Second))
+	if daemon.asleep {
+		t.Fatal("Asleep before the idle period passed")
+	}
+	if daemon.handleButton(eziog500.ButtonDown, start.Add(59*time.Second)) {
+		t.Error("A press while awake should not be used up")
+	}
+
+	// The press restarted the idle timer
+	daemon.checkIdle(start.Add(90 * time.Second))
+	if daemon.asleep {
+		t.Fatal("Asleep although a button was pressed 31s ago")
+	}
+	daemon.checkIdle(start.Add(2 * time.Minute))
+	if !daemon.asleep {
+		t.Fatal("Not asleep after a minute without input")
+	}
+	if got := d.Backlight(); got != 0 {
+		t.Errorf("Backlight while asleep = %d, want 0", got)
+	}
+	if n := d.FrameBuffer().CountSetPixels(); n != 0 {
+		t.Errorf("%d pixels lit while asleep", n)
+	}
+
+	// Scheduled changes wait for wake
+	daemon.setBacklight(180)
+	if got := d.Backlight(); got != 0 {
+		t.Errorf("Backlight changed to %d while asleep", got)
+	}
+
+	if !daemon.handleButton(eziog500.ButtonEnter, start.Add(3*time.Minute)) {
+		t.Error("The waking press should be used up")
+	}
+	if daemon.asleep {
+		t.Fatal("Still asleep after a press")
+	}
+	if got := d.Backlight(); got != 180 {
+		t.Errorf("Backlight after wake = %d, want the held 180", got)
+	}
+	daemon.checkIdle(start.Add(3*time.Minute + 59*time.Second))
+	if daemon.asleep {
+		t.Error("Asleep again before a full idle period")
+	}
+}
+
+func TestStatusDaemon_ScreensaverWithFreezeOnBlank(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	d.SetFreezeOnBlank(io.Discard)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetButtons(fakeButtons{})
+	daemon.EnableScreensaver(time.Minute)
+
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	daemon.lastInput = start
+	daemon.checkIdle(start.Add(time.Minute))
+	if !daemon.asleep {
+		t.Fatal("Not asleep after a minute without input")
+	}
+
+	// The screensaver's own blank frame doesn't leave the display frozen
+	daemon.handleButton(eziog500.ButtonEnter, start.Add(2*time.Minute))
+	if d.Frozen() {
+		t.Error("Display still frozen after waking from the screensaver")
+	}
+}
+
+func TestStatusDaemon_ScreensaverNeedsButtons(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.EnableScreensaver(time.Minute)
+
+	daemon.checkIdle(time.Now().Add(time.Hour))
+	if daemon.asleep {
+		t.Error("Slept without buttons to wake it")
+	}
+}
+
+func TestStatusDaemon_ButtonNavigation(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetButtons(fakeButtons{})
+	n := len(daemon.screens)
+
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	daemon.rotate(start)
+	if daemon.currentScreen != 1 {
+		t.Fatalf("Screen after rotating = %d, want 1", daemon.currentScreen)
+	}
+
+	daemon.handleButton(eziog500.ButtonRight, start)
+	if daemon.currentScreen != 2 {
+		t.Fatalf("Screen after Right = %d, want 2", daemon.currentScreen)
+	}
+
+	// The rotation waits out the grace period after a press
+	daemon.rotate(start.Add(display.DefaultRotationPause - time.Second))
+	if daemon.currentScreen != 2 {
+		t.Errorf("Rotated to %d during the grace period", daemon.currentScreen)
+	}
+	daemon.rotate(start.Add(display.DefaultRotationPause))
+	if daemon.currentScreen != 3 {
+		t.Errorf("Screen after the grace period = %d, want 3", daemon.currentScreen)
+	}
+
+	// Left wraps around from the first screen
+	daemon.currentScreen = 0
+	daemon.handleButton(eziog500.ButtonLeft, start)
+	if daemon.currentScreen != n-1 {
+		t.Errorf("Screen after Left from 0 = %d, want %d", daemon.currentScreen, n-1)
+	}
+
+	d.SetBacklight(240)
+	daemon.handleButton(eziog500.ButtonUp, start)
+	if got := d.Backlight(); got != 255 {
+		t.Errorf("Backlight after Up from 240 = %d, want 255", got)
+	}
+	daemon.handleButton(eziog500.ButtonDown, start)
+	if got := d.Backlight(); got != 255-backlightStep {
+		t.Errorf("Backlight after Down = %d, want %d", got, 255-backlightStep)
+	}
+	if daemon.lastBacklight != 255-backlightStep {
+		t.Errorf("lastBacklight after Down = %d, want %d", daemon.lastBacklight, 255-backlightStep)
+	}
+}
+
+func TestStatusDaemon_ButtonUnfreezes(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	d.SetFreezeOnBlank(io.Discard)
+	d.ClearAndUpdate()
+	if !d.Frozen() {
+		t.Fatal("Expected the blank frame to freeze the display")
+	}
+
+	start := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	if !daemon.handleButton(eziog500.ButtonRight, start) {
+		t.Error("A press that unfreezes the display should be used up")
+	}
+	if d.Frozen() || daemon.currentScreen != 0 {
+		t.Errorf("After the press: frozen %v, screen %d; want unfrozen on screen 0", d.Frozen(), daemon.currentScreen)
+	}
+}
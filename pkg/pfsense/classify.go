@@ -0,0 +1,100 @@
+package pfsense
+
+import (
+	"regexp"
+	"strings"
+)
+
+// InterfaceCategory is the traffic screen an interface is shown on.
+type InterfaceCategory string
+
+const (
+	CategoryNone   InterfaceCategory = ""       // Not shown on any traffic screen
+	CategoryWAN    InterfaceCategory = "wan"    // WAN Traffic
+	CategoryTunnel InterfaceCategory = "tunnel" // Tunnel Traffic
+	CategoryLAN    InterfaceCategory = "lan"    // LAN Traffic
+)
+
+// InterfaceField is the interface attribute a rule matches against.
+type InterfaceField int
+
+const (
+	MatchName        InterfaceField = iota // e.g. "igb0", "tun_wg0"
+	MatchDescription                       // e.g. "WAN", "INTERNAL_LAN"
+)
+
+// InterfaceRule puts interfaces whose Field starts with Prefix, or matches
+// Regexp when it is set, in Category.
+type InterfaceRule struct {
+	Category InterfaceCategory
+	Field    InterfaceField
+	Prefix   string
+	Regexp   *regexp.Regexp
+}
+
+// matches reports whether the rule applies to iface.
+func (r InterfaceRule) matches(iface InterfaceMetrics) bool {
+	value := iface.Name
+	if r.Field == MatchDescription {
+		value = iface.Description
+	}
+	if r.Regexp != nil {
+		return r.Regexp.MatchString(value)
+	}
+	return strings.HasPrefix(value, r.Prefix)
+}
+
+// DefaultInterfaceRules are pfSense's usual naming: WAN interfaces are
+// described WAN..., tunnels are WireGuard tun_wg devices or gateway/VPN
+// descriptions, and every other described interface is a LAN.
+func DefaultInterfaceRules() []InterfaceRule {
+	return []InterfaceRule{
+		{Category: CategoryWAN, Field: MatchDescription, Prefix: "WAN"},
+		{Category: CategoryTunnel, Field: MatchName, Prefix: "tun_wg"},
+		{Category: CategoryTunnel, Field: MatchDescription, Prefix: "GW_"},
+		{Category: CategoryTunnel, Field: MatchDescription, Prefix: "WG_"},
+		{Category: CategoryTunnel, Field: MatchDescription, Prefix: "MULLVAD"},
+		{Category: CategoryLAN, Field: MatchDescription, Regexp: regexp.MustCompile(`.`)},
+	}
+}
+
+// InterfaceClassifier sorts interfaces into traffic screen categories. The
+// first matching rule wins; interfaces no rule matches aren't shown.
+type InterfaceClassifier struct {
+	rules []InterfaceRule
+}
+
+// NewInterfaceClassifier creates a classifier applying rules in order.
+func NewInterfaceClassifier(rules ...InterfaceRule) *InterfaceClassifier {
+	return &InterfaceClassifier{rules: rules}
+}
+
+// Classify returns iface's category.
+func (c *InterfaceClassifier) Classify(iface InterfaceMetrics) InterfaceCategory {
+	for _, r := range c.rules {
+		if r.matches(iface) {
+			return r.Category
+		}
+	}
+	return CategoryNone
+}
+
+// Filter returns the interfaces in category, in their original order.
+func (c *InterfaceClassifier) Filter(ifaces []InterfaceMetrics, category InterfaceCategory) []InterfaceMetrics {
+	var result []InterfaceMetrics
+	for _, iface := range ifaces {
+		if c.Classify(iface) == category {
+			result = append(result, iface)
+		}
+	}
+	return result
+}
+
+// SetInterfaceRules replaces the rules grouping interfaces on the traffic
+// screens (nil restores DefaultInterfaceRules).
+func (sd *StatusDaemon) SetInterfaceRules(rules []InterfaceRule) {
+	if rules == nil {
+		rules = DefaultInterfaceRules()
+	}
+	sd.classifier = NewInterfaceClassifier(rules...)
+}
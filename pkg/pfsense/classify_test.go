@@ -0,0 +1,66 @@
+package pfsense
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestInterfaceClassifier_Defaults(t *testing.T) {
+	c := NewInterfaceClassifier(DefaultInterfaceRules()...)
+	tests := []struct {
+		iface InterfaceMetrics
+		want  InterfaceCategory
+	}{
+		{InterfaceMetrics{Name: "igb0", Description: "WAN"}, CategoryWAN},
+		{InterfaceMetrics{Name: "igb1", Description: "WAN2_FIBER"}, CategoryWAN},
+		{InterfaceMetrics{Name: "tun_wg0"}, CategoryTunnel},
+		{InterfaceMetrics{Name: "tun_wg1", Description: "HOME_VPN"}, CategoryTunnel},
+		{InterfaceMetrics{Name: "igb2.10", Description: "GW_OFFICE"}, CategoryTunnel},
+		{InterfaceMetrics{Name: "ovpnc1", Description: "WG_PEER"}, CategoryTunnel},
+		{InterfaceMetrics{Name: "ovpnc2", Description: "MULLVAD_SE"}, CategoryTunnel},
+		{InterfaceMetrics{Name: "igb3", Description: "INTERNAL_LAN"}, CategoryLAN},
+		{InterfaceMetrics{Name: "igb4", Description: "IOT"}, CategoryLAN},
+		{InterfaceMetrics{Name: "igb5"}, CategoryNone},
+	}
+	for _, tt := range tests {
+		if got := c.Classify(tt.iface); got != tt.want {
+			t.Errorf("Classify(%s %q) = %q, want %q", tt.iface.Name, tt.iface.Description, got, tt.want)
+		}
+	}
+}
+
+func TestInterfaceClassifier_CustomRules(t *testing.T) {
+	c := NewInterfaceClassifier(
+		InterfaceRule{Category: CategoryWAN, Field: MatchDescription, Regexp: regexp.MustCompile(`^(WAN|ISP)`)},
+		InterfaceRule{Category: CategoryTunnel, Field: MatchName, Prefix: "ovpn"},
+		InterfaceRule{Category: CategoryNone, Field: MatchDescription, Prefix: "MGMT"},
+		InterfaceRule{Category: CategoryLAN, Field: MatchName, Regexp: regexp.MustCompile(`.`)},
+	)
+	ifaces := []InterfaceMetrics{
+		{Name: "igb0", Description: "ISP_A"},
+		{Name: "ovpns1", Description: "ROAD_WARRIOR"},
+		{Name: "tun_wg0"}, // No longer a tunnel
+		{Name: "igb1", Description: "MGMT"},
+		{Name: "igb2", Description: "WAN"},
+		{Name: "igb3"},
+	}
+
+	want := map[InterfaceCategory][]string{
+		CategoryWAN:    {"igb0", "igb2"},
+		CategoryTunnel: {"ovpns1"},
+		CategoryLAN:    {"tun_wg0", "igb3"},
+		CategoryNone:   {"igb1"},
+	}
+	for category, names := range want {
+		got := c.Filter(ifaces, category)
+		if len(got) != len(names) {
+			t.Errorf("Filter(%q) = %v, want %v", category, got, names)
+			continue
+		}
+		for i, name := range names {
+			if got[i].Name != name {
+				t.Errorf("Filter(%q)[%d] = %s, want %s", category, i, got[i].Name, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package pfsense
+
+import (
+	"bufio"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// dhcpLeaseFiles are the ISC dhcpd lease databases pfSense keeps for IPv4
+// and IPv6.
+var dhcpLeaseFiles = []string{
+	"/var/dhcpd/var/db/dhcpd.leases",
+	"/var/dhcpd/var/db/dhcpd6.leases",
+}
+
+// DHCPLease is an active DHCP lease.
+type DHCPLease struct {
+	IP       string    `json:"ip"`
+	MAC      string    `json:"mac"`      // Empty for DHCPv6 leases
+	Hostname string    `json:"hostname"` // Client-supplied, may be empty
+	Expiry   time.Time `json:"expiry"`   // Zero if the lease never expires
+}
+
+// getDHCPLeases returns the active, unexpired leases from every readable
+// lease file, or the last read error if none could be read.
+func (s *SystemMetrics) getDHCPLeases() ([]DHCPLease, error) {
+	var leases []DHCPLease
+	var lastErr error
+	read := 0
+	for _, path := range dhcpLeaseFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		read++
+		leases = append(leases, parseDHCPLeases(string(data), time.Now())...)
+	}
+	if read == 0 {
+		return nil, lastErr
+	}
+	sortLeases(leases)
+	return leases, nil
+}
+
+// leaseRecord collects the statements of one lease or iaaddr block.
+type leaseRecord struct {
+	lease   DHCPLease
+	state   string
+	expires bool // An ends statement other than "never" was seen
+}
+
+// parseDHCPLeases parses a dhcpd.leases or dhcpd6.leases file and returns
+// the leases that are in the active binding state and haven't expired at
+// now. dhcpd appends a new entry each time a lease changes, so the last
+// entry for an address wins.
+//
+// IPv4 leases are top-level blocks:
+//
+//	lease 192.168.1.100 {
+//	  ends 4 2024/01/11 12:00:00;
+//	  binding state active;
+//	  hardware ethernet 00:11:22:33:44:55;
+//	  client-hostname "laptop";
+//	}
+//
+// IPv6 addresses are iaaddr blocks nested inside ia-na blocks.
+func parseDHCPLeases(data string, now time.Time) []DHCPLease {
+	latest := make(map[string]leaseRecord)
+	var order []string
+
+	// One entry per open block; nil for blocks that aren't leases
+	var stack []*leaseRecord
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := stripLeaseComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case strings.HasSuffix(line, "{"):
+			fields := strings.Fields(strings.TrimSuffix(line, "{"))
+			var rec *leaseRecord
+			if len(fields) == 2 && (fields[0] == "lease" || fields[0] == "iaaddr") {
+				rec = &leaseRecord{lease: DHCPLease{IP: fields[1]}}
+			}
+			stack = append(stack, rec)
+
+		case line == "}":
+			if len(stack) == 0 {
+				continue // Unbalanced; ignore
+			}
+			rec := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if rec == nil {
+				continue
+			}
+			if _, seen := latest[rec.lease.IP]; !seen {
+				order = append(order, rec.lease.IP)
+			}
+			latest[rec.lease.IP] = *rec
+
+		default:
+			if len(stack) > 0 && stack[len(stack)-1] != nil {
+				stack[len(stack)-1].apply(strings.TrimSuffix(line, ";"))
+			}
+		}
+	}
+
+	var leases []DHCPLease
+	for _, ip := range order {
+		rec := latest[ip]
+		if rec.state != "active" {
+			continue // Free, expired, abandoned, backup, ...
+		}
+		if rec.expires && !rec.lease.Expiry.After(now) {
+			continue
+		}
+		leases = append(leases, rec.lease)
+	}
+	return leases
+}
+
+// apply records one statement from inside a lease block.
+func (r *leaseRecord) apply(stmt string) {
+	fields := strings.Fields(stmt)
+	if len(fields) < 2 {
+		return
+	}
+	switch fields[0] {
+	case "binding":
+		// "binding state active"; "next binding state" is ignored
+		if len(fields) == 3 && fields[1] == "state" {
+			r.state = fields[2]
+		}
+	case "ends":
+		if fields[1] == "never" {
+			r.expires = false
+			r.lease.Expiry = time.Time{}
+			return
+		}
+		if t, ok := parseLeaseTime(fields[1:]); ok {
+			r.expires = true
+			r.lease.Expiry = t
+		}
+	case "hardware":
+		if len(fields) == 3 {
+			r.lease.MAC = fields[2]
+		}
+	case "client-hostname":
+		r.lease.Hostname = strings.Trim(strings.TrimPrefix(stmt, "client-hostname "), `"`)
+	}
+}
+
+// parseLeaseTime parses a lease timestamp: "<weekday> YYYY/MM/DD HH:MM:SS"
+// in UTC, or "epoch <seconds>" when dhcpd uses db-time-format local.
+func parseLeaseTime(fields []string) (time.Time, bool) {
+	if len(fields) == 2 && fields[0] == "epoch" {
+		secs, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return time.Unix(secs, 0).UTC(), true
+	}
+	if len(fields) == 3 {
+		t, err := time.Parse("2006/01/02 15:04:05", fields[1]+" "+fields[2])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// stripLeaseComment trims a line and removes a trailing # comment outside
+// quoted strings.
+func stripLeaseComment(line string) string {
+	inQuote := false
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if inQuote {
+				i++ // Skip the escaped character
+			}
+		case '"':
+			inQuote = !inQuote
+		case '#':
+			if !inQuote {
+				return strings.TrimSpace(line[:i])
+			}
+		}
+	}
+	return strings.TrimSpace(line)
+}
+
+// sortLeases orders leases by address, IPv4 before IPv6.
+func sortLeases(leases []DHCPLease) {
+	sort.SliceStable(leases, func(i, j int) bool {
+		a, errA := netip.ParseAddr(leases[i].IP)
+		b, errB := netip.ParseAddr(leases[j].IP)
+		if errA != nil || errB != nil {
+			return leases[i].IP < leases[j].IP
+		}
+		return a.Less(b)
+	})
+}
@@ -0,0 +1,123 @@
+package pfsense
+
+import (
+	"testing"
+	"time"
+)
+
+const sampleLeases = `# The format of this file is documented in the dhcpd.leases(5) manual page.
+# This lease file was written by isc-dhcp-4.4.3
+
+# authoring-byte-order entry is generated, DO NOT DELETE
+authoring-byte-order little-endian;
+
+lease 192.168.1.100 {
+  starts 4 2024/01/11 10:00:00;
+  ends 4 2024/01/11 12:00:00;
+  cltt 4 2024/01/11 10:00:00;
+  binding state active;
+  next binding state free;
+  rewind binding state free;
+  hardware ethernet 00:11:22:33:44:55;
+  uid "\001\000\021\"3DU";
+  client-hostname "laptop";
+}
+lease 192.168.1.20 {
+  starts 4 2024/01/11 09:00:00;
+  ends epoch 1704974400; # Thu Jan 11 12:00:00 2024
+  binding state active;
+  next binding state free;
+  hardware ethernet aa:bb:cc:dd:ee:ff;
+  set vendor-class-identifier = "android-dhcp-14";
+  client-hostname "phone # 2";
+}
+lease 192.168.1.101 {
+  starts 3 2024/01/10 08:00:00;
+  ends 3 2024/01/10 10:00:00;
+  binding state active;
+  hardware ethernet 00:00:00:00:00:01;
+  client-hostname "stale";
+}
+lease 192.168.1.102 {
+  starts 4 2024/01/11 10:00:00;
+  ends 4 2024/01/11 12:00:00;
+  binding state abandoned;
+  next binding state free;
+  hardware ethernet 00:00:00:00:00:02;
+}
+lease 192.168.1.103 {
+  starts 4 2024/01/11 10:00:00;
+  ends never;
+  binding state active;
+  hardware ethernet 00:00:00:00:00:03;
+}
+lease 192.168.1.104 {
+  starts 4 2024/01/11 10:00:00;
+  ends 4 2024/01/11 12:00:00;
+  binding state active;
+  hardware ethernet 00:00:00:00:00:04;
+  client-hostname "released";
+}
+lease 192.168.1.104 {
+  starts 4 2024/01/11 10:30:00;
+  ends 4 2024/01/11 10:30:00;
+  binding state free;
+  hardware ethernet 00:00:00:00:00:04;
+}
+`
+
+const sampleLeases6 = `server-duid "\000\001\000\001-\216\230\023\000\014)\012\013\014";
+
+ia-na "\001\000\000\000\000\001\000\001\036\237\012\276\000\014)\001\002\003" {
+  cltt 4 2024/01/11 10:00:00;
+  iaaddr 2001:db8::100 {
+    binding state active;
+    preferred-life 4500;
+    max-life 7200;
+    ends 4 2024/01/11 12:00:00;
+  }
+}
+ia-na "\002\000\000\000\000\001" {
+  cltt 3 2024/01/10 10:00:00;
+  iaaddr 2001:db8::200 {
+    binding state expired;
+    ends 3 2024/01/10 12:00:00;
+  }
+}
+`
+
+func TestParseDHCPLeases(t *testing.T) {
+	now := time.Date(2024, 1, 11, 11, 0, 0, 0, time.UTC)
+	leases := parseDHCPLeases(sampleLeases, now)
+
+	want := []DHCPLease{
+		{IP: "192.168.1.100", MAC: "00:11:22:33:44:55", Hostname: "laptop", Expiry: time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC)},
+		{IP: "192.168.1.20", MAC: "aa:bb:cc:dd:ee:ff", Hostname: "phone # 2", Expiry: time.Unix(1704974400, 0).UTC()},
+		{IP: "192.168.1.103", MAC: "00:00:00:00:00:03"},
+	}
+	if len(leases) != len(want) {
+		t.Fatalf("Expected %d leases, got %d: %+v", len(want), len(leases), leases)
+	}
+	for i, w := range want {
+		if leases[i] != w {
+			t.Errorf("Lease %d: expected %+v, got %+v", i, w, leases[i])
+		}
+	}
+}
+
+func TestParseDHCPLeases_IPv6(t *testing.T) {
+	now := time.Date(2024, 1, 11, 11, 0, 0, 0, time.UTC)
+	leases := parseDHCPLeases(sampleLeases6, now)
+	if len(leases) != 1 || leases[0].IP != "2001:db8::100" || leases[0].MAC != "" {
+		t.Errorf("Unexpected leases: %+v", leases)
+	}
+}
+
+func TestSortLeases(t *testing.T) {
+	leases := []DHCPLease{{IP: "2001:db8::1"}, {IP: "192.168.1.100"}, {IP: "192.168.1.20"}}
+	sortLeases(leases)
+	got := []string{leases[0].IP, leases[1].IP, leases[2].IP}
+	if got[0] != "192.168.1.20" || got[1] != "192.168.1.100" || got[2] != "2001:db8::1" {
+		t.Errorf("Unexpected order: %v", got)
+	}
+}
@@ -0,0 +1,232 @@
+package pfsense
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Gateway states, ordered from best to worst.
+const (
+	GatewayUp   = "up"
+	GatewayWarn = "warn" // Latency or loss above the warning threshold
+	GatewayDown = "down"
+)
+
+// Thresholds match pfSense's default gateway monitoring settings.
+const (
+	gatewayLatencyWarn = 200 * time.Millisecond
+	gatewayLatencyDown = 500 * time.Millisecond
+	gatewayLossWarn    = 10.0
+	gatewayLossDown    = 20.0
+)
+
+// dpingerSockets matches the status sockets pfSense creates for each
+// monitored gateway: dpinger_<gateway>~<source>~<monitor>.sock
+var dpingerSockets = "/var/run/dpinger_*.sock"
+
+// pingTimeout bounds the fallback ICMP ping to the default route.
+const pingTimeout = 2 * time.Second
+
+// GatewayMetrics contains reachability for a monitored gateway.
+type GatewayMetrics struct {
+	Name    string        `json:"name"`
+	Monitor string        `json:"monitor"` // Monitored IP address
+	RTT     time.Duration `json:"rtt"`     // Average round-trip time
+	StdDev  time.Duration `json:"stddev"`  // Round-trip time standard deviation
+	Loss    float64       `json:"loss"`    // Packet loss percentage
+	Status  string        `json:"status"`  // GatewayUp, GatewayWarn, or GatewayDown
+}
+
+// WorstGatewayStatus returns the worst state across all gateways, or an empty
+// string if none are monitored.
+func (m *Metrics) WorstGatewayStatus() string {
+	worst := ""
+	for _, gw := range m.Gateways {
+		if gatewayRank(gw.Status) > gatewayRank(worst) {
+			worst = gw.Status
+		}
+	}
+	return worst
+}
+
+func gatewayRank(status string) int {
+	switch status {
+	case GatewayUp:
+		return 1
+	case GatewayWarn:
+		return 2
+	case GatewayDown:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// gatewayStatus classifies a gateway using pfSense's default thresholds.
+func gatewayStatus(rtt time.Duration, loss float64) string {
+	switch {
+	case loss >= gatewayLossDown || rtt >= gatewayLatencyDown:
+		return GatewayDown
+	case loss >= gatewayLossWarn || rtt >= gatewayLatencyWarn:
+		return GatewayWarn
+	default:
+		return GatewayUp
+	}
+}
+
+// getGateways returns gateway status from dpinger, falling back to pinging
+// the default route when dpinger isn't running (non-pfSense systems).
+func (s *SystemMetrics) getGateways() ([]GatewayMetrics, error) {
+	socks, _ := filepath.Glob(dpingerSockets)
+	if len(socks) == 0 {
+		gw, err := pingDefaultRoute()
+		if err != nil {
+			return nil, err
+		}
+		return []GatewayMetrics{gw}, nil
+	}
+
+	var gateways []GatewayMetrics
+	for _, sock := range socks {
+		gw, err := readDpinger(sock)
+		if err != nil {
+			continue
+		}
+		gateways = append(gateways, gw)
+	}
+	sort.Slice(gateways, func(i, j int) bool { return gateways[i].Name < gateways[j].Name })
+	return gateways, nil
+}
+
+// readDpinger reads one status line from a dpinger status socket.
+func readDpinger(sock string) (GatewayMetrics, error) {
+	conn, err := net.DialTimeout("unix", sock, time.Second)
+	if err != nil {
+		return GatewayMetrics{}, err
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return GatewayMetrics{}, err
+	}
+	gw, err := parseDpinger(line)
+	if err != nil {
+		return GatewayMetrics{}, err
+	}
+	_, gw.Monitor = parseDpingerSocketName(sock)
+	return gw, nil
+}
+
+// parseDpinger parses a dpinger status line.
+//
+// Format: <name> <latency avg µs> <latency stddev µs> <loss %>
+func parseDpinger(line string) (GatewayMetrics, error) {
+	parts := strings.Fields(line)
+	if len(parts) != 4 {
+		return GatewayMetrics{}, fmt.Errorf("invalid dpinger status: %q", line)
+	}
+	avg, err1 := strconv.ParseUint(parts[1], 10, 64)
+	stddev, err2 := strconv.ParseUint(parts[2], 10, 64)
+	loss, err3 := strconv.ParseFloat(parts[3], 64)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return GatewayMetrics{}, fmt.Errorf("invalid dpinger status: %q", line)
+	}
+
+	gw := GatewayMetrics{
+		Name:   parts[0],
+		RTT:    time.Duration(avg) * time.Microsecond,
+		StdDev: time.Duration(stddev) * time.Microsecond,
+		Loss:   loss,
+	}
+	gw.Status = gatewayStatus(gw.RTT, gw.Loss)
+	return gw, nil
+}
+
+// parseDpingerSocketName extracts the gateway name and monitor IP from a
+// socket path like /var/run/dpinger_WAN_DHCP~192.0.2.10~1.1.1.1.sock.
+func parseDpingerSocketName(path string) (name, monitor string) {
+	base := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "dpinger_"), ".sock")
+	parts := strings.Split(base, "~")
+	if len(parts) == 3 {
+		return parts[0], parts[2]
+	}
+	return base, ""
+}
+
+// pingDefaultRoute pings the default gateway once.
+func pingDefaultRoute() (GatewayMetrics, error) {
+	addr, err := defaultRoute()
+	if err != nil {
+		return GatewayMetrics{}, err
+	}
+
+	gw := GatewayMetrics{Name: "default", Monitor: addr}
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "ping", "-c", "1", addr).Output()
+	rtt, ok := parsePingRTT(string(out))
+	if err != nil || !ok {
+		gw.Loss = 100
+		gw.Status = GatewayDown
+		return gw, nil
+	}
+	gw.RTT = rtt
+	gw.Status = gatewayStatus(gw.RTT, gw.Loss)
+	return gw, nil
+}
+
+// defaultRoute returns the IPv4 default gateway address.
+func defaultRoute() (string, error) {
+	if runtime.GOOS == "linux" {
+		out, err := exec.Command("ip", "-4", "route", "show", "default").Output()
+		if err != nil {
+			return "", err
+		}
+		// default via 192.0.2.1 dev eth0
+		parts := strings.Fields(string(out))
+		for i := 0; i+1 < len(parts); i++ {
+			if parts[i] == "via" {
+				return parts[i+1], nil
+			}
+		}
+		return "", fmt.Errorf("no default route")
+	}
+
+	out, err := exec.Command("route", "-n", "get", "default").Output()
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "gateway:"); ok {
+			return strings.TrimSpace(v), nil
+		}
+	}
+	return "", fmt.Errorf("no default route")
+}
+
+var pingTimeRe = regexp.MustCompile(`time[=<]([0-9.]+) ?ms`)
+
+// parsePingRTT extracts the round-trip time from ping output.
+func parsePingRTT(out string) (time.Duration, bool) {
+	match := pingTimeRe.FindStringSubmatch(out)
+	if match == nil {
+		return 0, false
+	}
+	ms, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(ms * float64(time.Millisecond)), true
+}
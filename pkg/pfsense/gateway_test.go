@@ -0,0 +1,72 @@
+package pfsense
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDpinger(t *testing.T) {
+	gw, err := parseDpinger("WAN_DHCP 12345 678 0\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gw.Name != "WAN_DHCP" || gw.RTT != 12345*time.Microsecond || gw.StdDev != 678*time.Microsecond || gw.Loss != 0 {
+		t.Errorf("Unexpected gateway: %+v", gw)
+	}
+	if gw.Status != GatewayUp {
+		t.Errorf("Expected up, got %s", gw.Status)
+	}
+
+	for _, bad := range []string{"", "WAN_DHCP 1 2", "WAN_DHCP x 2 3"} {
+		if _, err := parseDpinger(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestGatewayStatus(t *testing.T) {
+	tests := []struct {
+		rtt  time.Duration
+		loss float64
+		want string
+	}{
+		{10 * time.Millisecond, 0, GatewayUp},
+		{250 * time.Millisecond, 0, GatewayWarn},
+		{10 * time.Millisecond, 15, GatewayWarn},
+		{600 * time.Millisecond, 0, GatewayDown},
+		{0, 100, GatewayDown},
+	}
+	for _, tt := range tests {
+		if got := gatewayStatus(tt.rtt, tt.loss); got != tt.want {
+			t.Errorf("gatewayStatus(%v, %v) = %s, want %s", tt.rtt, tt.loss, got, tt.want)
+		}
+	}
+}
+
+func TestParseDpingerSocketName(t *testing.T) {
+	name, monitor := parseDpingerSocketName("/var/run/dpinger_WAN_DHCP~192.0.2.10~1.1.1.1.sock")
+	if name != "WAN_DHCP" || monitor != "1.1.1.1" {
+		t.Errorf("Got %q, %q", name, monitor)
+	}
+}
+
+func TestParsePingRTT(t *testing.T) {
+	rtt, ok := parsePingRTT("64 bytes from 192.0.2.1: icmp_seq=0 ttl=64 time=1.234 ms\n")
+	if !ok || rtt != 1234*time.Microsecond {
+		t.Errorf("Got %v, %v", rtt, ok)
+	}
+	if _, ok := parsePingRTT("Request timeout for icmp_seq 0\n"); ok {
+		t.Error("Expected no RTT for a timeout")
+	}
+}
+
+func TestWorstGatewayStatus(t *testing.T) {
+	m := &Metrics{}
+	if got := m.WorstGatewayStatus(); got != "" {
+		t.Errorf("Expected empty status with no gateways, got %q", got)
+	}
+	m.Gateways = []GatewayMetrics{{Status: GatewayUp}, {Status: GatewayDown}, {Status: GatewayWarn}}
+	if got := m.WorstGatewayStatus(); got != GatewayDown {
+		t.Errorf("Expected down, got %q", got)
+	}
+}
@@ -0,0 +1,150 @@
+package pfsense
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/host"
+	"github.com/shirou/gopsutil/v3/load"
+	"github.com/shirou/gopsutil/v3/mem"
+	psnet "github.com/shirou/gopsutil/v3/net"
+)
+
+// GopsutilMetrics implements MetricsProvider using gopsutil for CPU, memory,
+// load and interface counters. It is the collector used on Linux; filesystem,
+// temperature, process, gateway and NTP data still come from SystemMetrics.
+type GopsutilMetrics struct {
+	sys       *SystemMetrics
+	prevCPU   cpuStats
+	prevCores []cpuStats
+}
+
+// NewGopsutilMetrics creates a new GopsutilMetrics collector.
+func NewGopsutilMetrics() *GopsutilMetrics {
+	return &GopsutilMetrics{sys: &SystemMetrics{}}
+}
+
+// GetMetrics collects current system metrics.
+func (g *GopsutilMetrics) GetMetrics() (*Metrics, error) {
+	m := &Metrics{}
+
+	// Get hostname
+	hostname, err := os.Hostname()
+	if err == nil {
+		m.Hostname = hostname
+	}
+
+	// Get uptime
+	uptime, err := host.Uptime()
+	if err == nil {
+		m.Uptime = time.Duration(uptime) * time.Second
+	}
+	if boot, err := host.BootTime(); err == nil {
+		bootTime := time.Unix(int64(boot), 0)
+		m.BootTime = &bootTime
+	}
+
+	// Get aggregate and per-core CPU usage
+	if times, err := cpu.Times(false); err == nil && len(times) > 0 {
+		cur := cpuStatsFromTimes(times[0])
+		m.CPU = cpuDeltas([]cpuStats{g.prevCPU}, []cpuStats{cur})[0]
+		g.prevCPU = cur
+	}
+	if times, err := cpu.Times(true); err == nil {
+		cores := make([]cpuStats, len(times))
+		for i, t := range times {
+			cores[i] = cpuStatsFromTimes(t)
+		}
+		m.PerCPU = cpuDeltas(g.prevCores, cores)
+		g.prevCores = cores
+	}
+
+	// Get memory
+	if vm, err := mem.VirtualMemory(); err == nil {
+		m.MemTotal = vm.Total
+		if vm.Total > vm.Available {
+			m.MemUsed = vm.Total - vm.Available
+		}
+	}
+
+	// Get swap
+	if sw, err := mem.SwapMemory(); err == nil {
+		m.SwapUsed = sw.Used
+		m.SwapTotal = sw.Total
+	}
+
+	// Get load average
+	if avg, err := load.Avg(); err == nil {
+		m.LoadAvg = [3]float64{avg.Load1, avg.Load5, avg.Load15}
+	}
+
+	// Get network interfaces
+	ifaces, err := psnet.Interfaces()
+	if err == nil {
+		counters, _ := psnet.IOCounters(true)
+		m.Interfaces = interfacesFromGopsutil(ifaces, counters)
+	}
+
+	g.sys.collectShared(m)
+	return m, nil
+}
+
+// cpuStatsFromTimes converts gopsutil CPU times (seconds) to cpuStats ticks.
+// I/O wait and steal count as idle, irq/softirq as interrupt time.
+func cpuStatsFromTimes(t cpu.TimesStat) cpuStats {
+	ticks := func(sec float64) uint64 { return uint64(sec * 100) }
+	c := cpuStats{
+		user:   ticks(t.User),
+		nice:   ticks(t.Nice),
+		system: ticks(t.System),
+		intr:   ticks(t.Irq + t.Softirq),
+		idle:   ticks(t.Idle + t.Iowait + t.Steal),
+	}
+	c.total = c.user + c.nice + c.system + c.intr + c.idle
+	return c
+}
+
+// interfacesFromGopsutil merges interface details with their counters,
+// skipping the same pseudo-interfaces as the ifconfig path.
+func interfacesFromGopsutil(ifaces []psnet.InterfaceStat, counters []psnet.IOCountersStat) []InterfaceMetrics {
+	byName := make(map[string]psnet.IOCountersStat, len(counters))
+	for _, c := range counters {
+		byName[c.Name] = c
+	}
+
+	var result []InterfaceMetrics
+	for _, iface := range ifaces {
+		if strings.HasPrefix(iface.Name, "lo") {
+			continue
+		}
+		im := InterfaceMetrics{Name: iface.Name, Status: InterfaceDown}
+		for _, flag := range iface.Flags {
+			if flag == "up" {
+				im.Status = InterfaceUp
+			}
+		}
+		for _, addr := range iface.Addrs {
+			ip, ipnet, err := net.ParseCIDR(addr.Addr)
+			if err != nil || ip.To4() == nil {
+				continue
+			}
+			im.IP = ip.String()
+			// Match the ifconfig netmask format, e.g. 0xffffff00
+			im.Netmask = fmt.Sprintf("0x%s", ipnet.Mask.String())
+			break
+		}
+		if c, ok := byName[iface.Name]; ok {
+			im.RxBytes = c.BytesRecv
+			im.TxBytes = c.BytesSent
+			im.RxErrors = c.Errin
+			im.TxErrors = c.Errout
+			im.Drops = c.Dropin
+		}
+		result = append(result, im)
+	}
+	return result
+}
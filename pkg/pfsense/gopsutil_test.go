@@ -0,0 +1,86 @@
+package pfsense
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	psnet "github.com/shirou/gopsutil/v3/net"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+)
+
+func TestNewMetricsProvider_SelectsBackend(t *testing.T) {
+	p := NewMetricsProvider()
+	_, isGopsutil := p.(*GopsutilMetrics)
+	if want := runtime.GOOS == "linux"; isGopsutil != want {
+		t.Errorf("GOOS %s: got %T", runtime.GOOS, p)
+	}
+}
+
+func TestCPUStatsFromTimes(t *testing.T) {
+	prev := cpuStatsFromTimes(cpu.TimesStat{User: 10, System: 5, Idle: 80, Iowait: 5})
+	cur := cpuStatsFromTimes(cpu.TimesStat{User: 15, System: 7, Idle: 81, Iowait: 6, Irq: 0.5, Softirq: 0.5})
+
+	if prev.total != 10000 || prev.idle != 8500 {
+		t.Errorf("prev = %+v, want total 10000 idle 8500", prev)
+	}
+	// 1000 ticks elapsed, 200 of them idle/iowait
+	if got := cpuDeltas([]cpuStats{prev}, []cpuStats{cur})[0]; got != 80 {
+		t.Errorf("usage = %.1f, want 80", got)
+	}
+}
+
+func TestInterfacesFromGopsutil(t *testing.T) {
+	ifaces := []psnet.InterfaceStat{
+		{Name: "lo", Flags: []string{"up", "loopback"}, Addrs: []psnet.InterfaceAddr{{Addr: "127.0.0.1/8"}}},
+		{Name: "eth0", Flags: []string{"up", "broadcast"}, Addrs: []psnet.InterfaceAddr{
+			{Addr: "fe80::1/64"},
+			{Addr: "192.168.1.1/24"},
+		}},
+		{Name: "eth1", Flags: []string{"broadcast"}},
+	}
+	counters := []psnet.IOCountersStat{
+		{Name: "eth0", BytesRecv: 1000, BytesSent: 2000, Errin: 1, Errout: 2, Dropin: 3},
+	}
+
+	got := interfacesFromGopsutil(ifaces, counters)
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 interfaces (loopback skipped), got %d", len(got))
+	}
+	want := InterfaceMetrics{
+		Name: "eth0", Status: InterfaceUp, IP: "192.168.1.1", Netmask: "0xffffff00",
+		RxBytes: 1000, TxBytes: 2000, RxErrors: 1, TxErrors: 2, Drops: 3,
+	}
+	if got[0] != want {
+		t.Errorf("eth0 = %+v, want %+v", got[0], want)
+	}
+	if got[1].Status != InterfaceDown || got[1].IP != "" {
+		t.Errorf("eth1 = %+v, want down with no IP", got[1])
+	}
+}
+
+func TestStatusDaemon_FetchFromProvider(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	provider := &staticProvider{m: testMetrics()}
+	daemon.metrics = provider
+
+	daemon.fetchMetrics()
+	got, err := daemon.GetMetrics()
+	if err != nil {
+		t.Fatalf("GetMetrics failed: %v", err)
+	}
+	if got != provider.m {
+		t.Error("Expected the daemon to cache the provider's metrics")
+	}
+
+	// A failing provider keeps the last good sample
+	provider.err = errors.New("collector failed")
+	provider.m = nil
+	daemon.fetchMetrics()
+	if got2, _ := daemon.GetMetrics(); got2 != got {
+		t.Error("Expected the cached metrics to survive a provider error")
+	}
+}
@@ -0,0 +1,116 @@
+package pfsense
+
+import (
+	"fmt"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// LEDRole selects what an LED indicates.
+type LEDRole int
+
+const (
+	LEDRoleOff    LEDRole = iota // Always off
+	LEDRoleScreen                // Green on the logo screen, orange on traffic screens
+	LEDRoleHealth                // Green/orange/red from the policy's thresholds
+	LEDRoleHome                  // Green on the logo screen
+)
+
+// LEDPolicy decides the LED colors from metrics and the current screen.
+// A metric at or below its Warn threshold is healthy, above Warn is a
+// warning, and above Critical is critical.
+type LEDPolicy struct {
+	CPUWarn        float64 // CPU usage percentage
+	CPUCritical    float64
+	MemWarn        float64 // Memory usage percentage
+	MemCritical    float64
+	TempCritical   float64 // Hottest sensor in °C (no warning level)
+	StatesWarn     float64 // pf state table usage as a percentage of its limit
+	StatesCritical float64
+
+	// Roles maps LED1, LED2, LED3 (top to bottom) to what they indicate.
+	Roles [3]LEDRole
+}
+
+// DefaultLEDPolicy returns the policy the daemon uses unless overridden:
+// screen type on LED1, health on LED2, and home on LED3.
+func DefaultLEDPolicy() LEDPolicy {
+	return LEDPolicy{
+		CPUWarn:        70,
+		CPUCritical:    90,
+		MemWarn:        80,
+		MemCritical:    90,
+		TempCritical:   DefaultTempThreshold,
+		StatesWarn:     80,
+		StatesCritical: 95,
+		Roles:          [3]LEDRole{LEDRoleScreen, LEDRoleHealth, LEDRoleHome},
+	}
+}
+
+// Validate checks that each warning threshold is not above its critical one.
+func (p LEDPolicy) Validate() error {
+	if p.CPUWarn > p.CPUCritical {
+		return fmt.Errorf("CPU warning threshold %.0f%% is above critical %.0f%%", p.CPUWarn, p.CPUCritical)
+	}
+	if p.MemWarn > p.MemCritical {
+		return fmt.Errorf("memory warning threshold %.0f%% is above critical %.0f%%", p.MemWarn, p.MemCritical)
+	}
+	if p.StatesWarn > p.StatesCritical {
+		return fmt.Errorf("state table warning threshold %.0f%% is above critical %.0f%%", p.StatesWarn, p.StatesCritical)
+	}
+	return nil
+}
+
+// HealthColor returns green, orange, or red for the metrics. Gateway state
+// also counts: a degraded gateway is a warning and a down gateway is critical.
+// The pf state table counts when its limit is known.
+func (p LEDPolicy) HealthColor(m *Metrics) eziog500.LEDColor {
+	memPct := 0.0
+	if m.MemTotal > 0 {
+		memPct = float64(m.MemUsed) / float64(m.MemTotal) * 100
+	}
+	gateway := m.WorstGatewayStatus()
+	statesPct, _ := m.StateUsage()
+
+	switch {
+	case m.CPU > p.CPUCritical || memPct > p.MemCritical || m.MaxTemp() > p.TempCritical ||
+		gateway == GatewayDown || statesPct > p.StatesCritical:
+		return eziog500.LEDRed
+	case m.CPU > p.CPUWarn || memPct > p.MemWarn || gateway == GatewayWarn || statesPct > p.StatesWarn:
+		return eziog500.LEDOrange
+	default:
+		return eziog500.LEDGreen
+	}
+}
+
+// Colors returns the color of LED1, LED2, and LED3 for the given metrics and
+// screen. isHome is true on the logo (first) screen.
+func (p LEDPolicy) Colors(m *Metrics, screen StatusScreen, isHome bool) [3]eziog500.LEDColor {
+	var colors [3]eziog500.LEDColor
+	for i, role := range p.Roles {
+		switch role {
+		case LEDRoleScreen:
+			if isHome {
+				colors[i] = eziog500.LEDGreen
+			} else if isTrafficScreen(screen) {
+				colors[i] = eziog500.LEDOrange
+			}
+		case LEDRoleHealth:
+			colors[i] = p.HealthColor(m)
+		case LEDRoleHome:
+			if isHome {
+				colors[i] = eziog500.LEDGreen
+			}
+		}
+	}
+	return colors
+}
+
+// isTrafficScreen reports whether s shows interface traffic.
+func isTrafficScreen(s StatusScreen) bool {
+	switch s.(type) {
+	case *WANTrafficScreen, *TunnelTrafficScreen, *LANTrafficScreen:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package pfsense
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestLEDPolicy_HealthColorBoundaries(t *testing.T) {
+	p := DefaultLEDPolicy()
+
+	tests := []struct {
+		name string
+		m    Metrics
+		want eziog500.LEDColor
+	}{
+		{"idle", Metrics{CPU: 10, MemUsed: 10, MemTotal: 100}, eziog500.LEDGreen},
+		{"cpu at warn", Metrics{CPU: 70, MemTotal: 100}, eziog500.LEDGreen},
+		{"cpu above warn", Metrics{CPU: 70.1, MemTotal: 100}, eziog500.LEDOrange},
+		{"cpu at critical", Metrics{CPU: 90, MemTotal: 100}, eziog500.LEDOrange},
+		{"cpu above critical", Metrics{CPU: 90.1, MemTotal: 100}, eziog500.LEDRed},
+		{"mem at warn", Metrics{MemUsed: 80, MemTotal: 100}, eziog500.LEDGreen},
+		{"mem above warn", Metrics{MemUsed: 81, MemTotal: 100}, eziog500.LEDOrange},
+		{"mem above critical", Metrics{MemUsed: 91, MemTotal: 100}, eziog500.LEDRed},
+		{"no memory info", Metrics{}, eziog500.LEDGreen},
+		{"temp at threshold", Metrics{Temps: []float64{80}}, eziog500.LEDGreen},
+		{"temp above threshold", Metrics{Temps: []float64{80.5}}, eziog500.LEDRed},
+		{"gateway warn", Metrics{Gateways: []GatewayMetrics{{Status: GatewayWarn}}}, eziog500.LEDOrange},
+		{"gateway down", Metrics{Gateways: []GatewayMetrics{{Status: GatewayDown}}}, eziog500.LEDRed},
+		{"states above warn", Metrics{PFStates: 81, PFStateLimit: 100}, eziog500.LEDOrange},
+		{"states above critical", Metrics{PFStates: 96, PFStateLimit: 100}, eziog500.LEDRed},
+		{"states without limit", Metrics{PFStates: 1000000}, eziog500.LEDGreen},
+	}
+	for _, tt := range tests {
+		if got := p.HealthColor(&tt.m); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLEDPolicy_CustomThresholds(t *testing.T) {
+	p := DefaultLEDPolicy()
+	p.CPUWarn, p.CPUCritical = 30, 50
+
+	if got := p.HealthColor(&Metrics{CPU: 40}); got != eziog500.LEDOrange {
+		t.Errorf("Expected orange at 40%% CPU, got %v", got)
+	}
+	if got := p.HealthColor(&Metrics{CPU: 60}); got != eziog500.LEDRed {
+		t.Errorf("Expected red at 60%% CPU, got %v", got)
+	}
+}
+
+func TestLEDPolicy_Colors(t *testing.T) {
+	p := DefaultLEDPolicy()
+	m := &Metrics{CPU: 95}
+
+	got := p.Colors(m, &LogoScreen{}, true)
+	want := [3]eziog500.LEDColor{eziog500.LEDGreen, eziog500.LEDRed, eziog500.LEDGreen}
+	if got != want {
+		t.Errorf("Logo screen: got %v, want %v", got, want)
+	}
+
+	got = p.Colors(m, &WANTrafficScreen{}, false)
+	want = [3]eziog500.LEDColor{eziog500.LEDOrange, eziog500.LEDRed, eziog500.LEDOff}
+	if got != want {
+		t.Errorf("Traffic screen: got %v, want %v", got, want)
+	}
+
+	// Remap health to the bottom LED and disable the others
+	p.Roles = [3]LEDRole{LEDRoleOff, LEDRoleOff, LEDRoleHealth}
+	got = p.Colors(m, &LogoScreen{}, true)
+	want = [3]eziog500.LEDColor{eziog500.LEDOff, eziog500.LEDOff, eziog500.LEDRed}
+	if got != want {
+		t.Errorf("Remapped roles: got %v, want %v", got, want)
+	}
+}
+
+func TestLEDPolicy_Validate(t *testing.T) {
+	if err := DefaultLEDPolicy().Validate(); err != nil {
+		t.Errorf("Default policy should be valid: %v", err)
+	}
+	p := DefaultLEDPolicy()
+	p.MemWarn = 95
+	if err := p.Validate(); err == nil {
+		t.Error("Expected error when warning is above critical")
+	}
+}
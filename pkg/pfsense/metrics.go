@@ -3,9 +3,13 @@ package pfsense
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -13,26 +17,153 @@ import (
 
 // Metrics contains system metrics from pfSense.
 type Metrics struct {
-	Hostname   string
-	CPU        float64 // CPU usage percentage
-	MemUsed    uint64  // Memory used in bytes
-	MemTotal   uint64  // Total memory in bytes
-	Uptime     time.Duration
-	LoadAvg    [3]float64 // 1, 5, 15 minute load averages
-	Interfaces []InterfaceMetrics
+	Hostname   string              `json:"hostname"`
+	CPU        float64             `json:"cpu"`                 // CPU usage percentage
+	PerCPU     []float64           `json:"per_cpu"`             // Usage percentage per core
+	MemUsed    uint64              `json:"mem_used"`            // Memory used in bytes
+	MemTotal   uint64              `json:"mem_total"`           // Total memory in bytes
+	SwapUsed   uint64              `json:"swap_used"`           // Swap used in bytes
+	SwapTotal  uint64              `json:"swap_total"`          // Total swap in bytes (0 if none)
+	Uptime     time.Duration       `json:"-"`                   // Encoded as uptime_seconds
+	BootTime   *time.Time          `json:"boot_time,omitempty"` // Nil if unknown
+	LoadAvg    [3]float64          `json:"load_avg"`            // 1, 5, 15 minute load averages
+	Interfaces []InterfaceMetrics  `json:"interfaces"`
+	DiskUsage  []FilesystemMetrics `json:"disk_usage"`
+	Temps      []float64           `json:"temps"`     // Sensor temperatures in °C (CPU cores first, then thermal zones)
+	Processes  []ProcessMetrics    `json:"processes"` // Top processes by CPU usage
+	Gateways   []GatewayMetrics    `json:"gateways"`
+	NTPStatus  string              `json:"ntp_status"`  // NTPSynced, NTPUnsynced, or empty if unknown
+	DHCPLeases []DHCPLease         `json:"dhcp_leases"` // Active leases, sorted by address
+
+	PFStates     uint64 `json:"pf_states"`      // Current pf state table entries
+	PFStateLimit uint64 `json:"pf_state_limit"` // State table hard limit (0 if unknown)
+	PFMatches    uint64 `json:"pf_matches"`     // Rule matches since pf was enabled
+	PFBlocked    uint64 `json:"pf_blocked"`     // Packets blocked on the loginterface
+
+	Services []ServiceStatus `json:"services"`
+	PublicIP string          `json:"public_ip"` // Empty unless the lookup is enabled
+	Battery  *BatteryStatus  `json:"battery"`   // Nil without a battery
+}
+
+// metricsFields is Metrics without its methods, so the JSON methods can
+// encode the fields without recursing.
+type metricsFields Metrics
+
+// metricsJSON is the JSON form of Metrics, with Uptime in seconds.
+type metricsJSON struct {
+	metricsFields
+	UptimeSeconds float64 `json:"uptime_seconds"`
+}
+
+// MarshalJSON encodes the metrics with Uptime as uptime_seconds.
+func (m Metrics) MarshalJSON() ([]byte, error) {
+	return json.Marshal(metricsJSON{metricsFields(m), m.Uptime.Seconds()})
+}
+
+// UnmarshalJSON decodes metrics encoded by MarshalJSON.
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	var v metricsJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*m = Metrics(v.metricsFields)
+	m.Uptime = time.Duration(v.UptimeSeconds * float64(time.Second))
+	return nil
+}
+
+// NTP synchronization states.
+const (
+	NTPSynced   = "synced"
+	NTPUnsynced = "unsynced"
+)
+
+// MaxTemp returns the highest sensor temperature, or 0 if none are available.
+func (m *Metrics) MaxTemp() float64 {
+	max := 0.0
+	for _, t := range m.Temps {
+		if t > max {
+			max = t
+		}
+	}
+	return max
+}
+
+// Interface states, normalized from ifconfig's media status and the
+// interface flags.
+const (
+	InterfaceUp   = "up"
+	InterfaceDown = "down"
+)
+
+// normalizeInterfaceStatus maps an ifconfig "status:" value to InterfaceUp
+// or InterfaceDown. Wireless interfaces report "associated" once connected;
+// everything else ("no carrier", "no network", ...) is down.
+func normalizeInterfaceStatus(status string) string {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "active", "associated", InterfaceUp:
+		return InterfaceUp
+	}
+	return InterfaceDown
 }
 
 // InterfaceMetrics contains network interface statistics.
 type InterfaceMetrics struct {
-	Name        string
-	Description string // e.g., "WAN", "INTERNAL_LAN"
-	Status      string // active, no carrier
-	IP          string
-	Netmask     string
-	RxBytes     uint64
-	TxBytes     uint64
+	Name        string `json:"name"`
+	Description string `json:"description"` // e.g., "WAN", "INTERNAL_LAN"
+	Status      string `json:"status"`      // InterfaceUp or InterfaceDown
+	IP          string `json:"ip"`
+	Netmask     string `json:"netmask"`
+	RxBytes     uint64 `json:"rx_bytes"`
+	TxBytes     uint64 `json:"tx_bytes"`
+	RxErrors    uint64 `json:"rx_errors"` // Input errors (Ierrs)
+	TxErrors    uint64 `json:"tx_errors"` // Output errors (Oerrs)
+	Drops       uint64 `json:"drops"`     // Input drops (Idrop)
+}
+
+// ErrorCount returns the combined error and drop count.
+func (i InterfaceMetrics) ErrorCount() uint64 {
+	return i.RxErrors + i.TxErrors + i.Drops
+}
+
+// Label returns the description, or the device name if there is none.
+func (i InterfaceMetrics) Label() string {
+	if i.Description == "" {
+		return i.Name
+	}
+	return i.Description
+}
+
+// FilesystemMetrics contains usage for a mounted filesystem.
+type FilesystemMetrics struct {
+	Mount  string `json:"mount"`  // Mount point, e.g. "/" or "/var"
+	Device string `json:"device"` // Filesystem device, e.g. "/dev/ufsid/..." or "tmpfs"
+	Total  uint64 `json:"total"`  // Total size in bytes
+	Used   uint64 `json:"used"`   // Used bytes
+	Avail  uint64 `json:"avail"`  // Available bytes
 }
 
+// UsedPercent returns the percentage of the filesystem in use.
+func (f FilesystemMetrics) UsedPercent() float64 {
+	if f.Total == 0 {
+		return 0
+	}
+	return float64(f.Used) / float64(f.Total) * 100
+}
+
+// ProcessMetrics contains resource usage for a single process.
+type ProcessMetrics struct {
+	PID  int     `json:"pid"`
+	CPU  float64 `json:"cpu"` // CPU usage percentage
+	Mem  float64 `json:"mem"` // Memory usage percentage
+	Name string  `json:"name"`
+}
+
+// topProcessCount is the number of processes reported by GetMetrics.
+const topProcessCount = 4
+
+// diskMounts are the mount points reported by getDisks.
+var diskMounts = []string{"/", "/var"}
+
 // MetricsProvider is an interface for collecting system metrics.
 type MetricsProvider interface {
 	GetMetrics() (*Metrics, error)
@@ -40,7 +171,10 @@ type MetricsProvider interface {
 
 // SystemMetrics implements MetricsProvider for FreeBSD/pfSense.
 type SystemMetrics struct {
-	prevCPU cpuStats
+	prevCPU   cpuStats
+	prevCores []cpuStats
+	services  []string        // Checked by getServices; nil means DefaultServices
+	publicIP  *publicIPLookup // nil unless enabled with SetPublicIPURL
 }
 
 type cpuStats struct {
@@ -57,6 +191,16 @@ func NewSystemMetrics() *SystemMetrics {
 	return &SystemMetrics{}
 }
 
+// NewMetricsProvider returns the metrics collector for the running OS:
+// GopsutilMetrics on Linux, where ifconfig/netstat may be missing or differ,
+// and the sysctl/ifconfig based SystemMetrics everywhere else.
+func NewMetricsProvider() MetricsProvider {
+	if runtime.GOOS == "linux" {
+		return NewGopsutilMetrics()
+	}
+	return NewSystemMetrics()
+}
+
 // GetMetrics collects current system metrics.
 func (s *SystemMetrics) GetMetrics() (*Metrics, error) {
 	m := &Metrics{}
@@ -68,9 +212,9 @@ func (s *SystemMetrics) GetMetrics() (*Metrics, error) {
 	}
 
 	// Get uptime
-	uptime, err := s.getUptime()
+	uptime, bootTime, err := s.getUptime()
 	if err == nil {
-		m.Uptime = uptime
+		m.Uptime, m.BootTime = uptime, &bootTime
 	}
 
 	// Get CPU usage
@@ -79,6 +223,12 @@ func (s *SystemMetrics) GetMetrics() (*Metrics, error) {
 		m.CPU = cpu
 	}
 
+	// Get per-core CPU usage
+	perCPU, err := s.getPerCPU()
+	if err == nil {
+		m.PerCPU = perCPU
+	}
+
 	// Get memory
 	memUsed, memTotal, err := s.getMemory()
 	if err == nil {
@@ -86,6 +236,13 @@ func (s *SystemMetrics) GetMetrics() (*Metrics, error) {
 		m.MemTotal = memTotal
 	}
 
+	// Get swap
+	swapUsed, swapTotal, err := s.getSwap()
+	if err == nil {
+		m.SwapUsed = swapUsed
+		m.SwapTotal = swapTotal
+	}
+
 	// Get load average
 	load, err := s.getLoadAvg()
 	if err == nil {
@@ -98,11 +255,81 @@ func (s *SystemMetrics) GetMetrics() (*Metrics, error) {
 		m.Interfaces = interfaces
 	}
 
+	s.collectShared(m)
 	return m, nil
 }
 
-// getUptime returns the system uptime.
-func (s *SystemMetrics) getUptime() (time.Duration, error) {
+// collectShared fills in the metrics gathered the same way on every platform:
+// filesystems, temperatures, processes, gateways, clock sync and battery.
+func (s *SystemMetrics) collectShared(m *Metrics) {
+	// Get filesystem usage
+	disks, err := s.getDisks()
+	if err == nil {
+		m.DiskUsage = disks
+	}
+
+	// Get temperatures
+	temps, err := s.getTemperatures()
+	if err == nil {
+		m.Temps = temps
+	}
+
+	// Get busiest processes
+	procs, err := s.getTopProcesses(topProcessCount)
+	if err == nil {
+		m.Processes = procs
+	}
+
+	// Get gateway reachability
+	gateways, err := s.getGateways()
+	if err == nil {
+		m.Gateways = gateways
+	}
+
+	// Get clock synchronization
+	ntp, err := s.getNTPStatus()
+	if err == nil {
+		m.NTPStatus = ntp
+	}
+
+	// Get DHCP clients
+	leases, err := s.getDHCPLeases()
+	if err == nil {
+		m.DHCPLeases = leases
+	}
+
+	// Get firewall state table and counters
+	pf, err := s.getPFStates()
+	if err == nil {
+		m.PFStates, m.PFStateLimit = pf.states, pf.limit
+		m.PFMatches, m.PFBlocked = pf.matches, pf.blocked
+	}
+
+	// Get daemon status
+	names := s.services
+	if names == nil {
+		names = DefaultServices
+	}
+	services, err := getServices(names)
+	if err == nil {
+		m.Services = services
+	}
+
+	// Get public address (opt-in)
+	publicIP, err := s.getPublicIP()
+	if err == nil {
+		m.PublicIP = publicIP
+	}
+
+	// Get battery charge
+	if b, ok := s.getBattery(); ok {
+		m.Battery = &b
+	}
+}
+
+// getUptime returns the system uptime and boot time. On Linux the boot time
+// is worked out from the uptime, to the second.
+func (s *SystemMetrics) getUptime() (time.Duration, time.Time, error) {
 	// Try sysctl (FreeBSD/pfSense)
 	out, err := exec.Command("sysctl", "-n", "kern.boottime").Output()
 	if err == nil {
@@ -112,7 +339,7 @@ func (s *SystemMetrics) getUptime() (time.Duration, error) {
 		_, err := fmt.Sscanf(str, "{ sec = %d,", &sec)
 		if err == nil && sec > 0 {
 			bootTime := time.Unix(sec, 0)
-			return time.Since(bootTime), nil
+			return time.Since(bootTime), bootTime, nil
 		}
 	}
 
@@ -123,12 +350,13 @@ func (s *SystemMetrics) getUptime() (time.Duration, error) {
 		if len(parts) > 0 {
 			seconds, err := strconv.ParseFloat(parts[0], 64)
 			if err == nil {
-				return time.Duration(seconds * float64(time.Second)), nil
+				uptime := time.Duration(seconds * float64(time.Second))
+				return uptime, time.Now().Add(-uptime).Round(time.Second), nil
 			}
 		}
 	}
 
-	return 0, fmt.Errorf("unable to get uptime")
+	return 0, time.Time{}, fmt.Errorf("unable to get uptime")
 }
 
 // getCPU returns CPU usage percentage.
@@ -192,6 +420,82 @@ func (s *SystemMetrics) getCPU() (float64, error) {
 	return 0, fmt.Errorf("unable to get CPU stats")
 }
 
+// getPerCPU returns usage per core since the previous call (all zero on the
+// first call).
+func (s *SystemMetrics) getPerCPU() ([]float64, error) {
+	var cores []cpuStats
+
+	// Try sysctl (FreeBSD): kern.cp_time for every CPU in turn
+	out, err := exec.Command("sysctl", "-n", "kern.cp_times").Output()
+	if err == nil {
+		cores = parseCPTimes(string(out))
+	} else if data, err := os.ReadFile("/proc/stat"); err == nil {
+		cores = parseProcStatCores(string(data))
+	}
+	if len(cores) == 0 {
+		return nil, fmt.Errorf("unable to get per-CPU stats")
+	}
+
+	usage := cpuDeltas(s.prevCores, cores)
+	s.prevCores = cores
+	return usage, nil
+}
+
+// parseCPTimes parses "sysctl -n kern.cp_times": five counters
+// (user nice sys intr idle) per CPU, all on one line.
+func parseCPTimes(out string) []cpuStats {
+	parts := strings.Fields(out)
+	var cores []cpuStats
+	for i := 0; i+5 <= len(parts); i += 5 {
+		var v [5]uint64
+		for j := range v {
+			v[j], _ = strconv.ParseUint(parts[i+j], 10, 64)
+		}
+		cores = append(cores, cpuStats{
+			user: v[0], nice: v[1], system: v[2], intr: v[3], idle: v[4],
+			total: v[0] + v[1] + v[2] + v[3] + v[4],
+		})
+	}
+	return cores
+}
+
+// parseProcStatCores parses the "cpuN" lines of Linux /proc/stat. I/O wait
+// counts as idle, and irq/softirq as interrupt time.
+func parseProcStatCores(data string) []cpuStats {
+	var cores []cpuStats
+	for _, line := range strings.Split(data, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 5 || !strings.HasPrefix(parts[0], "cpu") || parts[0] == "cpu" {
+			continue
+		}
+		var v [7]uint64 // user nice system idle iowait irq softirq
+		for j := range v {
+			if j+1 < len(parts) {
+				v[j], _ = strconv.ParseUint(parts[j+1], 10, 64)
+			}
+		}
+		c := cpuStats{user: v[0], nice: v[1], system: v[2], idle: v[3] + v[4], intr: v[5] + v[6]}
+		c.total = c.user + c.nice + c.system + c.intr + c.idle
+		cores = append(cores, c)
+	}
+	return cores
+}
+
+// cpuDeltas returns the busy percentage of each core between two samples.
+// Cores without a previous sample (or with no elapsed ticks) report 0.
+func cpuDeltas(prev, cur []cpuStats) []float64 {
+	usage := make([]float64, len(cur))
+	for i, c := range cur {
+		if i >= len(prev) || c.total <= prev[i].total {
+			continue
+		}
+		deltaTotal := c.total - prev[i].total
+		deltaIdle := c.idle - prev[i].idle
+		usage[i] = 100.0 * float64(deltaTotal-deltaIdle) / float64(deltaTotal)
+	}
+	return usage
+}
+
 // getMemory returns memory usage.
 func (s *SystemMetrics) getMemory() (used, total uint64, err error) {
 	// Try sysctl (FreeBSD)
@@ -254,6 +558,74 @@ func (s *SystemMetrics) getMemory() (used, total uint64, err error) {
 	return 0, 0, fmt.Errorf("unable to get memory stats")
 }
 
+// getSwap returns swap usage. A system without swap reports zero total.
+func (s *SystemMetrics) getSwap() (used, total uint64, err error) {
+	// Try swapinfo (FreeBSD)
+	out, err := exec.Command("swapinfo", "-k").Output()
+	if err == nil {
+		used, total = parseSwapinfo(string(out))
+		if total == 0 {
+			// No swap devices listed; trust vm.swap_total if it says otherwise
+			if totOut, err := exec.Command("sysctl", "-n", "vm.swap_total").Output(); err == nil {
+				total, _ = strconv.ParseUint(strings.TrimSpace(string(totOut)), 10, 64)
+			}
+		}
+		return used, total, nil
+	}
+
+	// Try /proc/meminfo (Linux)
+	data, err := os.ReadFile("/proc/meminfo")
+	if err != nil {
+		return 0, 0, err
+	}
+	used, total = parseMeminfoSwap(string(data))
+	return used, total, nil
+}
+
+// parseSwapinfo sums the device rows of "swapinfo -k" output, skipping the
+// header and the "Total" row printed when there is more than one device.
+func parseSwapinfo(out string) (used, total uint64) {
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 4 || parts[0] == "Device" || parts[0] == "Total" {
+			continue
+		}
+		blocks, err1 := strconv.ParseUint(parts[1], 10, 64)
+		inUse, err2 := strconv.ParseUint(parts[2], 10, 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		total += blocks * 1024
+		used += inUse * 1024
+	}
+	return used, total
+}
+
+// parseMeminfoSwap reads SwapTotal and SwapFree from /proc/meminfo.
+func parseMeminfoSwap(data string) (used, total uint64) {
+	var free uint64
+	for _, line := range strings.Split(data, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 2 {
+			continue
+		}
+		kb, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		switch parts[0] {
+		case "SwapTotal:":
+			total = kb * 1024
+		case "SwapFree:":
+			free = kb * 1024
+		}
+	}
+	if total > free {
+		used = total - free
+	}
+	return used, total
+}
+
 // getLoadAvg returns system load averages.
 func (s *SystemMetrics) getLoadAvg() ([3]float64, error) {
 	var load [3]float64
@@ -287,6 +659,189 @@ func (s *SystemMetrics) getLoadAvg() ([3]float64, error) {
 	return load, fmt.Errorf("unable to get load average")
 }
 
+// getDisks returns usage for the root and /var filesystems.
+// Mounts that don't exist separately (e.g. /var on the root fs) are omitted.
+func (s *SystemMetrics) getDisks() ([]FilesystemMetrics, error) {
+	// -P gives one line per filesystem on both FreeBSD and Linux
+	out, err := exec.Command("df", "-kP").Output()
+	if err != nil {
+		return nil, err
+	}
+	return parseDF(string(out), diskMounts), nil
+}
+
+// parseDF parses POSIX "df -kP" output, returning the given mounts in order.
+//
+// Format: Filesystem 1024-blocks Used Available Capacity Mounted-on
+func parseDF(out string, mounts []string) []FilesystemMetrics {
+	found := make(map[string]FilesystemMetrics)
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 6 {
+			continue
+		}
+		total, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			continue // Header line
+		}
+		used, _ := strconv.ParseUint(parts[2], 10, 64)
+		avail, _ := strconv.ParseUint(parts[3], 10, 64)
+		mount := strings.Join(parts[5:], " ")
+		found[mount] = FilesystemMetrics{
+			Mount:  mount,
+			Device: parts[0],
+			Total:  total * 1024,
+			Used:   used * 1024,
+			Avail:  avail * 1024,
+		}
+	}
+
+	var result []FilesystemMetrics
+	for _, m := range mounts {
+		if fs, ok := found[m]; ok {
+			result = append(result, fs)
+		}
+	}
+	return result
+}
+
+// getTopProcesses returns the n processes using the most CPU.
+func (s *SystemMetrics) getTopProcesses(n int) ([]ProcessMetrics, error) {
+	// Same column list works on FreeBSD and Linux (procps)
+	out, err := exec.Command("ps", "-axo", "pid,pcpu,pmem,comm").Output()
+	if err != nil {
+		return nil, err
+	}
+	procs := parsePS(string(out))
+	if len(procs) > n {
+		procs = procs[:n]
+	}
+	return procs, nil
+}
+
+// parsePS parses "ps -axo pid,pcpu,pmem,comm" output, sorted by CPU usage
+// (then memory) descending. Command names may contain spaces.
+//
+// Format: PID %CPU %MEM COMMAND
+func parsePS(out string) []ProcessMetrics {
+	var procs []ProcessMetrics
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.Fields(line)
+		if len(parts) < 4 {
+			continue
+		}
+		pid, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue // Header line
+		}
+		cpu, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			continue
+		}
+		mem, _ := strconv.ParseFloat(parts[2], 64)
+		procs = append(procs, ProcessMetrics{
+			PID:  pid,
+			CPU:  cpu,
+			Mem:  mem,
+			Name: strings.Join(parts[3:], " "),
+		})
+	}
+
+	sort.SliceStable(procs, func(i, j int) bool {
+		if procs[i].CPU != procs[j].CPU {
+			return procs[i].CPU > procs[j].CPU
+		}
+		return procs[i].Mem > procs[j].Mem
+	})
+	return procs
+}
+
+// getNTPStatus reports whether the system clock is synchronized.
+func (s *SystemMetrics) getNTPStatus() (string, error) {
+	// Try ntpq (pfSense runs ntpd)
+	out, err := exec.Command("ntpq", "-pn").Output()
+	if err == nil {
+		if parseNTPQ(string(out)) {
+			return NTPSynced, nil
+		}
+		return NTPUnsynced, nil
+	}
+
+	// Fallback for systemd-timesyncd/chrony on Linux
+	out, err = exec.Command("timedatectl", "show", "-p", "NTPSynchronized", "--value").Output()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(string(out)) == "yes" {
+		return NTPSynced, nil
+	}
+	return NTPUnsynced, nil
+}
+
+// parseNTPQ reports whether "ntpq -pn" output has a selected system peer,
+// marked with a leading '*'.
+func parseNTPQ(out string) bool {
+	for _, line := range strings.Split(out, "\n") {
+		if strings.HasPrefix(line, "*") {
+			return true
+		}
+	}
+	return false
+}
+
+// getTemperatures returns CPU and board temperatures in °C.
+func (s *SystemMetrics) getTemperatures() ([]float64, error) {
+	// Try sysctl (FreeBSD). Requires coretemp/amdtemp or ACPI thermal zones;
+	// sysctl exits non-zero if either tree is missing but still prints the other.
+	out, _ := exec.Command("sysctl", "dev.cpu", "hw.acpi.thermal").Output()
+	if temps := parseSysctlTemps(string(out)); len(temps) > 0 {
+		return temps, nil
+	}
+
+	// Try /sys/class/thermal (Linux), values in millidegrees
+	zones, _ := filepath.Glob("/sys/class/thermal/thermal_zone*/temp")
+	var temps []float64
+	for _, zone := range zones {
+		data, err := os.ReadFile(zone)
+		if err != nil {
+			continue
+		}
+		milli, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+		if err == nil {
+			temps = append(temps, milli/1000)
+		}
+	}
+	if len(temps) > 0 {
+		return temps, nil
+	}
+
+	return nil, fmt.Errorf("no temperature sensors found")
+}
+
+// parseSysctlTemps extracts all "*.temperature: NN.NC" values from sysctl output.
+func parseSysctlTemps(out string) []float64 {
+	var temps []float64
+	for _, line := range strings.Split(out, "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok || !strings.HasSuffix(name, ".temperature") {
+			continue
+		}
+		if t, err := parseSysctlTemp(value); err == nil {
+			temps = append(temps, t)
+		}
+	}
+	return temps
+}
+
+// parseSysctlTemp parses a FreeBSD sysctl temperature such as "45.0C".
+func parseSysctlTemp(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasSuffix(s, "C") {
+		return 0, fmt.Errorf("invalid temperature %q", s)
+	}
+	return strconv.ParseFloat(strings.TrimSuffix(s, "C"), 64)
+}
+
 // getInterfaces returns network interface information by parsing ifconfig output.
 func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 	var result []InterfaceMetrics
@@ -315,8 +870,7 @@ func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 					!strings.HasPrefix(current.Name, "enc") {
 					// Get traffic stats from pre-fetched map
 					if stats, ok := ifaceStats[current.Name]; ok {
-						current.RxBytes = stats.rx
-						current.TxBytes = stats.tx
+						stats.apply(current)
 					}
 					result = append(result, *current)
 				}
@@ -327,7 +881,12 @@ func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 			if len(parts) > 0 {
 				current = &InterfaceMetrics{
 					Name:   strings.TrimSpace(parts[0]),
-					Status: "down",
+					Status: InterfaceDown,
+				}
+				// Tunnels have no media status line, so go by the UP flag
+				// until one turns up: "flags=8051<UP,POINTOPOINT,...>"
+				if strings.Contains(line, "<UP,") || strings.Contains(line, "<UP>") {
+					current.Status = InterfaceUp
 				}
 			}
 		} else if current != nil && len(line) > 0 {
@@ -340,8 +899,7 @@ func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 
 			// Parse status (active or no carrier)
 			if strings.HasPrefix(line, "status:") {
-				status := strings.TrimSpace(strings.TrimPrefix(line, "status:"))
-				current.Status = status
+				current.Status = normalizeInterfaceStatus(strings.TrimPrefix(line, "status:"))
 			}
 
 			// Parse inet address
@@ -364,8 +922,7 @@ func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 			!strings.HasPrefix(current.Name, "pfsync") &&
 			!strings.HasPrefix(current.Name, "enc") {
 			if stats, ok := ifaceStats[current.Name]; ok {
-				current.RxBytes = stats.rx
-				current.TxBytes = stats.tx
+				stats.apply(current)
 			}
 			result = append(result, *current)
 		}
@@ -374,19 +931,33 @@ func (s *SystemMetrics) getInterfaces() ([]InterfaceMetrics, error) {
 	return result, nil
 }
 
-type ifaceStatsEntry struct{ rx, tx uint64 }
+type ifaceStatsEntry struct{ rx, tx, rxErrs, txErrs, drops uint64 }
+
+// apply copies the counters onto an interface.
+func (e ifaceStatsEntry) apply(iface *InterfaceMetrics) {
+	iface.RxBytes = e.rx
+	iface.TxBytes = e.tx
+	iface.RxErrors = e.rxErrs
+	iface.TxErrors = e.txErrs
+	iface.Drops = e.drops
+}
 
 // getAllInterfaceStats runs netstat -ibn once and returns a map of interface stats.
 // This is much more efficient than calling getInterfaceStats per interface.
 func (s *SystemMetrics) getAllInterfaceStats() map[string]ifaceStatsEntry {
-	result := make(map[string]ifaceStatsEntry)
-
 	out, err := exec.Command("netstat", "-ibn").Output()
 	if err != nil {
-		return result
+		return make(map[string]ifaceStatsEntry)
 	}
+	return parseNetstatIBN(string(out))
+}
 
-	lines := strings.Split(string(out), "\n")
+// parseNetstatIBN parses the <Link#N> rows of "netstat -ibn" output.
+// Columns: Name Mtu Network Address Ipkts Ierrs Idrop Ibytes Opkts Oerrs Obytes Coll
+func parseNetstatIBN(out string) map[string]ifaceStatsEntry {
+	result := make(map[string]ifaceStatsEntry)
+
+	lines := strings.Split(out, "\n")
 	for _, line := range lines {
 		parts := strings.Fields(line)
 		if len(parts) < 11 {
@@ -400,9 +971,13 @@ func (s *SystemMetrics) getAllInterfaceStats() map[string]ifaceStatsEntry {
 
 		ifaceName := strings.TrimSuffix(parts[0], "*")
 
-		rx, _ := strconv.ParseUint(parts[7], 10, 64)
-		tx, _ := strconv.ParseUint(parts[10], 10, 64)
-		result[ifaceName] = ifaceStatsEntry{rx: rx, tx: tx}
+		var e ifaceStatsEntry
+		e.rxErrs, _ = strconv.ParseUint(parts[5], 10, 64)
+		e.drops, _ = strconv.ParseUint(parts[6], 10, 64)
+		e.rx, _ = strconv.ParseUint(parts[7], 10, 64)
+		e.txErrs, _ = strconv.ParseUint(parts[9], 10, 64)
+		e.tx, _ = strconv.ParseUint(parts[10], 10, 64)
+		result[ifaceName] = e
 	}
 
 	return result
@@ -463,13 +1038,64 @@ func FormatBytes(b uint64) string {
 	return fmt.Sprintf("%.1f %cB", float64(b)/float64(div), "KMGTPE"[exp])
 }
 
-// FormatRate formats bytes per second to a human-readable rate.
-func FormatRate(bytesPerSec float64) string {
-	if bytesPerSec < 1024 {
-		return fmt.Sprintf("%.0f B/s", bytesPerSec)
+// RateFormatter formats traffic rates with a choice of units.
+type RateFormatter struct {
+	Base     float64 // Step between prefixes: 1024 (the default if 0) or 1000
+	Decimals int     // Places shown once a prefix is used
+	Bits     bool    // Bits per second (bps, Kbps) instead of bytes (B/s, KB/s)
+	MaxUnit  int     // Largest prefix: 1 for K, 2 for M, 3 for G (also if 0)
+}
+
+var (
+	// DefaultRateFormatter is FormatRate's: 1024-based bytes, one place,
+	// going no higher than MB/s.
+	DefaultRateFormatter = RateFormatter{Base: 1024, Decimals: 1, MaxUnit: 2}
+
+	// BitRateFormatter is FormatBitRate's: 1000-based bits, one place, the
+	// way link speeds are quoted.
+	BitRateFormatter = RateFormatter{Base: 1000, Decimals: 1, Bits: true}
+)
+
+var (
+	byteRateUnits = []string{"B/s", "KB/s", "MB/s", "GB/s"}
+	bitRateUnits  = []string{"bps", "Kbps", "Mbps", "Gbps"}
+)
+
+// Format formats bytes per second, using the largest prefix up to MaxUnit
+// that keeps the value at least 1. Values under one prefix step are whole
+// numbers.
+func (f RateFormatter) Format(bytesPerSec float64) string {
+	v, units := bytesPerSec, byteRateUnits
+	if f.Bits {
+		v, units = v*8, bitRateUnits
+	}
+	base := f.Base
+	if base <= 1 {
+		base = 1024
+	}
+
+	top := len(units) - 1
+	if f.MaxUnit > 0 {
+		top = min(top, f.MaxUnit)
+	}
+	exp := 0
+	for exp < top && v >= base {
+		v /= base
+		exp++
 	}
-	if bytesPerSec < 1024*1024 {
-		return fmt.Sprintf("%.1f KB/s", bytesPerSec/1024)
+	if exp == 0 {
+		return fmt.Sprintf("%.0f %s", v, units[0])
 	}
-	return fmt.Sprintf("%.1f MB/s", bytesPerSec/1024/1024)
+	return fmt.Sprintf("%.*f %s", max(0, f.Decimals), v, units[exp])
+}
+
+// FormatRate formats bytes per second to a human-readable rate.
+func FormatRate(bytesPerSec float64) string {
+	return DefaultRateFormatter.Format(bytesPerSec)
+}
+
+// FormatBitRate formats bytes per second as a bit rate with decimal
+// prefixes, the way link speeds are quoted (1 Kbps = 1000 bits/s).
+func FormatBitRate(bytesPerSec float64) string {
+	return BitRateFormatter.Format(bytesPerSec)
 }
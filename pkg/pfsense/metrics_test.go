@@ -0,0 +1,474 @@
+package pfsense
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const sampleDF = `Filesystem                  1024-blocks    Used    Avail Capacity  Mounted on
+/dev/ufsid/5f1a2b3c4d5e6f70  29736140 2964360 24393092    11%    /
+devfs                                1       1        0   100%    /dev
+tmpfs                           4096     212     3884     5%    /var
+/dev/md0                        3484      12     3196     0%    /tmp
+/dev/da0s1                    100000   50000    50000    50%    /mnt/usb stick
+`
+
+func TestParseDF(t *testing.T) {
+	disks := parseDF(sampleDF, []string{"/", "/var"})
+	if len(disks) != 2 {
+		t.Fatalf("Expected 2 filesystems, got %d", len(disks))
+	}
+
+	root := disks[0]
+	if root.Mount != "/" || root.Device != "/dev/ufsid/5f1a2b3c4d5e6f70" {
+		t.Errorf("Unexpected root filesystem: %+v", root)
+	}
+	if root.Total != 29736140*1024 || root.Used != 2964360*1024 || root.Avail != 24393092*1024 {
+		t.Errorf("Unexpected root sizes: %+v", root)
+	}
+
+	if disks[1].Mount != "/var" || disks[1].Used != 212*1024 {
+		t.Errorf("Unexpected /var filesystem: %+v", disks[1])
+	}
+}
+
+func TestParseDF_MissingMount(t *testing.T) {
+	// /var is part of the root filesystem on many installs
+	out := `Filesystem     1K-blocks     Used Available Use% Mounted on
+/dev/sda1       41152736 12345678  26693568  32% /
+`
+	disks := parseDF(out, []string{"/", "/var"})
+	if len(disks) != 1 || disks[0].Mount != "/" {
+		t.Fatalf("Expected only the root filesystem, got %+v", disks)
+	}
+	if pct := disks[0].UsedPercent(); pct < 29.9 || pct > 30.1 {
+		t.Errorf("Expected ~30%% used, got %.2f", pct)
+	}
+}
+
+func TestParseDF_MountWithSpaces(t *testing.T) {
+	disks := parseDF(sampleDF, []string{"/mnt/usb stick"})
+	if len(disks) != 1 || disks[0].UsedPercent() != 50 {
+		t.Errorf("Expected mount with spaces to parse, got %+v", disks)
+	}
+}
+
+func TestParseDF_Empty(t *testing.T) {
+	if disks := parseDF("", diskMounts); len(disks) != 0 {
+		t.Errorf("Expected no filesystems, got %+v", disks)
+	}
+}
+
+func TestParseSysctlTemp(t *testing.T) {
+	tests := map[string]float64{
+		"45.0C":  45.0,
+		" 27.9C": 27.9,
+		"100.5C": 100.5,
+	}
+	for in, want := range tests {
+		got, err := parseSysctlTemp(in)
+		if err != nil || got != want {
+			t.Errorf("parseSysctlTemp(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+
+	for _, bad := range []string{"", "45.0", "hotC"} {
+		if _, err := parseSysctlTemp(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+func TestParseSysctlTemps(t *testing.T) {
+	out := `dev.cpu.0.%desc: ACPI CPU
+dev.cpu.0.temperature: 45.0C
+dev.cpu.0.freq: 1600
+dev.cpu.1.temperature: 47.5C
+hw.acpi.thermal.tz0.temperature: 27.9C
+hw.acpi.thermal.tz0._CRT: 105.0C
+`
+	temps := parseSysctlTemps(out)
+	want := []float64{45.0, 47.5, 27.9}
+	if len(temps) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, temps)
+	}
+	for i := range want {
+		if temps[i] != want[i] {
+			t.Errorf("Temp %d: expected %v, got %v", i, want[i], temps[i])
+		}
+	}
+
+	m := &Metrics{Temps: temps}
+	if m.MaxTemp() != 47.5 {
+		t.Errorf("Expected max temp 47.5, got %v", m.MaxTemp())
+	}
+}
+
+func TestParsePS(t *testing.T) {
+	out := `  PID %CPU %MEM COMMAND
+    1  0.0  0.1 init
+  412 12.5  2.3 unbound
+  977 12.5  4.0 php-fpm: pool nginx
+  980 45.2  1.0 Web Content
+ bad line here
+`
+	procs := parsePS(out)
+	if len(procs) != 4 {
+		t.Fatalf("Expected 4 processes, got %d: %+v", len(procs), procs)
+	}
+
+	want := []ProcessMetrics{
+		{PID: 980, CPU: 45.2, Mem: 1.0, Name: "Web Content"},
+		{PID: 977, CPU: 12.5, Mem: 4.0, Name: "php-fpm: pool nginx"}, // Tie broken by memory
+		{PID: 412, CPU: 12.5, Mem: 2.3, Name: "unbound"},
+		{PID: 1, CPU: 0, Mem: 0.1, Name: "init"},
+	}
+	for i, w := range want {
+		if procs[i] != w {
+			t.Errorf("procs[%d] = %+v, want %+v", i, procs[i], w)
+		}
+	}
+}
+
+func TestParseNTPQ(t *testing.T) {
+	synced := `     remote           refid      st t when poll reach   delay   offset  jitter
+==============================================================================
+ 0.pfsense.pool. .POOL.          16 p    -   64    0    0.000   +0.000   0.000
+*162.159.200.1   10.20.8.4        3 u   38   64  377   12.093   -0.481   0.512
++192.0.2.10      203.0.113.5      2 u   40   64  377   20.118   +1.004   0.733
+`
+	if !parseNTPQ(synced) {
+		t.Error("Expected a '*' peer to count as synced")
+	}
+
+	unsynced := `     remote           refid      st t when poll reach   delay   offset  jitter
+==============================================================================
+ 162.159.200.1   .INIT.          16 u    -   64    0    0.000   +0.000   0.000
+`
+	if parseNTPQ(unsynced) {
+		t.Error("Expected no system peer to count as unsynced")
+	}
+}
+
+func TestParseCPTimes_Deltas(t *testing.T) {
+	// user nice sys intr idle for each of 4 CPUs
+	first := "100 0 50 10 840 200 0 100 0 700 0 0 0 0 1000 500 0 250 50 200\n"
+	second := "150 0 75 15 860 300 0 200 0 700 0 0 0 0 1100 500 0 250 50 200\n"
+
+	prev := parseCPTimes(first)
+	if len(prev) != 4 {
+		t.Fatalf("Expected 4 cores, got %d", len(prev))
+	}
+	if prev[0].total != 1000 || prev[0].idle != 840 {
+		t.Errorf("core 0 = %+v, want total 1000 idle 840", prev[0])
+	}
+
+	cur := parseCPTimes(second)
+	usage := cpuDeltas(prev, cur)
+	want := []float64{
+		80,  // 100 ticks, 20 idle
+		100, // 200 ticks, none idle
+		0,   // 100 ticks, all idle
+		0,   // no ticks elapsed
+	}
+	if len(usage) != len(want) {
+		t.Fatalf("Expected %d usage values, got %d", len(want), len(usage))
+	}
+	for i, w := range want {
+		if usage[i] != w {
+			t.Errorf("usage[%d] = %.1f, want %.1f", i, usage[i], w)
+		}
+	}
+
+	// Without a previous sample every core reads zero
+	for i, u := range cpuDeltas(nil, cur) {
+		if u != 0 {
+			t.Errorf("first sample usage[%d] = %.1f, want 0", i, u)
+		}
+	}
+}
+
+func TestParseProcStatCores(t *testing.T) {
+	data := `cpu  400 0 200 3000 100 0 0 0 0 0
+cpu0 200 0 100 1500 50 10 5 0 0 0
+cpu1 200 0 100 1500 50 0 0 0 0 0
+intr 12345
+`
+	cores := parseProcStatCores(data)
+	if len(cores) != 2 {
+		t.Fatalf("Expected 2 cores, got %d", len(cores))
+	}
+	if cores[0].idle != 1550 || cores[0].intr != 15 || cores[0].total != 1865 {
+		t.Errorf("cpu0 = %+v, want idle 1550 intr 15 total 1865", cores[0])
+	}
+}
+
+func TestParseSwapinfo(t *testing.T) {
+	out := `Device          1K-blocks     Used    Avail Capacity
+/dev/ada0p3       2097152    51200  2045952     2%
+/dev/md99         1048576        0  1048576     0%
+Total             3145728    51200  3094528     2%
+`
+	used, total := parseSwapinfo(out)
+	if total != 3145728*1024 {
+		t.Errorf("total = %d, want %d", total, 3145728*1024)
+	}
+	if used != 51200*1024 {
+		t.Errorf("used = %d, want %d", used, 51200*1024)
+	}
+
+	// Header only: no swap configured
+	used, total = parseSwapinfo("Device          1K-blocks     Used    Avail Capacity\n")
+	if used != 0 || total != 0 {
+		t.Errorf("no swap: used=%d total=%d, want 0 0", used, total)
+	}
+}
+
+func TestParseMeminfoSwap(t *testing.T) {
+	data := `MemTotal:        8048572 kB
+MemAvailable:    6012344 kB
+SwapTotal:       2097148 kB
+SwapFree:        1572860 kB
+`
+	used, total := parseMeminfoSwap(data)
+	if total != 2097148*1024 {
+		t.Errorf("total = %d, want %d", total, 2097148*1024)
+	}
+	if used != (2097148-1572860)*1024 {
+		t.Errorf("used = %d, want %d", used, (2097148-1572860)*1024)
+	}
+
+	used, total = parseMeminfoSwap("SwapTotal:             0 kB\nSwapFree:              0 kB\n")
+	if used != 0 || total != 0 {
+		t.Errorf("no swap: used=%d total=%d, want 0 0", used, total)
+	}
+}
+
+func TestParseNetstatIBN(t *testing.T) {
+	out := `Name    Mtu Network       Address              Ipkts Ierrs Idrop     Ibytes    Opkts Oerrs     Obytes  Coll
+igb0   1500 <Link#1>      00:0d:b9:4a:12:30  9876543    12     3 8765432100  5432109     7 1234567890     0
+igb0      - 203.0.113.0/2 203.0.113.10       1234567     -     -  987654321  2345678     -  345678901     -
+igb1*  1500 <Link#2>      00:0d:b9:4a:12:31        0     0     0          0        0     0          0     0
+`
+	stats := parseNetstatIBN(out)
+	if len(stats) != 2 {
+		t.Fatalf("Expected 2 link entries, got %d", len(stats))
+	}
+
+	want := ifaceStatsEntry{rx: 8765432100, tx: 1234567890, rxErrs: 12, txErrs: 7, drops: 3}
+	if stats["igb0"] != want {
+		t.Errorf("igb0 = %+v, want %+v", stats["igb0"], want)
+	}
+	if _, ok := stats["igb1"]; !ok {
+		t.Error("Expected the trailing '*' to be stripped from inactive interfaces")
+	}
+
+	var iface InterfaceMetrics
+	stats["igb0"].apply(&iface)
+	if iface.ErrorCount() != 22 {
+		t.Errorf("ErrorCount() = %d, want 22", iface.ErrorCount())
+	}
+}
+
+func TestMetrics_JSON(t *testing.T) {
+	m := &Metrics{
+		Hostname: "fw",
+		CPU:      42.5,
+		MemUsed:  1 << 30,
+		MemTotal: 4 << 30,
+		Uptime:   26*time.Hour + 30*time.Second,
+		LoadAvg:  [3]float64{0.5, 0.25, 0.1},
+		Interfaces: []InterfaceMetrics{
+			{Name: "igb0", Description: "WAN", Status: InterfaceUp, TxBytes: 100},
+		},
+		Gateways: []GatewayMetrics{{Name: "WAN_DHCP", Status: GatewayUp}},
+	}
+
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if raw["hostname"] != "fw" || raw["cpu"] != 42.5 || raw["mem_total"] != float64(4<<30) {
+		t.Errorf("Unexpected top-level fields: %s", data)
+	}
+	if raw["uptime_seconds"] != 93630.0 {
+		t.Errorf("uptime_seconds = %v, want 93630", raw["uptime_seconds"])
+	}
+	if _, ok := raw["uptime"]; ok {
+		t.Error("Expected no nanosecond uptime field")
+	}
+	if _, ok := raw["boot_time"]; ok {
+		t.Error("Expected no boot_time field when it is unknown")
+	}
+	ifaces, _ := raw["interfaces"].([]any)
+	if len(ifaces) != 1 || ifaces[0].(map[string]any)["name"] != "igb0" {
+		t.Errorf("Unexpected interfaces: %v", raw["interfaces"])
+	}
+
+	var back Metrics
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Uptime != m.Uptime || back.Hostname != m.Hostname || len(back.Gateways) != 1 {
+		t.Errorf("Round trip mismatch: %+v", back)
+	}
+}
+
+const samplePfctlInfo = `Status: Enabled for 12 days 03:04:05           Debug: Urgent
+
+Hostid:   0x1a2b3c4d
+Checksum: 0x0123456789abcdef0123456789abcdef
+
+Interface Stats for igb0              IPv4             IPv6
+  Bytes In                      7940000000                0
+  Bytes Out                     1200000000                0
+  Packets In
+    Passed                        10000000                0
+    Blocked                          12345               55
+  Packets Out
+    Passed                         9000000                0
+    Blocked                             10                0
+
+State Table                          Total             Rate
+  current entries                     1234               
+  half-open tcp                          3               
+  searches                        98765432          93.4/s
+  inserts                           654321            0.6/s
+  removals                          653087            0.6/s
+Source Tracking Table
+  current entries                        7               
+Counters
+  match                            5678901            5.4/s
+  bad-offset                             0            0.0/s
+  fragment                               2            0.0/s
+  state-limit                            0            0.0/s
+Limit Counters
+  max states per rule                    0            0.0/s
+`
+
+const samplePfctlLimits = `states        hard limit   200000
+src-nodes     hard limit   200000
+frags         hard limit     5000
+table-entries hard limit   400000
+`
+
+func TestParsePfctlInfo(t *testing.T) {
+	st, ok := parsePfctlInfo(samplePfctlInfo)
+	if !ok {
+		t.Fatal("Expected a state table")
+	}
+	if st.states != 1234 {
+		t.Errorf("states = %d, want 1234 (not the source tracking entries)", st.states)
+	}
+	if st.matches != 5678901 {
+		t.Errorf("matches = %d, want 5678901", st.matches)
+	}
+	if st.blocked != 12345+55+10 {
+		t.Errorf("blocked = %d, want %d", st.blocked, 12345+55+10)
+	}
+
+	if _, ok := parsePfctlInfo("pfctl: /dev/pf: Permission denied\n"); ok {
+		t.Error("Expected no state table in an error message")
+	}
+}
+
+func TestParsePfctlStateLimit(t *testing.T) {
+	if got := parsePfctlStateLimit(samplePfctlLimits); got != 200000 {
+		t.Errorf("limit = %d, want 200000", got)
+	}
+	if got := parsePfctlStateLimit(""); got != 0 {
+		t.Errorf("limit of empty output = %d, want 0", got)
+	}
+}
+
+func TestNormalizeInterfaceStatus(t *testing.T) {
+	for status, want := range map[string]string{
+		"active":      InterfaceUp,
+		" associated": InterfaceUp,
+		"no carrier":  InterfaceDown,
+		"no network":  InterfaceDown,
+		"":            InterfaceDown,
+	} {
+		if got := normalizeInterfaceStatus(status); got != want {
+			t.Errorf("normalizeInterfaceStatus(%q) = %s, want %s", status, got, want)
+		}
+	}
+}
+
+func TestFormatRate(t *testing.T) {
+	for bytesPerSec, want := range map[float64]string{
+		0:               "0 B/s",
+		1023:            "1023 B/s",
+		1024:            "1.0 KB/s",
+		1024*1024 - 100: "1023.9 KB/s",
+		1024 * 1024:     "1.0 MB/s",
+		2 << 30:         "2048.0 MB/s", // No GB/s
+	} {
+		if got := FormatRate(bytesPerSec); got != want {
+			t.Errorf("FormatRate(%v) = %q, want %q", bytesPerSec, got, want)
+		}
+	}
+}
+
+func TestRateFormatter_Base(t *testing.T) {
+	binary := RateFormatter{Base: 1024, Decimals: 1}
+	si := RateFormatter{Base: 1000, Decimals: 1}
+	for bytesPerSec, want := range map[float64][2]string{
+		1000:      {"1000 B/s", "1.0 KB/s"},
+		1024:      {"1.0 KB/s", "1.0 KB/s"},
+		1_000_000: {"976.6 KB/s", "1.0 MB/s"},
+		1 << 20:   {"1.0 MB/s", "1.0 MB/s"},
+		2e9:       {"1.9 GB/s", "2.0 GB/s"},
+	} {
+		if got := binary.Format(bytesPerSec); got != want[0] {
+			t.Errorf("1024-based Format(%v) = %q, want %q", bytesPerSec, got, want[0])
+		}
+		if got := si.Format(bytesPerSec); got != want[1] {
+			t.Errorf("1000-based Format(%v) = %q, want %q", bytesPerSec, got, want[1])
+		}
+	}
+
+	// Precision and bits
+	f := RateFormatter{Base: 1024, Decimals: 2, Bits: true}
+	if got := f.Format(1 << 17); got != "1.00 Mbps" {
+		t.Errorf("1024-based bit Format = %q, want 1.00 Mbps", got)
+	}
+	if got := (RateFormatter{Base: 1000, MaxUnit: 1}).Format(2e6); got != "2000 KB/s" {
+		t.Errorf("MaxUnit 1 Format(2e6) = %q, want 2000 KB/s", got)
+	}
+	if got := (RateFormatter{}).Format(1536); got != "2 KB/s" {
+		t.Errorf("Zero RateFormatter Format(1536) = %q, want 1024-based with no places", got)
+	}
+}
+
+func TestFormatBitRate(t *testing.T) {
+	for bytesPerSec, want := range map[float64]string{
+		0:       "0 bps",
+		124:     "992 bps",
+		125:     "1.0 Kbps",
+		124_990: "999.9 Kbps",
+		125_000: "1.0 Mbps",
+		1.25e8:  "1.0 Gbps",
+	} {
+		if got := FormatBitRate(bytesPerSec); got != want {
+			t.Errorf("FormatBitRate(%v) = %q, want %q", bytesPerSec, got, want)
+		}
+	}
+
+	// The daemon's formatter follows SetBitRates
+	var nilDaemon *StatusDaemon
+	if got := nilDaemon.formatRate(125); got != "125 B/s" {
+		t.Errorf("nil daemon formatRate(125) = %q, want bytes", got)
+	}
+	sd := &StatusDaemon{}
+	sd.SetBitRates(true)
+	if got := sd.formatRate(125); got != "1.0 Kbps" {
+		t.Errorf("formatRate(125) with bit rates = %q, want 1.0 Kbps", got)
+	}
+}
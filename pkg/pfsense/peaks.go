@@ -0,0 +1,84 @@
+package pfsense
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// updatePeak raises name's peak rates to r where r is higher.
+func (sd *StatusDaemon) updatePeak(name string, r ifaceRate) {
+	sd.peakMu.Lock()
+	defer sd.peakMu.Unlock()
+	p := sd.ifacePeaks[name]
+	p.txRate = max(p.txRate, r.txRate)
+	p.rxRate = max(p.rxRate, r.rxRate)
+	sd.ifacePeaks[name] = p
+}
+
+// prunePeaks forgets the peaks of interfaces not in current.
+func (sd *StatusDaemon) prunePeaks(current map[string]bool) {
+	sd.peakMu.Lock()
+	defer sd.peakMu.Unlock()
+	for name := range sd.ifacePeaks {
+		if !current[name] {
+			delete(sd.ifacePeaks, name)
+		}
+	}
+}
+
+// PeakRate returns the highest transmit and receive rates, in bytes per
+// second, seen on an interface since the peaks were last reset.
+func (sd *StatusDaemon) PeakRate(name string) (tx, rx float64) {
+	sd.peakMu.Lock()
+	defer sd.peakMu.Unlock()
+	p := sd.ifacePeaks[name]
+	return p.txRate, p.rxRate
+}
+
+// ResetPeaks clears every interface's peak rates. It is safe to call from
+// any goroutine, e.g. a menu action.
+func (sd *StatusDaemon) ResetPeaks() {
+	sd.peakMu.Lock()
+	defer sd.peakMu.Unlock()
+	clear(sd.ifacePeaks)
+	sd.peaksSince = time.Now()
+}
+
+// PeaksSince returns when the peaks were last reset, or the daemon created.
+func (sd *StatusDaemon) PeaksSince() time.Time {
+	sd.peakMu.Lock()
+	defer sd.peakMu.Unlock()
+	return sd.peaksSince
+}
+
+// compactRate is formatRate cut down for tight rows and the peaks drawn
+// beside live rates, e.g. "1.5K" for 1.5 KB/s or "1.5Mb" for 1.5 Mbps.
+func (sd *StatusDaemon) compactRate(bytesPerSec float64) string {
+	return strings.NewReplacer(" ", "", "B/s", "", "bps", "b").Replace(sd.formatRate(bytesPerSec))
+}
+
+// drawRate draws label and a live rate at x, y with the peak in the small
+// font after it, and returns where the text ends.
+func (sd *StatusDaemon) drawRate(fb *eziog500.FrameBuffer, x, y int, label string, rate, peak float64) int {
+	end := font.RenderText(fb, font.BuiltinFont, x, y, label+sd.formatRate(rate))
+	return font.RenderText(fb, font.SmallFont, end+2, y+2, sd.compactRate(peak))
+}
+
+// drawRateRow draws an interface's live rates from x on a 10 pixel list
+// row at y, with its transmit and receive peaks stacked at the right edge.
+func (sd *StatusDaemon) drawRateRow(fb *eziog500.FrameBuffer, x, y int, name string) {
+	tx, rx := sd.GetIfaceRate(name)
+	peakTx, peakRx := sd.PeakRate(name)
+	sf := font.SmallFont
+	ptx, prx := sd.compactRate(peakTx), sd.compactRate(peakRx)
+	font.RenderText(fb, sf, 128-font.MeasureText(sf, ptx), y, ptx)
+	font.RenderText(fb, sf, 128-font.MeasureText(sf, prx), y+5, prx)
+
+	peakX := 128 - max(font.MeasureText(sf, ptx), font.MeasureText(sf, prx))
+	live := fmt.Sprintf("T%s R%s", sd.compactRate(tx), sd.compactRate(rx))
+	font.RenderTextClipped(fb, font.BuiltinFont, x, y, peakX-2-x, live)
+}
@@ -0,0 +1,91 @@
+package pfsense
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+)
+
+func TestStatusDaemon_PeakRate(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	samples := []ifaceRate{
+		{txRate: 100, rxRate: 1000},
+		{txRate: 500, rxRate: 3000},
+		{txRate: 900, rxRate: 2000}, // RX already past its peak
+		{txRate: 300, rxRate: 500},
+		{txRate: 0, rxRate: 0},
+	}
+	for _, r := range samples {
+		daemon.updatePeak("igb0", r)
+	}
+	if tx, rx := daemon.PeakRate("igb0"); tx != 900 || rx != 3000 {
+		t.Errorf("PeakRate = %v/%v, want 900/3000", tx, rx)
+	}
+	if tx, rx := daemon.PeakRate("igb1"); tx != 0 || rx != 0 {
+		t.Errorf("PeakRate of an unseen interface = %v/%v, want 0/0", tx, rx)
+	}
+
+	before := daemon.PeaksSince()
+	time.Sleep(time.Millisecond)
+	daemon.ResetPeaks()
+	if tx, rx := daemon.PeakRate("igb0"); tx != 0 || rx != 0 {
+		t.Errorf("PeakRate after reset = %v/%v, want 0/0", tx, rx)
+	}
+	if !daemon.PeaksSince().After(before) {
+		t.Error("Expected ResetPeaks to move PeaksSince")
+	}
+
+	daemon.updatePeak("igb0", ifaceRate{txRate: 50, rxRate: 60})
+	if tx, rx := daemon.PeakRate("igb0"); tx != 50 || rx != 60 {
+		t.Errorf("PeakRate = %v/%v, want 50/60", tx, rx)
+	}
+}
+
+func TestStatusDaemon_CompactRate(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	for bytesPerSec, want := range map[float64]string{
+		512:     "512",
+		1536:    "1.5K",
+		3 << 20: "3.0M",
+	} {
+		if got := daemon.compactRate(bytesPerSec); got != want {
+			t.Errorf("compactRate(%v) = %q, want %q", bytesPerSec, got, want)
+		}
+	}
+	daemon.SetRateFormatter(BitRateFormatter)
+	if got := daemon.compactRate(1500); got != "12.0Kb" {
+		t.Errorf("compactRate with bits = %q, want 12.0Kb", got)
+	}
+}
+
+func TestLANTrafficScreen_Peaks(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	m := &Metrics{Interfaces: []InterfaceMetrics{{Name: "igb1", Description: "LAN"}}}
+	s := &LANTrafficScreen{daemon: daemon}
+	peakPixels := func() int {
+		if err := s.Render(d, m); err != nil {
+			t.Fatalf("render failed: %v", err)
+		}
+		n := 0
+		for x := 100; x < 128; x++ {
+			for y := 11; y < 21; y++ {
+				if d.FrameBuffer().GetPixel(x, y) {
+					n++
+				}
+			}
+		}
+		return n
+	}
+
+	// The peaks are drawn at the end of the row on every frame
+	before := peakPixels()
+	daemon.updatePeak("igb1", ifaceRate{txRate: 900 << 10, rxRate: 900 << 10})
+	if after := peakPixels(); after <= before {
+		t.Errorf("Expected wider peaks at the row end, got %d lit pixels, had %d", after, before)
+	}
+}
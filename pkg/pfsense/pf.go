@@ -0,0 +1,104 @@
+package pfsense
+
+import (
+	"bufio"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// pfStats holds pf's state table and packet counters.
+type pfStats struct {
+	states, limit, matches, blocked uint64
+}
+
+// getPFStates reads the state table size and counters from pfctl -vsi and
+// the state limit from pfctl -sm. It fails where pf isn't available.
+func (s *SystemMetrics) getPFStates() (pfStats, error) {
+	out, err := exec.Command("pfctl", "-vsi").Output()
+	if err != nil {
+		return pfStats{}, err
+	}
+	st, ok := parsePfctlInfo(string(out))
+	if !ok {
+		return pfStats{}, fmt.Errorf("no state table in pfctl -si output")
+	}
+
+	if out, err := exec.Command("pfctl", "-sm").Output(); err == nil {
+		st.limit = parsePfctlStateLimit(string(out))
+	}
+	return st, nil
+}
+
+// parsePfctlInfo parses pfctl -vsi output:
+//
+//	Interface Stats for igb0              IPv4             IPv6
+//	  Packets In
+//	    Passed                        10000000                0
+//	    Blocked                          12345                0
+//	...
+//	State Table                          Total             Rate
+//	  current entries                     1234
+//	...
+//	Counters
+//	  match                            5678901          123.4/s
+//
+// Blocked packets are summed across directions and address families; they
+// are only present when pf has a loginterface, as pfSense sets for WAN.
+func parsePfctlInfo(out string) (pfStats, bool) {
+	var st pfStats
+	found := false
+	section := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+		if line[0] != ' ' {
+			section = strings.Fields(line)[0]
+			continue
+		}
+
+		fields := strings.Fields(line)
+		switch {
+		case section == "State" && len(fields) >= 3 && fields[0] == "current" && fields[1] == "entries":
+			if n, err := strconv.ParseUint(fields[2], 10, 64); err == nil {
+				st.states = n
+				found = true
+			}
+		case section == "Counters" && len(fields) >= 2 && fields[0] == "match":
+			st.matches, _ = strconv.ParseUint(fields[1], 10, 64)
+		case section == "Interface" && fields[0] == "Blocked":
+			for _, f := range fields[1:] {
+				n, _ := strconv.ParseUint(f, 10, 64)
+				st.blocked += n
+			}
+		}
+	}
+	return st, found
+}
+
+// parsePfctlStateLimit returns the state table hard limit from pfctl -sm
+// output ("states        hard limit   200000"), or 0 if absent.
+func parsePfctlStateLimit(out string) uint64 {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 4 && fields[0] == "states" && fields[1] == "hard" && fields[2] == "limit" {
+			n, _ := strconv.ParseUint(fields[3], 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// StateUsage returns the pf state table fill percentage, or false if the
+// limit is unknown.
+func (m *Metrics) StateUsage() (float64, bool) {
+	if m.PFStateLimit == 0 {
+		return 0, false
+	}
+	return float64(m.PFStates) / float64(m.PFStateLimit) * 100, true
+}
@@ -0,0 +1,126 @@
+package pfsense
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultPublicIPURL is a lookup service that answers with the caller's
+// address as plain text.
+const DefaultPublicIPURL = "https://api.ipify.org"
+
+// publicIPTTL is how long a looked-up address is reused, and how long to
+// wait after a failed lookup before trying again.
+const publicIPTTL = 5 * time.Minute
+
+// publicIPTimeout bounds each lookup so a slow service can't stall
+// metrics collection.
+const publicIPTimeout = 3 * time.Second
+
+// publicIPLookup fetches and caches the public address from a URL that
+// returns it as plain text.
+type publicIPLookup struct {
+	url    string
+	client *http.Client
+	now    func() time.Time // Overridden in tests
+
+	mu          sync.Mutex
+	ip          string
+	lastAttempt time.Time
+}
+
+func newPublicIPLookup(url string) *publicIPLookup {
+	return &publicIPLookup{
+		url:    url,
+		client: &http.Client{Timeout: publicIPTimeout},
+		now:    time.Now,
+	}
+}
+
+// get returns the cached address, refreshing it once publicIPTTL has
+// passed since the last attempt. A failed refresh keeps the previous
+// address if there is one.
+func (l *publicIPLookup) get() (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.lastAttempt.IsZero() && now.Sub(l.lastAttempt) < publicIPTTL {
+		if l.ip == "" {
+			return "", fmt.Errorf("public IP unavailable")
+		}
+		return l.ip, nil
+	}
+	l.lastAttempt = now
+
+	ip, err := fetchPublicIP(l.client, l.url)
+	if err != nil {
+		if l.ip != "" {
+			return l.ip, nil
+		}
+		return "", err
+	}
+	l.ip = ip
+	return ip, nil
+}
+
+// fetchPublicIP GETs url and parses the body as an IP address.
+func fetchPublicIP(client *http.Client, url string) (string, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("public IP lookup: unexpected status %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return "", err
+	}
+	text := strings.TrimSpace(string(body))
+	ip := net.ParseIP(text)
+	if ip == nil {
+		return "", fmt.Errorf("public IP lookup: invalid address %q", text)
+	}
+	return ip.String(), nil
+}
+
+// getPublicIP returns the public address when the lookup is enabled.
+func (s *SystemMetrics) getPublicIP() (string, error) {
+	if s.publicIP == nil {
+		return "", fmt.Errorf("public IP lookup disabled")
+	}
+	return s.publicIP.get()
+}
+
+// SetPublicIPURL enables looking up the public address from url, which
+// must answer with the address as plain text (e.g. DefaultPublicIPURL).
+// An empty url disables the lookup, which is the default.
+func (s *SystemMetrics) SetPublicIPURL(url string) {
+	if url == "" {
+		s.publicIP = nil
+		return
+	}
+	s.publicIP = newPublicIPLookup(url)
+}
+
+// SetPublicIPURL enables the public address lookup; see
+// SystemMetrics.SetPublicIPURL.
+func (g *GopsutilMetrics) SetPublicIPURL(url string) {
+	g.sys.SetPublicIPURL(url)
+}
+
+// SetPublicIPURL enables the public address lookup on the metrics provider,
+// if it supports one. The address is shown on the WAN Traffic screen.
+func (sd *StatusDaemon) SetPublicIPURL(url string) {
+	if p, ok := sd.metrics.(interface{ SetPublicIPURL(string) }); ok {
+		p.SetPublicIPURL(url)
+	}
+}
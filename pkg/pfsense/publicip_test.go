@@ -0,0 +1,84 @@
+package pfsense
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPublicIPLookup_Caches(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		fmt.Fprintln(w, "203.0.113.7")
+	}))
+	defer srv.Close()
+
+	l := newPublicIPLookup(srv.URL)
+	clock := time.Unix(0, 0)
+	l.now = func() time.Time { return clock }
+
+	for i := 0; i < 3; i++ {
+		ip, err := l.get()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ip != "203.0.113.7" {
+			t.Fatalf("ip = %q, want 203.0.113.7", ip)
+		}
+		clock = clock.Add(time.Minute)
+	}
+	if n := hits.Load(); n != 1 {
+		t.Errorf("Expected 1 lookup within the TTL, got %d", n)
+	}
+
+	clock = clock.Add(publicIPTTL)
+	if _, err := l.get(); err != nil {
+		t.Fatal(err)
+	}
+	if n := hits.Load(); n != 2 {
+		t.Errorf("Expected a fresh lookup after the TTL, got %d lookups", n)
+	}
+}
+
+func TestPublicIPLookup_Errors(t *testing.T) {
+	body := "203.0.113.7"
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	l := newPublicIPLookup(srv.URL)
+	clock := time.Unix(0, 0)
+	l.now = func() time.Time { return clock }
+
+	// Garbage is rejected
+	body = "<html>rate limited</html>"
+	if _, err := l.get(); err == nil {
+		t.Error("Expected an error for a non-IP response")
+	}
+
+	// A good answer is cached, then kept when a later refresh fails
+	clock = clock.Add(publicIPTTL)
+	body = "2001:db8::1"
+	if ip, err := l.get(); err != nil || ip != "2001:db8::1" {
+		t.Fatalf("get = %q, %v", ip, err)
+	}
+	clock = clock.Add(publicIPTTL)
+	status = http.StatusServiceUnavailable
+	if ip, err := l.get(); err != nil || ip != "2001:db8::1" {
+		t.Errorf("get after failure = %q, %v; want the previous address", ip, err)
+	}
+}
+
+func TestSystemMetrics_PublicIPOptIn(t *testing.T) {
+	s := &SystemMetrics{}
+	if _, err := s.getPublicIP(); err == nil {
+		t.Error("Expected the lookup to be disabled by default")
+	}
+}
@@ -0,0 +1,115 @@
+package pfsense
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ScreenFactory creates a screen bound to a daemon.
+type ScreenFactory func(d *StatusDaemon) StatusScreen
+
+// registry maps screen names to factories. Lookups are case-insensitive;
+// names keeps the registered spelling in registration order.
+var registry = struct {
+	sync.RWMutex
+	names     []string
+	factories map[string]ScreenFactory
+}{factories: make(map[string]ScreenFactory)}
+
+// defaultScreens are the built-in screens a new daemon shows, in order.
+var defaultScreens = []string{
+	"Logo", "Dashboard", "Clock", "CPU", "CPU Graph", "CPU Cores", "Memory",
+	"Disk", "Temperature", "Processes", "Gateways", "Firewall", "Services",
+	"DHCP Leases", "Interfaces", "WAN Traffic", "Tunnel Traffic",
+	"LAN Traffic", "Traffic Graph",
+}
+
+func init() {
+	RegisterScreen("Logo", func(d *StatusDaemon) StatusScreen { return &LogoScreen{} })
+	RegisterScreen("Dashboard", func(d *StatusDaemon) StatusScreen { return &DashboardScreen{daemon: d} })
+	RegisterScreen("Clock", func(d *StatusDaemon) StatusScreen { return &ClockScreen{daemon: d} })
+	RegisterScreen("CPU", func(d *StatusDaemon) StatusScreen { return &CPUScreen{} })
+	RegisterScreen("CPU Graph", func(d *StatusDaemon) StatusScreen { return &GraphScreen{Kind: GraphCPU, daemon: d} })
+	RegisterScreen("CPU Cores", func(d *StatusDaemon) StatusScreen { return &CoresScreen{} })
+	RegisterScreen("Memory", func(d *StatusDaemon) StatusScreen { return &MemoryScreen{} })
+	RegisterScreen("Disk", func(d *StatusDaemon) StatusScreen { return &DiskScreen{} })
+	RegisterScreen("Temperature", func(d *StatusDaemon) StatusScreen { return &TempScreen{} })
+	RegisterScreen("Processes", func(d *StatusDaemon) StatusScreen { return &ProcessScreen{} })
+	RegisterScreen("Gateways", func(d *StatusDaemon) StatusScreen { return &GatewayScreen{} })
+	RegisterScreen("Firewall", func(d *StatusDaemon) StatusScreen { return &FirewallScreen{daemon: d} })
+	RegisterScreen("Services", func(d *StatusDaemon) StatusScreen { return &ServiceScreen{daemon: d} })
+	RegisterScreen("DHCP Leases", func(d *StatusDaemon) StatusScreen { return &LeasesScreen{} })
+	RegisterScreen("Interfaces", func(d *StatusDaemon) StatusScreen { return &InterfaceScreen{} })
+	RegisterScreen("All Interfaces", func(d *StatusDaemon) StatusScreen { return &InterfaceScreen{ShowDown: true} })
+	RegisterScreen("WAN Traffic", func(d *StatusDaemon) StatusScreen { return &WANTrafficScreen{daemon: d} })
+	RegisterScreen("Tunnel Traffic", func(d *StatusDaemon) StatusScreen { return &TunnelTrafficScreen{daemon: d} })
+	RegisterScreen("LAN Traffic", func(d *StatusDaemon) StatusScreen { return &LANTrafficScreen{daemon: d} })
+	RegisterScreen("Traffic Graph", func(d *StatusDaemon) StatusScreen { return &GraphScreen{Kind: GraphTraffic, daemon: d} })
+}
+
+// RegisterScreen makes a screen available to NewScreenByName and
+// StatusDaemon.SetScreens, typically from an init function. The name should
+// match the screen's Name(). It panics if the name is already registered or
+// factory is nil.
+func RegisterScreen(name string, factory ScreenFactory) {
+	if factory == nil {
+		panic("pfsense: RegisterScreen factory is nil for " + name)
+	}
+	key := strings.ToLower(strings.TrimSpace(name))
+	registry.Lock()
+	defer registry.Unlock()
+	if _, dup := registry.factories[key]; dup {
+		panic("pfsense: RegisterScreen called twice for " + name)
+	}
+	registry.factories[key] = factory
+	registry.names = append(registry.names, name)
+}
+
+// NewScreenByName creates the registered screen with the given name,
+// matched case-insensitively, bound to d.
+func NewScreenByName(name string, d *StatusDaemon) (StatusScreen, error) {
+	registry.RLock()
+	factory := registry.factories[strings.ToLower(strings.TrimSpace(name))]
+	registry.RUnlock()
+	if factory == nil {
+		return nil, fmt.Errorf("unknown screen %q", name)
+	}
+	return factory(d), nil
+}
+
+// ScreenNames returns the names of all registered screens, built-in ones
+// first, in registration order.
+func ScreenNames() []string {
+	registry.RLock()
+	defer registry.RUnlock()
+	return append([]string(nil), registry.names...)
+}
+
+// SetScreens replaces the daemon's screens with the named ones, in the given
+// order. Unknown names are reported together, and the screen list is left
+// unchanged on error.
+func (sd *StatusDaemon) SetScreens(names []string) error {
+	if len(names) == 0 {
+		return fmt.Errorf("no screens selected")
+	}
+
+	var screens []StatusScreen
+	var unknown []string
+	for _, name := range names {
+		s, err := NewScreenByName(name, sd)
+		if err != nil {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+			continue
+		}
+		screens = append(screens, s)
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown screen %s (available: %s)",
+			strings.Join(unknown, ", "), strings.Join(ScreenNames(), ", "))
+	}
+
+	sd.screens = screens
+	sd.currentScreen = 0
+	return nil
+}
@@ -0,0 +1,115 @@
+package pfsense
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/display"
+)
+
+func TestStatusDaemon_DefaultScreensMatchNames(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	names := defaultScreens
+	if len(daemon.screens) != len(names) {
+		t.Fatalf("daemon has %d screens, registry %d", len(daemon.screens), len(names))
+	}
+	for i, s := range daemon.screens {
+		if s.Name() != names[i] {
+			t.Errorf("screen %d: Name() = %q, registered as %q", i, s.Name(), names[i])
+		}
+	}
+}
+
+func TestStatusDaemon_SetScreens(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	if err := daemon.SetScreens([]string{"clock", "WAN Traffic", " Gateways ", "Logo"}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Clock", "WAN Traffic", "Gateways", "Logo"}
+	if len(daemon.screens) != len(want) {
+		t.Fatalf("Expected %d screens, got %d", len(want), len(daemon.screens))
+	}
+	for i, s := range daemon.screens {
+		if s.Name() != want[i] {
+			t.Errorf("screen %d = %q, want %q", i, s.Name(), want[i])
+		}
+	}
+	// Screens that need daemon state get it
+	if daemon.screens[1].(*WANTrafficScreen).daemon != daemon {
+		t.Error("Expected WAN Traffic to be bound to the daemon")
+	}
+
+	// Unknown names are all reported and leave the list alone
+	err := daemon.SetScreens([]string{"CPU", "Weather", "Sports"})
+	if err == nil {
+		t.Fatal("Expected an error for unknown screens")
+	}
+	if !strings.Contains(err.Error(), `"Weather", "Sports"`) || !strings.Contains(err.Error(), "Traffic Graph") {
+		t.Errorf("Unhelpful error: %v", err)
+	}
+	if len(daemon.screens) != len(want) {
+		t.Error("Expected a failed SetScreens to keep the previous screens")
+	}
+
+	if err := daemon.SetScreens(nil); err == nil {
+		t.Error("Expected an error for an empty screen list")
+	}
+}
+
+// dummyScreen is a third-party style screen for registry tests.
+type dummyScreen struct{ daemon *StatusDaemon }
+
+func (s *dummyScreen) Name() string { return "Dummy" }
+
+func (s *dummyScreen) Render(d *display.Display, m *Metrics) error { return d.Update() }
+
+// registerDummy keeps TestRegisterScreen repeatable with -count.
+var registerDummy sync.Once
+
+func TestRegisterScreen(t *testing.T) {
+	registerDummy.Do(func() {
+		RegisterScreen("Dummy", func(d *StatusDaemon) StatusScreen { return &dummyScreen{daemon: d} })
+	})
+
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	s, err := NewScreenByName("dummy", daemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ds, ok := s.(*dummyScreen); !ok || ds.daemon != daemon {
+		t.Errorf("NewScreenByName returned %#v, want a dummyScreen bound to the daemon", s)
+	}
+
+	// Registered screens are listed and usable by name, but not shown by default
+	names := ScreenNames()
+	if names[len(names)-1] != "Dummy" {
+		t.Errorf("ScreenNames() = %v, want Dummy last", names)
+	}
+	for _, s := range daemon.screens {
+		if s.Name() == "Dummy" {
+			t.Error("Expected registered screens to be opt-in")
+		}
+	}
+	if err := daemon.SetScreens([]string{"Logo", "Dummy"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewScreenByName("Nonexistent", daemon); err == nil {
+		t.Error("Expected an error for an unregistered name")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a duplicate name to panic")
+		}
+	}()
+	RegisterScreen("LOGO", func(d *StatusDaemon) StatusScreen { return &LogoScreen{} })
+}
@@ -2,15 +2,21 @@
 package pfsense
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"os"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/sagostin/ezio-g500/pkg/display"
 	"github.com/sagostin/ezio-g500/pkg/eziog500"
 	"github.com/sagostin/ezio-g500/pkg/font"
+	"github.com/sagostin/ezio-g500/pkg/ui"
 )
 
 // StatusScreen represents a single status display screen.
@@ -22,7 +28,7 @@ type StatusScreen interface {
 // StatusDaemon manages rotating status screens.
 type StatusDaemon struct {
 	display        *display.Display
-	metrics        *SystemMetrics
+	metrics        MetricsProvider
 	screens        []StatusScreen
 	currentScreen  int
 	updateInterval time.Duration
@@ -31,15 +37,96 @@ type StatusDaemon struct {
 	frameCount     int
 	lastIfaceBytes map[string]ifaceBytes
 	lastSampleTime time.Time
-	ifaceRates     map[string]ifaceRate
-	cachedMetrics  *Metrics // Cached metrics to reduce process spawning
-	lastScreenHash uint64   // For dirty-frame detection
+	ifaceRates     map[string]ifaceRate  // Smoothed with rateSmoothing
+	rawRates       map[string]ifaceRate  // From the last two samples alone
+	ifaceHistory   map[string][]float64  // Recent raw tx+rx rates, oldest first
+	rateSmoothing  float64               // Weight of each new rate sample, 1 for none
+	ifaceBaseline  map[string]ifaceBytes // Counters when the daemon first saw each interface
+	ifacePeaks     map[string]ifaceRate  // Highest rates since peaksSince
+	ifaceMu        sync.RWMutex          // Guards ifaceRates, rawRates, ifaceHistory and ifaceBaseline (read while rendering)
+	peaksSince     time.Time
+	peakMu         sync.Mutex // Guards ifacePeaks and peaksSince (reset from the menu)
+	lastPFBlocked  uint64
+	pfBlockRate    float64      // Blocked packets per second, guarded by metricsMu
+	cachedMetrics  *Metrics     // Cached metrics to reduce process spawning
+	metricsMu      sync.RWMutex // Guards cachedMetrics (read by MetricsServer)
+	schedule       *BacklightSchedule
+	lastBacklight  int // Last scheduled or ambient level written, -1 if none
+	ambient        *BacklightController
+	ambientOK      bool // Last ambient reading succeeded, so it overrides the schedule
+	ledPolicy      LEDPolicy
+	classifier     *InterfaceClassifier
+	clock12h       bool // ClockScreen uses 12-hour time
+	rates          RateFormatter
+	alerts         *AlertManager
+	loading        *LoadingScreen
+	errorAfter     int // Consecutive failed fetches before ErrorScreen is shown
+	fetchFailures  int // Consecutive failed fetches, guarded by metricsMu
+	failingSince   time.Time
+	fetchErr       error
+	lastBootTime   time.Time
+	bootStateFile  string
+	onReboot       func(bootTime time.Time)
+	onBootStateErr func(err error)
+	onConnState    func(state eziog500.ConnState)
+	done           chan struct{}
+	stopOnce       sync.Once
+	serviceIcons   [2]ui.Icon  // ServiceScreen's running and stopped icons
+	reconnecting   atomic.Bool // Serial port is down; show ReconnectingScreen
+	resync         atomic.Bool // Port was reopened; resend device state
+
+	// Button input and the screensaver, used only by Run's goroutine
+	buttons       ButtonSource
+	onEnter       func()
+	navigated     time.Time     // Last screen change by hand
+	rotationPause time.Duration // Time the rotation waits after navigated
+	lastInput     time.Time     // Last button press, or when Run started
+	sleepAfter    time.Duration // Time without input before sleeping, 0 for never
+	asleep        bool
+	wakeBacklight byte // Backlight level to restore on wake
 }
 
+// DefaultTempThreshold is the temperature (°C) above which the health LED
+// (LED2 by default) turns red.
+const DefaultTempThreshold = 80.0
+
 type ifaceBytes struct{ tx, rx uint64 }
 type ifaceRate struct{ txRate, rxRate float64 }
 
-// MetricsHistory stores historical data.
+// smooth returns the moving average r after a new sample, which has weight
+// alpha.
+func (r ifaceRate) smooth(sample ifaceRate, alpha float64) ifaceRate {
+	return ifaceRate{
+		txRate: r.txRate + alpha*(sample.txRate-r.txRate),
+		rxRate: r.rxRate + alpha*(sample.rxRate-r.rxRate),
+	}
+}
+
+// counterRate returns the per-second rate of a byte counter that went from
+// last to cur in elapsed seconds. A counter that went backwards was reset
+// (interface bounce or 32-bit wrap), so that sample's rate is 0 and the
+// caller's new baseline is cur.
+func counterRate(cur, last uint64, elapsed float64) float64 {
+	if cur < last {
+		return 0
+	}
+	return float64(cur-last) / elapsed
+}
+
+// since returns the bytes transferred between base and b. A counter below
+// its baseline was reset, so all of it counts.
+func (b ifaceBytes) since(base ifaceBytes) ifaceBytes {
+	diff := func(cur, old uint64) uint64 {
+		if cur < old {
+			return cur
+		}
+		return cur - old
+	}
+	return ifaceBytes{tx: diff(b.tx, base.tx), rx: diff(b.rx, base.rx)}
+}
+
+// MetricsHistory stores historical data. AddSample shifts the slices in
+// place, so while samples are being added read them through Snapshot.
 type MetricsHistory struct {
 	CPUHistory     []float64
 	TxRateHistory  []float64
@@ -48,6 +135,24 @@ type MetricsHistory struct {
 	lastTxBytes    uint64
 	lastRxBytes    uint64
 	lastSampleTime time.Time
+	mu             sync.Mutex
+}
+
+// HistorySnapshot is a copy of a MetricsHistory's samples, oldest first.
+type HistorySnapshot struct {
+	CPU, Tx, Rx []float64
+}
+
+// LatestRates returns the newest Tx and Rx rates, 0 for either history
+// that has no samples yet.
+func (s HistorySnapshot) LatestRates() (tx, rx float64) {
+	if len(s.Tx) > 0 {
+		tx = s.Tx[len(s.Tx)-1]
+	}
+	if len(s.Rx) > 0 {
+		rx = s.Rx[len(s.Rx)-1]
+	}
+	return tx, rx
 }
 
 func NewMetricsHistory(maxSamples int) *MetricsHistory {
@@ -59,7 +164,22 @@ func NewMetricsHistory(maxSamples int) *MetricsHistory {
 	}
 }
 
+// Snapshot returns a copy of the samples, safe to use while AddSample
+// runs on another goroutine.
+func (h *MetricsHistory) Snapshot() HistorySnapshot {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return HistorySnapshot{
+		CPU: slices.Clone(h.CPUHistory),
+		Tx:  slices.Clone(h.TxRateHistory),
+		Rx:  slices.Clone(h.RxRateHistory),
+	}
+}
+
 func (h *MetricsHistory) AddSample(m *Metrics) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
 	// Use proper ring buffer pattern to avoid memory leak from slice-from-slice
 	if len(h.CPUHistory) >= h.maxSamples {
 		copy(h.CPUHistory, h.CPUHistory[1:])
@@ -76,8 +196,8 @@ func (h *MetricsHistory) AddSample(m *Metrics) {
 	if !h.lastSampleTime.IsZero() {
 		elapsed := now.Sub(h.lastSampleTime).Seconds()
 		if elapsed > 0 {
-			txRate := float64(totalTx-h.lastTxBytes) / elapsed
-			rxRate := float64(totalRx-h.lastRxBytes) / elapsed
+			txRate := counterRate(totalTx, h.lastTxBytes, elapsed)
+			rxRate := counterRate(totalRx, h.lastRxBytes, elapsed)
 
 			if len(h.TxRateHistory) >= h.maxSamples {
 				copy(h.TxRateHistory, h.TxRateHistory[1:])
@@ -98,29 +218,236 @@ func (h *MetricsHistory) AddSample(m *Metrics) {
 func NewStatusDaemon(d *display.Display, updateInterval, rotateInterval time.Duration) *StatusDaemon {
 	daemon := &StatusDaemon{
 		display:        d,
-		metrics:        NewSystemMetrics(),
+		metrics:        NewMetricsProvider(),
 		updateInterval: updateInterval,
 		rotateInterval: rotateInterval,
 		history:        NewMetricsHistory(12), // 12 samples = 1 minute at 5s intervals
 		lastIfaceBytes: make(map[string]ifaceBytes),
 		ifaceRates:     make(map[string]ifaceRate),
+		rawRates:       make(map[string]ifaceRate),
+		ifaceHistory:   make(map[string][]float64),
+		rateSmoothing:  1,
+		ifaceBaseline:  make(map[string]ifaceBytes),
+		ifacePeaks:     make(map[string]ifaceRate),
+		peaksSince:     time.Now(),
+		lastBacklight:  -1,
+		ledPolicy:      DefaultLEDPolicy(),
+		classifier:     NewInterfaceClassifier(DefaultInterfaceRules()...),
+		serviceIcons:   [2]ui.Icon{ui.IconCheck, ui.IconX},
+		loading:        &LoadingScreen{Title: "pfSense", Subtitle: "Starting..."},
+		errorAfter:     DefaultErrorAfter,
+		rates:          DefaultRateFormatter,
+		rotationPause:  display.DefaultRotationPause,
+		done:           make(chan struct{}),
 	}
 
-	// Multiple screens with better organization
-	daemon.screens = []StatusScreen{
-		&LogoScreen{},
-		&CPUScreen{},
-		&MemoryScreen{},
-		&InterfaceScreen{},
-		&WANTrafficScreen{daemon: daemon},
-		&TunnelTrafficScreen{daemon: daemon},
-		&LANTrafficScreen{daemon: daemon},
+	// Every built-in screen, in the default order
+	for _, name := range defaultScreens {
+		s, _ := NewScreenByName(name, daemon)
+		daemon.screens = append(daemon.screens, s)
 	}
 	return daemon
 }
 
 func (sd *StatusDaemon) AddScreen(s StatusScreen) { sd.screens = append(sd.screens, s) }
 
+// SetLEDPolicy replaces the thresholds and LED roles used by updateLEDs.
+func (sd *StatusDaemon) SetLEDPolicy(p LEDPolicy) {
+	sd.ledPolicy = p
+}
+
+// SetTempThreshold sets the temperature (°C) above which the health LED turns red.
+func (sd *StatusDaemon) SetTempThreshold(celsius float64) {
+	sd.ledPolicy.TempCritical = celsius
+}
+
+// SetClock12Hour switches ClockScreen between 12-hour and 24-hour time.
+func (sd *StatusDaemon) SetClock12Hour(enabled bool) {
+	sd.clock12h = enabled
+}
+
+// SetLoadingScreen replaces the screen shown until the first metrics
+// arrive (nil shows a blank screen).
+func (sd *StatusDaemon) SetLoadingScreen(s *LoadingScreen) {
+	sd.loading = s
+}
+
+// SetErrorThreshold sets how many fetches in a row must fail before
+// ErrorScreen replaces the screens, which otherwise keep showing the last
+// metrics.
+func (sd *StatusDaemon) SetErrorThreshold(failures int) {
+	sd.errorAfter = max(1, failures)
+}
+
+// SetOnReboot sets a function called with the new boot time when it moves
+// forward, i.e. the system rebooted. fn runs on the metrics goroutine. The
+// first sample only records the boot time unless SetBootStateFile has loaded
+// the one seen by a previous run, so without a state file a reboot that also
+// restarts the daemon isn't reported.
+func (sd *StatusDaemon) SetOnReboot(fn func(bootTime time.Time)) {
+	sd.onReboot = fn
+}
+
+// SetBootStateFile keeps the last boot time seen in path, so a reboot is
+// reported on the first sample of the daemon that starts after it. It loads
+// the previous run's boot time, if any; a missing file is not an error.
+// Call it before Run. Errors writing the file later go to the
+// SetOnBootStateError function.
+func (sd *StatusDaemon) SetBootStateFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		boot, err := time.Parse(time.RFC3339, strings.TrimSpace(string(data)))
+		if err != nil {
+			return fmt.Errorf("parse boot state %s: %w", path, err)
+		}
+		sd.lastBootTime = boot
+	}
+	sd.bootStateFile = path
+	return nil
+}
+
+// SetOnBootStateError sets a function called when the boot state file
+// can't be written. fn runs on the metrics goroutine.
+func (sd *StatusDaemon) SetOnBootStateError(fn func(err error)) {
+	sd.onBootStateErr = fn
+}
+
+// rebootSlack is how far the boot time may move without counting as a
+// reboot: FreeBSD shifts kern.boottime when the clock is stepped, and on
+// Linux it is worked out from the uptime.
+const rebootSlack = time.Minute
+
+// checkReboot records the boot time of a sample, calling onReboot if it
+// moved forward since the last one or the one in the boot state file.
+func (sd *StatusDaemon) checkReboot(boot *time.Time) {
+	if boot == nil || boot.IsZero() {
+		return
+	}
+	last := sd.lastBootTime
+	sd.lastBootTime = *boot
+	if last.IsZero() || boot.Sub(last).Abs() > rebootSlack {
+		if err := sd.saveBootTime(*boot); err != nil && sd.onBootStateErr != nil {
+			sd.onBootStateErr(err)
+		}
+	}
+	if !last.IsZero() && boot.Sub(last) > rebootSlack && sd.onReboot != nil {
+		sd.onReboot(*boot)
+	}
+}
+
+// saveBootTime writes boot to the boot state file, if one is set.
+func (sd *StatusDaemon) saveBootTime(boot time.Time) error {
+	if sd.bootStateFile == "" {
+		return nil
+	}
+	return os.WriteFile(sd.bootStateFile, []byte(boot.UTC().Format(time.RFC3339)+"\n"), 0o644)
+}
+
+// SetRateSmoothing smooths the interface rates shown by the traffic
+// screens with an exponential moving average that gives each new sample
+// weight alpha: 1 (the default) shows each sample as it is, and smaller
+// values trade jitter for lag; at 0.5 a step change is 7/8 shown after
+// three samples. Peaks are taken from the raw rates.
+func (sd *StatusDaemon) SetRateSmoothing(alpha float64) error {
+	if alpha <= 0 || alpha > 1 {
+		return fmt.Errorf("rate smoothing %v is outside (0, 1]", alpha)
+	}
+	sd.rateSmoothing = alpha
+	return nil
+}
+
+// SetBitRates switches the traffic screens between byte rates (KB/s, the
+// default) and bit rates (Kbps).
+func (sd *StatusDaemon) SetBitRates(enabled bool) {
+	if enabled {
+		sd.rates = BitRateFormatter
+	} else {
+		sd.rates = DefaultRateFormatter
+	}
+}
+
+// SetRateFormatter sets the units and precision of the traffic screens'
+// rates.
+func (sd *StatusDaemon) SetRateFormatter(f RateFormatter) {
+	sd.rates = f
+}
+
+// formatRate formats a traffic rate in the daemon's units. It is safe to
+// call on a nil daemon, which uses FormatRate's.
+func (sd *StatusDaemon) formatRate(bytesPerSec float64) string {
+	if sd == nil {
+		return FormatRate(bytesPerSec)
+	}
+	return sd.rates.Format(bytesPerSec)
+}
+
+// SetAlertManager feeds every collected metrics sample to am, whose
+// callbacks then run on the metrics goroutine (nil disables alerting).
+func (sd *StatusDaemon) SetAlertManager(am *AlertManager) {
+	sd.alerts = am
+}
+
+// SetBacklightSchedule enables scheduled backlight dimming (nil disables it).
+// The level is applied when Run starts and re-checked every minute.
+func (sd *StatusDaemon) SetBacklightSchedule(s *BacklightSchedule) {
+	sd.schedule = s
+	sd.lastBacklight = -1
+}
+
+// applyBacklightSchedule sets the scheduled level for t if it changed,
+// unless the ambient sensor is in charge.
+func (sd *StatusDaemon) applyBacklightSchedule(t time.Time) {
+	if sd.schedule == nil || sd.ambientOK {
+		return
+	}
+	sd.setBacklight(sd.schedule.LevelAt(t))
+}
+
+// SetBacklightController sets the backlight from an ambient light sensor
+// (nil disables it), read every ambientInterval while Run runs. While
+// readings succeed they override the backlight schedule; once they fail
+// the schedule, if any, takes over again.
+func (sd *StatusDaemon) SetBacklightController(c *BacklightController) {
+	sd.ambient = c
+	sd.ambientOK = false
+}
+
+// ambientInterval is how often Run reads the ambient light sensor.
+const ambientInterval = 5 * time.Second
+
+// applyAmbientBacklight reads the ambient sensor and sets its level.
+func (sd *StatusDaemon) applyAmbientBacklight(now time.Time) {
+	level, err := sd.ambient.Update()
+	if err != nil {
+		if sd.ambientOK {
+			sd.ambientOK = false
+			sd.applyBacklightSchedule(now)
+		}
+		return
+	}
+	sd.ambientOK = true
+	sd.setBacklight(level)
+}
+
+// setBacklight sets the backlight level if it changed. While the
+// screensaver is on the level is kept for wake instead.
+func (sd *StatusDaemon) setBacklight(level byte) {
+	if int(level) == sd.lastBacklight {
+		return
+	}
+	if sd.asleep {
+		sd.wakeBacklight = level
+		sd.lastBacklight = int(level)
+		return
+	}
+	if err := sd.display.SetBacklight(level); err == nil {
+		sd.lastBacklight = int(level)
+	}
+}
+
 // startMetricsCollector runs metrics collection in a background goroutine
 // This decouples metrics fetching from display rendering to prevent blocking
 func (sd *StatusDaemon) startMetricsCollector() {
@@ -131,8 +458,13 @@ func (sd *StatusDaemon) startMetricsCollector() {
 		// Initial fetch
 		sd.fetchMetrics()
 
-		for range ticker.C {
-			sd.fetchMetrics()
+		for {
+			select {
+			case <-ticker.C:
+				sd.fetchMetrics()
+			case <-sd.done:
+				return
+			}
 		}
 	}()
 }
@@ -141,11 +473,27 @@ func (sd *StatusDaemon) startMetricsCollector() {
 func (sd *StatusDaemon) fetchMetrics() {
 	metrics, err := sd.metrics.GetMetrics()
 	if err != nil {
-		return // Silently ignore errors, use cached data
+		// Keep the cached data; render switches to ErrorScreen if this
+		// goes on
+		sd.metricsMu.Lock()
+		if sd.fetchFailures == 0 {
+			sd.failingSince = time.Now()
+		}
+		sd.fetchFailures++
+		sd.fetchErr = err
+		sd.metricsMu.Unlock()
+		return
 	}
 
+	sd.metricsMu.Lock()
 	sd.cachedMetrics = metrics
+	sd.fetchFailures, sd.fetchErr = 0, nil
+	sd.metricsMu.Unlock()
+	sd.checkReboot(metrics.BootTime)
 	sd.history.AddSample(metrics)
+	if sd.alerts != nil {
+		sd.alerts.Feed(metrics)
+	}
 
 	// Build set of current interface names for pruning
 	currentIfaces := make(map[string]bool, len(metrics.Interfaces))
@@ -153,13 +501,42 @@ func (sd *StatusDaemon) fetchMetrics() {
 		currentIfaces[iface.Name] = true
 	}
 
+	// The screens read the per-interface state while it is updated
+	sd.ifaceMu.Lock()
+	defer sd.ifaceMu.Unlock()
+
 	// Prune stale interfaces from maps to prevent unbounded growth
 	for name := range sd.lastIfaceBytes {
 		if !currentIfaces[name] {
 			delete(sd.lastIfaceBytes, name)
 			delete(sd.ifaceRates, name)
+			delete(sd.rawRates, name)
+			delete(sd.ifaceHistory, name)
+		}
+	}
+	for name := range sd.ifaceBaseline {
+		if !currentIfaces[name] {
+			delete(sd.ifaceBaseline, name)
 		}
 	}
+	sd.prunePeaks(currentIfaces)
+
+	// Session totals count from the first sample of each interface, or from
+	// zero once a counter has been reset
+	for _, iface := range metrics.Interfaces {
+		cur := ifaceBytes{tx: iface.TxBytes, rx: iface.RxBytes}
+		base, ok := sd.ifaceBaseline[iface.Name]
+		if !ok {
+			base = cur
+		}
+		if cur.tx < base.tx {
+			base.tx = 0
+		}
+		if cur.rx < base.rx {
+			base.rx = 0
+		}
+		sd.ifaceBaseline[iface.Name] = base
+	}
 
 	// Calculate per-interface rates
 	now := time.Now()
@@ -168,19 +545,45 @@ func (sd *StatusDaemon) fetchMetrics() {
 		if elapsed > 0 {
 			for _, iface := range metrics.Interfaces {
 				if last, ok := sd.lastIfaceBytes[iface.Name]; ok {
-					sd.ifaceRates[iface.Name] = ifaceRate{
-						txRate: float64(iface.TxBytes-last.tx) / elapsed,
-						rxRate: float64(iface.RxBytes-last.rx) / elapsed,
+					r := ifaceRate{
+						txRate: counterRate(iface.TxBytes, last.tx, elapsed),
+						rxRate: counterRate(iface.RxBytes, last.rx, elapsed),
 					}
+					sd.rawRates[iface.Name] = r
+					sd.addIfaceHistory(iface.Name, r.txRate+r.rxRate)
+					if prev, ok := sd.ifaceRates[iface.Name]; ok {
+						sd.ifaceRates[iface.Name] = prev.smooth(r, sd.rateSmoothing)
+					} else {
+						sd.ifaceRates[iface.Name] = r
+					}
+					sd.updatePeak(iface.Name, r)
 				}
 				sd.lastIfaceBytes[iface.Name] = ifaceBytes{tx: iface.TxBytes, rx: iface.RxBytes}
 			}
+			if metrics.PFBlocked >= sd.lastPFBlocked {
+				rate := float64(metrics.PFBlocked-sd.lastPFBlocked) / elapsed
+				sd.metricsMu.Lock()
+				sd.pfBlockRate = rate
+				sd.metricsMu.Unlock()
+			}
 		}
 	}
+	sd.lastPFBlocked = metrics.PFBlocked
 	sd.lastSampleTime = now
 }
 
+// Stop makes Run return and stops the metrics collector. Run returns
+// once it is back in its loop, so not while an OnEnter handler is running.
+func (sd *StatusDaemon) Stop() {
+	sd.stopOnce.Do(func() { close(sd.done) })
+}
+
 func (sd *StatusDaemon) Run() error {
+	// Show a reconnecting screen while the serial port is down
+	if dev := sd.display.Device(); dev != nil {
+		dev.SetStateCallback(sd.connStateChanged)
+	}
+
 	// Start background metrics collection (completely separate from display)
 	sd.startMetricsCollector()
 
@@ -193,29 +596,124 @@ func (sd *StatusDaemon) Run() error {
 	defer animTicker.Stop()
 	defer rotateTicker.Stop()
 
+	// Backlight schedule is checked once a minute (nil channel never fires)
+	var scheduleC <-chan time.Time
+	if sd.schedule != nil {
+		scheduleTicker := time.NewTicker(time.Minute)
+		defer scheduleTicker.Stop()
+		scheduleC = scheduleTicker.C
+		sd.applyBacklightSchedule(time.Now())
+	}
+
+	// The ambient light sensor, if any, is read more often
+	var ambientC <-chan time.Time
+	if sd.ambient != nil {
+		ambientTicker := time.NewTicker(ambientInterval)
+		defer ambientTicker.Stop()
+		ambientC = ambientTicker.C
+		sd.applyAmbientBacklight(time.Now())
+	}
+
+	// Buttons switch screens and reset the screensaver's idle timer
+	var buttonC <-chan eziog500.Button
+	stopButtons := func() {}
+	if sd.buttons != nil {
+		buttonC, stopButtons = sd.buttons.ButtonChannel()
+	}
+	defer func() { stopButtons() }()
+	sd.lastInput = time.Now()
+
+	// Match the frame rate to the current screen
+	setFrameRate := func() {
+		if _, ok := sd.screens[sd.currentScreen].(*LogoScreen); ok {
+			animTicker.Reset(logoInterval)
+		} else {
+			animTicker.Reset(otherInterval)
+		}
+	}
+
 	for {
 		select {
-		case <-animTicker.C:
+		case <-sd.done:
+			return nil
+		case b, ok := <-buttonC:
+			if !ok {
+				buttonC = nil
+				continue
+			}
+			if !sd.handleButton(b, time.Now()) && b == eziog500.ButtonEnter && sd.onEnter != nil {
+				// Hand the buttons over until the handler returns
+				stopButtons()
+				sd.onEnter()
+				buttonC, stopButtons = sd.buttons.ButtonChannel()
+				sd.lastInput = time.Now()
+				sd.display.Invalidate()
+			}
+			setFrameRate()
+			sd.render()
+		case now := <-scheduleC:
+			sd.applyBacklightSchedule(now)
+		case now := <-ambientC:
+			sd.applyAmbientBacklight(now)
+		case now := <-animTicker.C:
+			sd.checkIdle(now)
 			sd.frameCount++
 			sd.render()
-		case <-rotateTicker.C:
-			sd.currentScreen = (sd.currentScreen + 1) % len(sd.screens)
-			// Adjust animation rate based on screen type
-			animTicker.Stop()
-			if sd.currentScreen == 0 { // Logo screen
-				animTicker = time.NewTicker(logoInterval)
-			} else {
-				animTicker = time.NewTicker(otherInterval)
-			}
+		case now := <-rotateTicker.C:
+			sd.rotate(now)
+			setFrameRate()
 		}
 	}
 }
 
+// SetOnConnState sets a function called when the display's serial port
+// fails or is reopened, e.g. to log it. Call it before Run; fn runs on
+// whichever goroutine's I/O noticed the change.
+func (sd *StatusDaemon) SetOnConnState(fn func(state eziog500.ConnState)) {
+	sd.onConnState = fn
+}
+
+// connStateChanged tracks the display's serial connection. It runs on
+// whichever goroutine's I/O noticed the change.
+func (sd *StatusDaemon) connStateChanged(state eziog500.ConnState) {
+	if sd.onConnState != nil {
+		sd.onConnState(state)
+	}
+	switch state {
+	case eziog500.Reconnecting:
+		sd.reconnecting.Store(true)
+	case eziog500.Connected:
+		sd.reconnecting.Store(false)
+		sd.resync.Store(true)
+	}
+}
+
 // render draws the current screen using cached metrics (no blocking I/O)
 func (sd *StatusDaemon) render() error {
-	metrics := sd.cachedMetrics
-	if metrics == nil {
-		return nil // No metrics yet, skip render
+	if sd.reconnecting.Load() {
+		// Each frame retries the port once its backoff has passed
+		return (&ReconnectingScreen{}).Render(sd.display, nil)
+	}
+	resynced := sd.resync.Swap(false)
+	if resynced {
+		// The display may have been power cycled; restore its backlight
+		// and resend the frame
+		sd.lastBacklight = -1
+		sd.applyBacklightSchedule(time.Now())
+		sd.display.Invalidate()
+	}
+
+	metrics, _ := sd.GetMetrics()
+	if sd.asleep {
+		// The LEDs keep reporting while the panel is dark
+		sd.updateLEDs(metrics)
+		if resynced {
+			return sd.blank()
+		}
+		return nil
+	}
+	if s := sd.fallbackScreen(metrics); s != nil {
+		return s.Render(sd.display, metrics)
 	}
 
 	// Update LEDs based on current metrics
@@ -234,98 +732,258 @@ func (sd *StatusDaemon) render() error {
 			s.frame = sd.frameCount
 		case *LANTrafficScreen:
 			s.frame = sd.frameCount
+		case *LeasesScreen:
+			s.frame = sd.frameCount
 		}
 		return sd.screens[sd.currentScreen].Render(sd.display, metrics)
 	}
 	return nil
 }
 
+// fallbackScreen returns the screen to show in place of the rotation:
+// ErrorScreen once errorAfter fetches in a row have failed, or the loading
+// screen before the first metrics. It returns nil when the rotation should
+// be shown.
+func (sd *StatusDaemon) fallbackScreen(m *Metrics) StatusScreen {
+	sd.metricsMu.RLock()
+	failures, since, err := sd.fetchFailures, sd.failingSince, sd.fetchErr
+	sd.metricsMu.RUnlock()
+
+	switch {
+	case failures >= sd.errorAfter:
+		return &ErrorScreen{Err: err, Since: since}
+	case m == nil && sd.loading != nil:
+		return sd.loading
+	case m == nil:
+		return &LoadingScreen{}
+	}
+	return nil
+}
+
+// ErrNoMetrics is returned by StatusDaemon.GetMetrics before the first
+// collection has completed.
+var ErrNoMetrics = errors.New("no metrics collected yet")
+
+// GetMetrics returns the most recently collected metrics without running any
+// commands, so StatusDaemon can serve as a MetricsProvider for other consumers.
+// The returned value is replaced, never modified, by later collections.
+func (sd *StatusDaemon) GetMetrics() (*Metrics, error) {
+	sd.metricsMu.RLock()
+	defer sd.metricsMu.RUnlock()
+	if sd.cachedMetrics == nil {
+		return nil, ErrNoMetrics
+	}
+	return sd.cachedMetrics, nil
+}
+
+// GetIfaceRate returns an interface's rates in bytes per second, smoothed
+// as set by SetRateSmoothing.
 func (sd *StatusDaemon) GetIfaceRate(name string) (tx, rx float64) {
+	sd.ifaceMu.RLock()
+	defer sd.ifaceMu.RUnlock()
 	if r, ok := sd.ifaceRates[name]; ok {
 		return r.txRate, r.rxRate
 	}
 	return 0, 0
 }
 
-// updateLEDs sets LED colors based on system metrics thresholds
+// GetIfaceRawRate returns an interface's rates over the last sample
+// interval, without smoothing.
+func (sd *StatusDaemon) GetIfaceRawRate(name string) (tx, rx float64) {
+	sd.ifaceMu.RLock()
+	defer sd.ifaceMu.RUnlock()
+	if r, ok := sd.rawRates[name]; ok {
+		return r.txRate, r.rxRate
+	}
+	return 0, 0
+}
+
+// ifaceHistoryLen is how many rate samples IfaceHistory keeps per
+// interface, 2 minutes at the 5 second fetch interval.
+const ifaceHistoryLen = 24
+
+// addIfaceHistory appends a rate sample to an interface's history,
+// dropping the oldest once it is full. sd.ifaceMu must be held.
+func (sd *StatusDaemon) addIfaceHistory(name string, rate float64) {
+	h := sd.ifaceHistory[name]
+	if len(h) >= ifaceHistoryLen {
+		copy(h, h[1:])
+		h = h[:ifaceHistoryLen-1]
+	}
+	sd.ifaceHistory[name] = append(h, rate)
+}
+
+// IfaceHistory returns an interface's recent combined tx and rx rates in
+// bytes per second, oldest first, unsmoothed. It is empty until two samples
+// have been taken.
+func (sd *StatusDaemon) IfaceHistory(name string) []float64 {
+	sd.ifaceMu.RLock()
+	defer sd.ifaceMu.RUnlock()
+	return append([]float64(nil), sd.ifaceHistory[name]...)
+}
+
+// drawIfaceSparkline draws an interface's rate history in the given box,
+// once there are enough samples for a line.
+func (sd *StatusDaemon) drawIfaceSparkline(fb *eziog500.FrameBuffer, name string, x, y, w, h int) {
+	if hist := sd.IfaceHistory(name); len(hist) >= 2 {
+		ui.DrawSparklineRange(fb, x, y, w, h, hist, 0, max(1, slices.Max(hist)))
+	}
+}
+
+// GetIfaceSession returns the bytes an interface has sent and received
+// since the daemon started.
+func (sd *StatusDaemon) GetIfaceSession(iface InterfaceMetrics) (tx, rx uint64) {
+	sd.ifaceMu.RLock()
+	base, ok := sd.ifaceBaseline[iface.Name]
+	sd.ifaceMu.RUnlock()
+	if !ok {
+		return 0, 0
+	}
+	s := ifaceBytes{tx: iface.TxBytes, rx: iface.RxBytes}.since(base)
+	return s.tx, s.rx
+}
+
+// PFBlockRate returns the rate of packets blocked by pf, per second.
+func (sd *StatusDaemon) PFBlockRate() float64 {
+	sd.metricsMu.RLock()
+	defer sd.metricsMu.RUnlock()
+	return sd.pfBlockRate
+}
+
+// updateLEDs sets LED colors according to the daemon's LEDPolicy
 func (sd *StatusDaemon) updateLEDs(m *Metrics) {
 	dev := sd.display.Device()
 	if dev == nil {
 		return
 	}
 
-	memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
-
-	// LED1 (top) - Info indicator: shows current screen type
-	// Green = logo/overview, Orange = traffic, Off = other
-	isLogo := sd.currentScreen == 0
-	isTraffic := sd.currentScreen >= 4 && sd.currentScreen <= 6
-	if isLogo {
-		dev.SetLED(eziog500.LED1, eziog500.LEDGreen)
-	} else if isTraffic {
-		dev.SetLED(eziog500.LED1, eziog500.LEDOrange)
-	} else {
-		dev.SetLED(eziog500.LED1, eziog500.LEDOff)
+	var screen StatusScreen
+	if sd.currentScreen < len(sd.screens) {
+		screen = sd.screens[sd.currentScreen]
 	}
-
-	// LED2 (middle) - Health indicator
-	// Green = all good (CPU<70%, MEM<80%)
-	// Orange = warning (CPU 70-90% or MEM 80-90%)
-	// Red = critical (CPU>90% or MEM>90%)
-	if m.CPU > 90 || memPct > 90 {
-		dev.SetLED(eziog500.LED2, eziog500.LEDRed)
-	} else if m.CPU > 70 || memPct > 80 {
-		dev.SetLED(eziog500.LED2, eziog500.LEDOrange)
-	} else {
-		dev.SetLED(eziog500.LED2, eziog500.LEDGreen)
+	colors := sd.ledPolicy.Colors(m, screen, sd.currentScreen == 0)
+	for i, color := range colors {
+		dev.SetLED(eziog500.LED1+eziog500.LED(i), color)
 	}
+}
 
-	// LED3 (bottom) - Home indicator: green on logo screen
-	if isLogo {
-		dev.SetLED(eziog500.LED3, eziog500.LEDGreen)
-	} else {
-		dev.SetLED(eziog500.LED3, eziog500.LEDOff)
-	}
+// ReconnectingScreen is shown by the daemon while the serial port is being
+// reopened. It is not part of the screen rotation and ignores metrics.
+type ReconnectingScreen struct{}
+
+func (s *ReconnectingScreen) Name() string { return "Reconnecting" }
+
+func (s *ReconnectingScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		msg := "RECONNECTING"
+		w := font.MeasureText(f, msg)
+		font.RenderText(fb, f, (eziog500.Width-w)/2, 24, msg)
+
+		sub := "serial port lost"
+		w = font.MeasureText(font.SmallFont, sub)
+		font.RenderText(fb, font.SmallFont, (eziog500.Width-w)/2, 38, sub)
+
+	})
+}
+
+// DefaultErrorAfter is how many metrics fetches in a row must fail before
+// the daemon shows ErrorScreen, 15 seconds at the 5 second fetch interval.
+const DefaultErrorAfter = 3
+
+// LoadingScreen is a splash shown until the daemon's first metrics arrive,
+// which takes a collection interval. Empty fields are left out.
+type LoadingScreen struct {
+	Title    string
+	Subtitle string
+	Logo     *ui.Bitmap
+}
+
+func (s *LoadingScreen) Name() string { return "Loading" }
+
+func (s *LoadingScreen) Render(d *display.Display, m *Metrics) error {
+	return display.Splash(d, s.Title, s.Subtitle, s.Logo)
+}
+
+// ErrorScreen reports that metrics collection keeps failing, with the last
+// error and how long it has been failing. It replaces the other screens so
+// stale numbers aren't mistaken for live ones.
+type ErrorScreen struct {
+	Err   error
+	Since time.Time // First failure of the run
+}
+
+func (s *ErrorScreen) Name() string { return "Error" }
+
+func (s *ErrorScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f, sf := font.BuiltinFont, font.SmallFont
+
+		title := "METRICS ERROR"
+		fb.FillRect(0, 0, eziog500.Width, f.Height()+2, true)
+		font.RenderTextInverted(fb, f, (eziog500.Width-font.MeasureText(f, title))/2, 1, title)
+
+		// As much of the error as fits above the footer
+		msg := "unknown error"
+		if s.Err != nil {
+			msg = s.Err.Error()
+		}
+		y := f.Height() + 4
+		footerY := eziog500.Height - sf.Height()
+		for _, line := range font.WrapText(sf, msg, eziog500.Width-4) {
+			if y+sf.Height() > footerY-2 {
+				break
+			}
+			font.RenderText(fb, sf, 2, y, line)
+			y += sf.LineHeight() + 1
+		}
+
+		if !s.Since.IsZero() {
+			footer := "FAILING FOR " + time.Since(s.Since).Round(time.Second).String()
+			font.RenderText(fb, sf, (eziog500.Width-font.MeasureText(sf, footer))/2, footerY, footer)
+		}
+
+	})
 }
 
 // ========== HELPERS ==========
 
+// scrollText returns a maxLen-character window of text that scrolls with
+// frame. Lengths and positions are counted in runes, not bytes.
 func scrollText(text string, maxLen, frame int) string {
-	if len(text) <= maxLen {
+	runes := []rune(text)
+	if len(runes) <= maxLen {
 		return text
 	}
 
-	// Add spacing for seamless loop
-	padded := text + "    "
-	textLen := len(padded)
-
 	// Scroll speed: every 5 frames (500ms per character) - slower for readability
 	// With a longer pause at the beginning
 	pauseFrames := 20 // Pause for 2 seconds at start
-	cycleLen := textLen*5 + pauseFrames
+	cycleLen := (len(runes)+ui.ScrollGap)*5 + pauseFrames
 
 	adjustedFrame := frame % cycleLen
 
 	// Pause at the beginning before scrolling
 	if adjustedFrame < pauseFrames {
-		return text[:maxLen]
+		return string(runes[:maxLen])
 	}
 
-	// Scroll position
-	pos := (adjustedFrame - pauseFrames) / 5
-	if pos >= textLen {
-		pos = pos % textLen
-	}
+	return ui.ScrollWindow(text, maxLen, (adjustedFrame-pauseFrames)/5)
+}
 
-	// Extract the visible portion, wrapping around
-	result := make([]byte, maxLen)
-	for i := 0; i < maxLen; i++ {
-		idx := (pos + i) % textLen
-		result[i] = padded[idx]
-	}
-	return string(result)
+// barWidget is drawBar as a ui.Widget, for use in layout containers.
+type barWidget struct {
+	w, h int
+	pct  float64
 }
 
+func (b *barWidget) Render(fb *eziog500.FrameBuffer, x, y int) { drawBar(fb, x, y, b.w, b.h, b.pct) }
+func (b *barWidget) Width() int                                { return b.w }
+func (b *barWidget) Height() int                               { return b.h }
+
 func drawBar(fb *eziog500.FrameBuffer, x, y, w, h int, pct float64) {
 	if pct < 0 {
 		pct = 0
@@ -395,30 +1053,114 @@ type LogoScreen struct{ frame int }
 func (s *LogoScreen) Name() string { return "Logo" }
 
 func (s *LogoScreen) Render(disp *display.Display, m *Metrics) error {
-	fb := disp.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
+	return disp.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		// Draw rotating 3D pf logo on left (10Hz animation)
+		draw3DPF(fb, 28, 32, s.frame)
+
+		// Info on right
+		x := 58
+		font.RenderText(fb, f, x, 2, "pfSense")
+		font.RenderText(fb, f, x, 12, scrollText(m.Hostname, 11, s.frame))
+
+		// Live uptime
+		days := int(m.Uptime.Hours() / 24)
+		hours := int(m.Uptime.Hours()) % 24
+		mins := int(m.Uptime.Minutes()) % 60
+		secs := int(m.Uptime.Seconds()) % 60
+		font.RenderText(fb, f, x, 24, fmt.Sprintf("%dd%02d:%02d:%02d", days, hours, mins, secs))
+
+		font.RenderText(fb, f, x, 38, fmt.Sprintf("CPU: %.0f%%", m.CPU))
+		memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
+		font.RenderText(fb, f, x, 48, fmt.Sprintf("MEM: %.0f%%", memPct))
+
+		// Battery in the top right corner, on systems that have one
+		if m.Battery != nil {
+			icon := &ui.BatteryIcon{Charge: m.Battery.Charge, Charging: m.Battery.Charging}
+			icon.Render(fb, 128-icon.Width(), 1)
+		}
 
-	// Draw rotating 3D pf logo on left (10Hz animation)
-	draw3DPF(fb, 28, 32, s.frame)
+	})
+}
+
+// ClockScreen shows the time in large digits with the date and NTP status.
+type ClockScreen struct {
+	daemon *StatusDaemon
+}
 
-	// Info on right
-	x := 58
-	font.RenderText(fb, f, x, 2, "pfSense")
-	font.RenderText(fb, f, x, 12, scrollText(m.Hostname, 11, s.frame))
+func (s *ClockScreen) Name() string { return "Clock" }
 
-	// Live uptime
-	days := int(m.Uptime.Hours() / 24)
-	hours := int(m.Uptime.Hours()) % 24
-	mins := int(m.Uptime.Minutes()) % 60
-	secs := int(m.Uptime.Seconds()) % 60
-	font.RenderText(fb, f, x, 24, fmt.Sprintf("%dd%02d:%02d:%02d", days, hours, mins, secs))
+func (s *ClockScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
 
-	font.RenderText(fb, f, x, 38, fmt.Sprintf("CPU: %.0f%%", m.CPU))
-	memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
-	font.RenderText(fb, f, x, 48, fmt.Sprintf("MEM: %.0f%%", memPct))
+		font.RenderTextInverted(fb, f, 0, 0, " CLOCK ")
 
-	return disp.Update()
+		clock := ui.NewClock(2)
+		clock.Hour12 = s.daemon.clock12h
+		clock.ShowDate = true
+		clock.Render(fb, (128-clock.Width())/2, 16)
+
+		switch m.NTPStatus {
+		case NTPSynced:
+			font.RenderText(fb, font.SmallFont, 0, 58, "NTP SYNCED")
+		case NTPUnsynced:
+			font.RenderText(fb, font.SmallFont, 0, 58, "NTP NOT SYNCED")
+		}
+	})
+}
+
+// DashboardScreen packs the headline numbers into one view: CPU and memory
+// bars, load, uptime, and total traffic.
+type DashboardScreen struct {
+	daemon *StatusDaemon
+}
+
+func (s *DashboardScreen) Name() string { return "Dashboard" }
+
+func (s *DashboardScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " "+font.TruncateText(f, m.Hostname, 100)+" ")
+
+		memPct := 0.0
+		if m.MemTotal > 0 {
+			memPct = float64(m.MemUsed) / float64(m.MemTotal) * 100
+		}
+		small := func(text string) *ui.Label { return &ui.Label{Text: text, Font: sf} }
+		bar := func(pct float64) *barWidget { return &barWidget{w: 84, h: sf.Height(), pct: pct} }
+
+		// Columns line up because every row is one small-font line tall; the bar
+		// leaves room for "100%"
+		usage := ui.NewHBox(4,
+			ui.NewVBox(3, small("CPU"), small("MEM")),
+			ui.NewVBox(3, bar(m.CPU), bar(memPct)),
+			ui.NewVBox(3, small(fmt.Sprintf("%.0f%%", m.CPU)), small(fmt.Sprintf("%.0f%%", memPct))),
+		)
+
+		var tx, rx float64
+		if s.daemon != nil {
+			tx, rx = s.daemon.history.Snapshot().LatestRates()
+		}
+		days := int(m.Uptime.Hours() / 24)
+		hours := int(m.Uptime.Hours()) % 24
+		mins := int(m.Uptime.Minutes()) % 60
+
+		body := ui.NewVBox(3,
+			usage,
+			small(fmt.Sprintf("LOAD %.2f %.2f %.2f", m.LoadAvg[0], m.LoadAvg[1], m.LoadAvg[2])),
+			small(fmt.Sprintf("UP %dD %02d:%02d", days, hours, mins)),
+			small(fmt.Sprintf("TX %s  RX %s", s.daemon.formatRate(tx), s.daemon.formatRate(rx))),
+		)
+		body.Render(fb, 0, 12)
+
+	})
 }
 
 // CPUScreen shows detailed CPU info.
@@ -427,21 +1169,148 @@ type CPUScreen struct{}
 func (s *CPUScreen) Name() string { return "CPU" }
 
 func (s *CPUScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
 
-	font.RenderTextInverted(fb, f, 0, 0, " CPU ")
-	font.RenderText(fb, f, 0, 14, fmt.Sprintf("Usage: %.1f%%", m.CPU))
-	drawBar(fb, 0, 26, 125, 10, m.CPU)
+		font.RenderTextInverted(fb, f, 0, 0, " CPU ")
+		font.RenderText(fb, f, 0, 14, fmt.Sprintf("Usage: %.1f%%", m.CPU))
+		drawBar(fb, 0, 26, 125, 10, m.CPU)
 
-	font.RenderText(fb, f, 0, 42, fmt.Sprintf("Load: %.2f %.2f %.2f", m.LoadAvg[0], m.LoadAvg[1], m.LoadAvg[2]))
+		font.RenderText(fb, f, 0, 42, fmt.Sprintf("Load: %.2f %.2f %.2f", m.LoadAvg[0], m.LoadAvg[1], m.LoadAvg[2]))
 
-	days := int(m.Uptime.Hours() / 24)
-	hours := int(m.Uptime.Hours()) % 24
-	font.RenderText(fb, f, 0, 54, fmt.Sprintf("Uptime: %dd %dh", days, hours))
+		days := int(m.Uptime.Hours() / 24)
+		hours := int(m.Uptime.Hours()) % 24
+		font.RenderText(fb, f, 0, 54, fmt.Sprintf("Uptime: %dd %dh", days, hours))
 
-	return d.Update()
+	})
+}
+
+// CoresScreen shows a usage bar per CPU core.
+type CoresScreen struct{}
+
+func (s *CoresScreen) Name() string { return "CPU Cores" }
+
+// maxCoreBars is the most cores CoresScreen can fit (two columns of eight).
+const maxCoreBars = 16
+
+func (s *CoresScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+
+		font.RenderTextInverted(fb, f, 0, 0, fmt.Sprintf(" CPU CORES (%d) ", len(m.PerCPU)))
+
+		if len(m.PerCPU) == 0 {
+			font.RenderText(fb, f, 10, 30, "No core info")
+			return
+		}
+
+		cores := m.PerCPU
+		if len(cores) > maxCoreBars {
+			cores = cores[:maxCoreBars]
+		}
+
+		// One column with percentages for up to 4 cores, otherwise two columns
+		cols := 1
+		if len(cores) > 4 {
+			cols = 2
+		}
+		rows := (len(cores) + cols - 1) / cols
+		rowH := 54 / rows
+		if rowH > 12 {
+			rowH = 12
+		}
+		barH := rowH - 2
+		colW := 128 / cols
+
+		for i, pct := range cores {
+			x := (i / rows) * colW
+			y := 10 + (i%rows)*rowH
+			label := fmt.Sprintf("%d", i)
+			font.RenderText(fb, sf, x, y+(barH-sf.Height())/2, label)
+
+			barX := x + 10
+			barW := colW - 12
+			if cols == 1 {
+				val := fmt.Sprintf("%.0f%%", pct)
+				font.RenderText(fb, sf, 128-font.MeasureText(sf, val), y+(barH-sf.Height())/2, val)
+				barW = 128 - 10 - 22
+			}
+			drawBar(fb, barX, y, barW, barH, pct)
+		}
+	})
+}
+
+// GraphKind selects the history plotted by a GraphScreen.
+type GraphKind int
+
+const (
+	GraphCPU     GraphKind = iota // CPU usage history
+	GraphTraffic                  // Aggregate Tx/Rx rate history
+)
+
+// GraphScreen plots the daemon's MetricsHistory across the display.
+type GraphScreen struct {
+	Kind   GraphKind
+	daemon *StatusDaemon
+}
+
+func (s *GraphScreen) Name() string {
+	if s.Kind == GraphTraffic {
+		return "Traffic Graph"
+	}
+	return "CPU Graph"
+}
+
+func (s *GraphScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+		h := s.daemon.history.Snapshot()
+
+		if s.Kind == GraphTraffic {
+			font.RenderTextInverted(fb, f, 0, 0, " TRAFFIC HISTORY ")
+			if len(h.Tx) == 0 {
+				font.RenderText(fb, f, 10, 30, "Collecting data")
+				return
+			}
+			tx := ui.Series{Label: "TX", Data: h.Tx, Style: ui.LineSolid}
+			rx := ui.Series{Label: "RX", Data: h.Rx, Style: ui.LineDashed}
+			chart := ui.NewLineChart(128, 44, tx, rx)
+			_, max := chart.Bounds()
+			chart.Min, chart.Max = 0, max
+			chart.Render(fb, 0, 10)
+
+			ui.NewLegend(tx, rx).Render(fb, 0, 58)
+			peak := "PEAK " + s.daemon.formatRate(max)
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, peak), 58, peak)
+			return
+		}
+
+		font.RenderTextInverted(fb, f, 0, 0, " CPU HISTORY ")
+		if len(h.CPU) == 0 {
+			font.RenderText(fb, f, 10, 30, "Collecting data")
+			return
+		}
+		fb.DrawHLine(0, 127, 53, true) // Baseline (0%)
+		ui.DrawSparklineRange(fb, 0, 10, 128, 44, h.CPU, 0, 100)
+
+		min, max := h.CPU[0], h.CPU[0]
+		for _, v := range h.CPU {
+			if v < min {
+				min = v
+			}
+			if v > max {
+				max = v
+			}
+		}
+		font.RenderText(fb, sf, 0, 58, fmt.Sprintf("MIN %.0f%% MAX %.0f%%", min, max))
+		now := fmt.Sprintf("NOW %.0f%%", h.CPU[len(h.CPU)-1])
+		font.RenderText(fb, sf, 128-font.MeasureText(sf, now), 58, now)
+	})
 }
 
 // MemoryScreen shows detailed memory info.
@@ -450,76 +1319,386 @@ type MemoryScreen struct{}
 func (s *MemoryScreen) Name() string { return "Memory" }
 
 func (s *MemoryScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
+		font.RenderTextInverted(fb, f, 0, 0, " MEMORY ")
+
+		if m.SwapTotal > 0 {
+			// Compact layout: one labelled bar each for RAM and swap
+			sf := font.SmallFont
+			swapPct := float64(m.SwapUsed) / float64(m.SwapTotal) * 100
+
+			font.RenderText(fb, f, 0, 12, fmt.Sprintf("RAM %.0f%%", memPct))
+			ramMB := fmt.Sprintf("%d/%dM", m.MemUsed/1024/1024, m.MemTotal/1024/1024)
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, ramMB), 13, ramMB)
+			drawBar(fb, 0, 22, 125, 10, memPct)
+
+			font.RenderText(fb, f, 0, 38, fmt.Sprintf("SWAP %.0f%%", swapPct))
+			swapMB := fmt.Sprintf("%d/%dM", m.SwapUsed/1024/1024, m.SwapTotal/1024/1024)
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, swapMB), 39, swapMB)
+			drawBar(fb, 0, 48, 125, 10, swapPct)
+
+			return
+		}
+
+		font.RenderText(fb, f, 0, 14, fmt.Sprintf("Usage: %.1f%%", memPct))
+		drawBar(fb, 0, 26, 125, 10, memPct)
+
+		usedMB := m.MemUsed / 1024 / 1024
+		totalMB := m.MemTotal / 1024 / 1024
+		freeMB := totalMB - usedMB
+		font.RenderText(fb, f, 0, 42, fmt.Sprintf("Used: %d MB", usedMB))
+		font.RenderText(fb, f, 0, 54, fmt.Sprintf("Free: %d MB", freeMB))
+
+	})
+}
+
+// DiskScreen shows filesystem usage for the root and /var mounts.
+type DiskScreen struct{}
+
+func (s *DiskScreen) Name() string { return "Disk" }
+
+func (s *DiskScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " DISK ")
+
+		y := 12
+		for i, fs := range m.DiskUsage {
+			if i >= 2 {
+				break
+			}
+			font.RenderText(fb, f, 0, y, fs.Mount)
+			usage := fmt.Sprintf("%s/%s", FormatBytes(fs.Used), FormatBytes(fs.Total))
+			font.RenderText(fb, f, 128-font.MeasureText(f, usage), y, usage)
+			drawBar(fb, 0, y+10, 125, 8, fs.UsedPercent())
+			y += 26
+		}
+		if len(m.DiskUsage) == 0 {
+			font.RenderText(fb, f, 10, 30, "No disk info")
+		}
+	})
+}
+
+// TempScreen shows CPU and board temperatures.
+type TempScreen struct{}
+
+func (s *TempScreen) Name() string { return "Temperature" }
+
+func (s *TempScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " TEMPERATURE ")
+
+		if len(m.Temps) == 0 {
+			font.RenderText(fb, f, 10, 30, "No sensors")
+			return
+		}
+
+		max := m.MaxTemp()
+		font.RenderText(fb, f, 0, 14, fmt.Sprintf("Max: %.1f°C", max))
+		drawBar(fb, 0, 26, 125, 8, max)
+
+		// Individual sensors in two columns
+		for i, t := range m.Temps {
+			if i >= 4 {
+				break
+			}
+			x := (i % 2) * 64
+			y := 40 + (i/2)*12
+			font.RenderText(fb, f, x, y, fmt.Sprintf("T%d:%.0f°C", i, t))
+		}
+	})
+}
+
+// ProcessScreen lists the busiest processes by CPU usage.
+type ProcessScreen struct{}
+
+func (s *ProcessScreen) Name() string { return "Processes" }
+
+func (s *ProcessScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " TOP PROCESSES ")
+
+		if len(m.Processes) == 0 {
+			font.RenderText(fb, f, 10, 30, "No process info")
+			return
+		}
+
+		for i, p := range m.Processes {
+			if i >= 4 {
+				break
+			}
+			y := 14 + i*12
+			cpu := fmt.Sprintf("%.1f%%", p.CPU)
+			cpuW := font.MeasureText(f, cpu)
+			name := font.TruncateText(f, p.Name, 128-cpuW-4)
+			font.RenderText(fb, f, 0, y, name)
+			font.RenderText(fb, f, 128-cpuW, y, cpu)
+		}
+	})
+}
+
+// GatewayScreen shows reachability, latency, and loss for each gateway.
+type GatewayScreen struct{}
+
+func (s *GatewayScreen) Name() string { return "Gateways" }
+
+func (s *GatewayScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " GATEWAYS ")
+
+		if len(m.Gateways) == 0 {
+			font.RenderText(fb, f, 10, 30, "No gateways")
+			return
+		}
+
+		// Three gateways fit: name and state, then RTT/loss in the small font
+		for i, gw := range m.Gateways {
+			if i >= 3 {
+				break
+			}
+			y := 12 + i*17
+			state := strings.ToUpper(gw.Status)
+			stateW := font.MeasureText(f, state)
+			font.RenderText(fb, f, 0, y, font.TruncateText(f, gw.Name, 128-stateW-4))
+			if gw.Status == GatewayDown {
+				font.RenderTextInverted(fb, f, 128-stateW, y, state)
+			} else {
+				font.RenderText(fb, f, 128-stateW, y, state)
+			}
 
-	memPct := float64(m.MemUsed) / float64(m.MemTotal) * 100
-	font.RenderTextInverted(fb, f, 0, 0, " MEMORY ")
-	font.RenderText(fb, f, 0, 14, fmt.Sprintf("Usage: %.1f%%", memPct))
-	drawBar(fb, 0, 26, 125, 10, memPct)
+			detail := fmt.Sprintf("RTT %.1fms LOSS %.0f%%", float64(gw.RTT)/float64(time.Millisecond), gw.Loss)
+			font.RenderText(fb, sf, 0, y+9, detail)
+		}
+	})
+}
+
+// FirewallScreen shows pf state table usage and the blocked packet rate.
+type FirewallScreen struct {
+	daemon *StatusDaemon
+}
+
+func (s *FirewallScreen) Name() string { return "Firewall" }
 
-	usedMB := m.MemUsed / 1024 / 1024
-	totalMB := m.MemTotal / 1024 / 1024
-	freeMB := totalMB - usedMB
-	font.RenderText(fb, f, 0, 42, fmt.Sprintf("Used: %d MB", usedMB))
-	font.RenderText(fb, f, 0, 54, fmt.Sprintf("Free: %d MB", freeMB))
+func (s *FirewallScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " FIREWALL ")
+
+		if m.PFStates == 0 && m.PFStateLimit == 0 {
+			font.RenderText(fb, f, 10, 30, "No pf info")
+			return
+		}
+
+		if pct, ok := m.StateUsage(); ok {
+			font.RenderText(fb, f, 0, 12, fmt.Sprintf("States %.0f%%", pct))
+			counts := fmt.Sprintf("%d/%d", m.PFStates, m.PFStateLimit)
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, counts), 13, counts)
+			drawBar(fb, 0, 22, 125, 10, pct)
+		} else {
+			font.RenderText(fb, f, 0, 12, fmt.Sprintf("States: %d", m.PFStates))
+		}
+
+		rate := 0.0
+		if s.daemon != nil {
+			rate = s.daemon.PFBlockRate()
+		}
+		font.RenderText(fb, f, 0, 38, fmt.Sprintf("Blocked: %.1f/s", rate))
+		font.RenderText(fb, sf, 0, 50, fmt.Sprintf("TOTAL BLOCKED %d", m.PFBlocked))
+		font.RenderText(fb, sf, 0, 57, fmt.Sprintf("RULE MATCHES %d", m.PFMatches))
 
-	return d.Update()
+	})
+}
+
+// ServiceScreen shows a check or cross for each monitored daemon, or the
+// icons chosen with StatusDaemon.SetServiceIcons.
+type ServiceScreen struct {
+	daemon *StatusDaemon
 }
 
-// InterfaceScreen shows active interfaces with IPs.
+func (s *ServiceScreen) Name() string { return "Services" }
+
+func (s *ServiceScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " SERVICES ")
+
+		if len(m.Services) == 0 {
+			font.RenderText(fb, f, 10, 30, "No service info")
+			return
+		}
+
+		// Five rows; a second column when there are more services
+		const rows = 5
+		colW := 128
+		if len(m.Services) > rows {
+			colW = 64
+		}
+		for i, svc := range m.Services {
+			if i >= 2*rows {
+				break
+			}
+			x, y := (i/rows)*colW, 11+(i%rows)*10
+			running, stopped := ui.IconCheck, ui.IconX
+			if s.daemon != nil {
+				running, stopped = s.daemon.serviceIcons[0], s.daemon.serviceIcons[1]
+			}
+			icon := stopped
+			if svc.Running {
+				icon = running
+			}
+			icon.Render(fb, x, y)
+			font.RenderText(fb, f, x+10, y, font.TruncateText(f, svc.Name, colW-12))
+		}
+	})
+}
+
+// LeasesScreen lists active DHCP leases by address and hostname, cycling
+// through them when there are more than fit.
+type LeasesScreen struct {
+	frame int
+}
+
+func (s *LeasesScreen) Name() string { return "DHCP Leases" }
+
+func (s *LeasesScreen) Render(d *display.Display, m *Metrics) error {
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+		sf := font.SmallFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " DHCP LEASES ")
+
+		total := len(m.DHCPLeases)
+		if total == 0 {
+			font.RenderText(fb, f, 10, 30, "No active leases")
+			return
+		}
+
+		count := fmt.Sprintf("%d", total)
+		font.RenderText(fb, sf, 128-font.MeasureText(sf, count), 1, count)
+
+		maxVis := 5
+		scrollPos := 0
+		if total > maxVis {
+			scrollPos = (s.frame / 15) % total
+		}
+
+		y := 11
+		for i := 0; i < maxVis && i < total; i++ {
+			lease := m.DHCPLeases[(scrollPos+i)%total]
+			ipEnd := font.RenderText(fb, f, 0, y, lease.IP)
+			name := lease.Hostname
+			if name == "" {
+				name = lease.MAC
+			}
+			if avail := 128 - ipEnd - 4; avail > 0 && name != "" {
+				name = font.TruncateText(sf, name, avail)
+				font.RenderText(fb, sf, 128-font.MeasureText(sf, name), y+1, name)
+			}
+			y += 10
+		}
+	})
+}
+
+// InterfaceScreen shows active interfaces with IPs. With ShowDown it lists
+// every interface, marking the ones that are down. Setting Font to
+// font.SmallFont fits more rows.
 type InterfaceScreen struct {
+	ShowDown  bool
+	Font      font.Font // nil uses the display's font
 	frame     int
 	scrollPos int
 }
 
-func (s *InterfaceScreen) Name() string { return "Interfaces" }
-
-func (s *InterfaceScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
-
-	font.RenderTextInverted(fb, f, 0, 0, " INTERFACES ")
+func (s *InterfaceScreen) Name() string {
+	if s.ShowDown {
+		return "All Interfaces"
+	}
+	return "Interfaces"
+}
 
-	var active []InterfaceMetrics
+// visible returns the interfaces the screen lists, busiest first.
+func (s *InterfaceScreen) visible(m *Metrics) []InterfaceMetrics {
+	var shown []InterfaceMetrics
 	for _, iface := range m.Interfaces {
-		if iface.IP != "" && iface.Status == "active" {
-			active = append(active, iface)
+		if s.ShowDown || (iface.IP != "" && iface.Status == InterfaceUp) {
+			shown = append(shown, iface)
 		}
 	}
 	// Sort by total traffic (highest first)
-	sort.Slice(active, func(i, j int) bool {
-		return (active[i].TxBytes + active[i].RxBytes) > (active[j].TxBytes + active[j].RxBytes)
+	sort.SliceStable(shown, func(i, j int) bool {
+		return (shown[i].TxBytes + shown[i].RxBytes) > (shown[j].TxBytes + shown[j].RxBytes)
 	})
+	return shown
+}
 
-	maxVis := 5
-	total := len(active)
-	if total > maxVis {
-		s.scrollPos = (s.frame / 15) % total
+func (s *InterfaceScreen) Render(d *display.Display, m *Metrics) error {
+	f := s.Font
+	if f == nil {
+		f = d.Font()
 	}
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
 
-	y := 11
-	for i := 0; i < maxVis && i < total; i++ {
-		idx := (s.scrollPos + i) % total
-		iface := active[idx]
-		name := iface.Description
-		if name == "" {
-			name = iface.Name
+		font.RenderTextInverted(fb, f, 0, 0, " INTERFACES ")
+
+		active := s.visible(m)
+		top, rowH := f.Height()+3, f.Height()+2
+		maxVis := (eziog500.Height - top) / rowH
+		total := len(active)
+		if total > maxVis {
+			s.scrollPos = (s.frame / 15) % total
 		}
-		font.RenderText(fb, f, 0, y, scrollText(name, 8, s.frame))
-		font.RenderText(fb, f, 55, y, iface.IP)
-		y += 10
-	}
 
-	if total > maxVis {
-		font.RenderText(fb, f, 110, 55, fmt.Sprintf("+%d", total-maxVis))
-	}
-	if total == 0 {
-		font.RenderText(fb, f, 10, 30, "No active ifaces")
-	}
-	return d.Update()
+		y := top
+		for i := 0; i < maxVis && i < total; i++ {
+			idx := (s.scrollPos + i) % total
+			iface := active[idx]
+			font.RenderText(fb, f, 0, y, scrollText(iface.Label(), 8, s.frame))
+			switch {
+			case iface.Status != InterfaceUp:
+				font.RenderText(fb, f, 55, y, "down")
+			case iface.IP == "":
+				font.RenderText(fb, f, 55, y, "no IP")
+			default:
+				font.RenderText(fb, f, 55, y, iface.IP)
+			}
+			y += rowH
+		}
+
+		if total > maxVis {
+			more := fmt.Sprintf("+%d", total-maxVis)
+			font.RenderText(fb, f, eziog500.Width-font.MeasureText(f, more), eziog500.Height-f.Height()-1, more)
+		}
+		if total == 0 {
+			if s.ShowDown {
+				font.RenderText(fb, f, 10, 30, "No interfaces")
+			} else {
+				font.RenderText(fb, f, 10, 30, "No active ifaces")
+			}
+		}
+	})
 }
 
 // WANTrafficScreen shows WAN interface traffic.
@@ -531,31 +1710,62 @@ type WANTrafficScreen struct {
 func (s *WANTrafficScreen) Name() string { return "WAN Traffic" }
 
 func (s *WANTrafficScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
-
-	font.RenderTextInverted(fb, f, 0, 0, " WAN TRAFFIC ")
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		headerEnd := font.RenderTextInverted(fb, f, 0, 0, " WAN TRAFFIC ")
+		if m.PublicIP != "" {
+			sf := font.SmallFont
+			ip := font.TruncateText(sf, m.PublicIP, 128-headerEnd-2)
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, ip), 1, ip)
+		}
 
-	y := 12
-	count := 0
-	for _, iface := range m.Interfaces {
-		if iface.Description == "WAN" || strings.HasPrefix(iface.Description, "WAN") {
-			if count >= 4 {
+		// Each WAN shows its live rates beside their peaks, then totals since
+		// boot and since the daemon started; two fit on screen
+		y := 12
+		sf := font.SmallFont
+		wans := s.daemon.classifier.Filter(m.Interfaces, CategoryWAN)
+		for i, iface := range wans {
+			if i >= 2 {
 				break
 			}
 			tx, rx := s.daemon.GetIfaceRate(iface.Name)
-			name := scrollText(iface.Description, 10, s.frame)
+			peakTx, peakRx := s.daemon.PeakRate(iface.Name)
+			name := scrollText(iface.Label(), 10, s.frame)
 			font.RenderText(fb, f, 0, y, name)
-			font.RenderText(fb, f, 0, y+10, fmt.Sprintf("  TX:%s RX:%s", FormatRate(tx), FormatRate(rx)))
-			y += 24
-			count++
+			s.daemon.drawIfaceSparkline(fb, iface.Name, 64, y, 24, 7)
+			drawErrorCount(fb, y+1, iface.ErrorCount())
+			end := s.daemon.drawRate(fb, 0, y+9, "T", tx, peakTx)
+			s.daemon.drawRate(fb, max(64, end+4), y+9, "R", rx, peakRx)
+
+			font.RenderText(fb, sf, 0, y+18, fmt.Sprintf("TOT %s/%s", compactBytes(iface.TxBytes), compactBytes(iface.RxBytes)))
+			sessTx, sessRx := s.daemon.GetIfaceSession(iface)
+			session := fmt.Sprintf("SES %s/%s", compactBytes(sessTx), compactBytes(sessRx))
+			font.RenderText(fb, sf, 128-font.MeasureText(sf, session), y+18, session)
+			y += 25
 		}
-	}
+		if len(wans) == 0 {
+			font.RenderText(fb, f, 10, 30, "No WAN interfaces")
+		}
+	})
+}
+
+// compactBytes is FormatBytes cut down for tight small-font rows, e.g.
+// "1.5G" instead of "1.5 GB".
+func compactBytes(b uint64) string {
+	return strings.NewReplacer(" ", "", "B", "").Replace(FormatBytes(b))
+}
+
+// drawErrorCount right-aligns a small "err:N" annotation at y. Nothing is
+// drawn when the count is zero.
+func drawErrorCount(fb *eziog500.FrameBuffer, y int, count uint64) {
 	if count == 0 {
-		font.RenderText(fb, f, 10, 30, "No WAN interfaces")
+		return
 	}
-	return d.Update()
+	sf := font.SmallFont
+	text := fmt.Sprintf("err:%d", count)
+	font.RenderText(fb, sf, 128-font.MeasureText(sf, text), y, text)
 }
 
 // TunnelTrafficScreen shows VPN/tunnel traffic.
@@ -568,53 +1778,40 @@ type TunnelTrafficScreen struct {
 func (s *TunnelTrafficScreen) Name() string { return "Tunnel Traffic" }
 
 func (s *TunnelTrafficScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
-
-	font.RenderTextInverted(fb, f, 0, 0, " TUNNEL TRAFFIC ")
-
-	var tunnels []InterfaceMetrics
-	for _, iface := range m.Interfaces {
-		if strings.HasPrefix(iface.Name, "tun_wg") ||
-			strings.HasPrefix(iface.Description, "GW_") ||
-			strings.HasPrefix(iface.Description, "WG_") ||
-			strings.HasPrefix(iface.Description, "MULLVAD") {
-			tunnels = append(tunnels, iface)
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " TUNNEL TRAFFIC ")
+
+		tunnels := s.daemon.classifier.Filter(m.Interfaces, CategoryTunnel)
+		// Sort by total traffic (highest first)
+		sort.Slice(tunnels, func(i, j int) bool {
+			return (tunnels[i].TxBytes + tunnels[i].RxBytes) > (tunnels[j].TxBytes + tunnels[j].RxBytes)
+		})
+
+		maxVis := 5
+		total := len(tunnels)
+		if total > maxVis {
+			s.scrollPos = (s.frame / 15) % total
 		}
-	}
-	// Sort by total traffic (highest first)
-	sort.Slice(tunnels, func(i, j int) bool {
-		return (tunnels[i].TxBytes + tunnels[i].RxBytes) > (tunnels[j].TxBytes + tunnels[j].RxBytes)
-	})
-
-	maxVis := 5
-	total := len(tunnels)
-	if total > maxVis {
-		s.scrollPos = (s.frame / 15) % total
-	}
 
-	y := 11
-	for i := 0; i < maxVis && i < total; i++ {
-		idx := (s.scrollPos + i) % total
-		iface := tunnels[idx]
-		name := iface.Description
-		if name == "" {
-			name = iface.Name
+		y := 11
+		for i := 0; i < maxVis && i < total; i++ {
+			idx := (s.scrollPos + i) % total
+			iface := tunnels[idx]
+			font.RenderTextClipped(fb, f, 0, y, 50, iface.Label())
+			s.daemon.drawRateRow(fb, 52, y, iface.Name)
+			y += 10
 		}
-		tx, rx := s.daemon.GetIfaceRate(iface.Name)
-		font.RenderText(fb, f, 0, y, scrollText(name, 8, s.frame))
-		font.RenderText(fb, f, 52, y, fmt.Sprintf("T%s R%s", FormatRate(tx), FormatRate(rx)))
-		y += 10
-	}
 
-	if total > maxVis {
-		font.RenderText(fb, f, 110, 55, fmt.Sprintf("+%d", total-maxVis))
-	}
-	if total == 0 {
-		font.RenderText(fb, f, 15, 30, "No tunnels")
-	}
-	return d.Update()
+		if total > maxVis {
+			font.RenderText(fb, f, 110, 55, fmt.Sprintf("+%d", total-maxVis))
+		}
+		if total == 0 {
+			font.RenderText(fb, f, 15, 30, "No tunnels")
+		}
+	})
 }
 
 // LANTrafficScreen shows LAN/other interface traffic.
@@ -627,55 +1824,46 @@ type LANTrafficScreen struct {
 func (s *LANTrafficScreen) Name() string { return "LAN Traffic" }
 
 func (s *LANTrafficScreen) Render(d *display.Display, m *Metrics) error {
-	fb := d.FrameBuffer()
-	fb.Clear()
-	f := font.BuiltinFont
+	return d.DrawFrame(func(fb *eziog500.FrameBuffer) {
+		fb.Clear()
+		f := font.BuiltinFont
+
+		font.RenderTextInverted(fb, f, 0, 0, " LAN TRAFFIC ")
+
+		lans := s.daemon.classifier.Filter(m.Interfaces, CategoryLAN)
+		// Sort by total traffic (highest first)
+		sort.Slice(lans, func(i, j int) bool {
+			return (lans[i].TxBytes + lans[i].RxBytes) > (lans[j].TxBytes + lans[j].RxBytes)
+		})
+
+		// Rows are too tight for per-interface counts; total them in the header
+		var errs uint64
+		for _, iface := range lans {
+			errs += iface.ErrorCount()
+		}
+		drawErrorCount(fb, 1, errs)
 
-	font.RenderTextInverted(fb, f, 0, 0, " LAN TRAFFIC ")
+		maxVis := 5
+		total := len(lans)
+		if total > maxVis {
+			s.scrollPos = (s.frame / 25) % total
+		}
 
-	var lans []InterfaceMetrics
-	for _, iface := range m.Interfaces {
-		if iface.Description == "" {
-			continue
+		y := 11
+		for i := 0; i < maxVis && i < total; i++ {
+			idx := (s.scrollPos + i) % total
+			iface := lans[idx]
+			font.RenderTextClipped(fb, f, 0, y, 32, iface.Label())
+			s.daemon.drawIfaceSparkline(fb, iface.Name, 34, y, 16, 7)
+			s.daemon.drawRateRow(fb, 52, y, iface.Name)
+			y += 10
 		}
-		// Exclude WAN and tunnels
-		if iface.Description == "WAN" || strings.HasPrefix(iface.Description, "WAN") {
-			continue
+
+		if total > maxVis {
+			font.RenderText(fb, f, 110, 55, fmt.Sprintf("+%d", total-maxVis))
 		}
-		if strings.HasPrefix(iface.Name, "tun_wg") ||
-			strings.HasPrefix(iface.Description, "GW_") ||
-			strings.HasPrefix(iface.Description, "WG_") ||
-			strings.HasPrefix(iface.Description, "MULLVAD") {
-			continue
+		if total == 0 {
+			font.RenderText(fb, f, 10, 30, "No LAN interfaces")
 		}
-		lans = append(lans, iface)
-	}
-	// Sort by total traffic (highest first)
-	sort.Slice(lans, func(i, j int) bool {
-		return (lans[i].TxBytes + lans[i].RxBytes) > (lans[j].TxBytes + lans[j].RxBytes)
 	})
-
-	maxVis := 5
-	total := len(lans)
-	if total > maxVis {
-		s.scrollPos = (s.frame / 25) % total
-	}
-
-	y := 11
-	for i := 0; i < maxVis && i < total; i++ {
-		idx := (s.scrollPos + i) % total
-		iface := lans[idx]
-		tx, rx := s.daemon.GetIfaceRate(iface.Name)
-		font.RenderText(fb, f, 0, y, scrollText(iface.Description, 8, s.frame))
-		font.RenderText(fb, f, 52, y, fmt.Sprintf("T%s R%s", FormatRate(tx), FormatRate(rx)))
-		y += 10
-	}
-
-	if total > maxVis {
-		font.RenderText(fb, f, 110, 55, fmt.Sprintf("+%d", total-maxVis))
-	}
-	if total == 0 {
-		font.RenderText(fb, f, 10, 30, "No LAN interfaces")
-	}
-	return d.Update()
 }
@@ -0,0 +1,651 @@
+package pfsense
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+func TestGraphScreen_EmptyHistory(t *testing.T) {
+	d, written := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	for _, kind := range []GraphKind{GraphCPU, GraphTraffic} {
+		s := &GraphScreen{Kind: kind, daemon: daemon}
+		if err := s.Render(d, &Metrics{}); err != nil {
+			t.Fatalf("%s: render failed: %v", s.Name(), err)
+		}
+	}
+	if len(written()) == 0 {
+		t.Error("Expected frames to be uploaded")
+	}
+}
+
+func TestGraphScreen_CPUHistory(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.history.CPUHistory = []float64{0, 100}
+
+	s := &GraphScreen{Kind: GraphCPU, daemon: daemon}
+	if err := s.Render(d, &Metrics{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// 0% sits on the baseline at the left, 100% at the top of the chart on the right
+	fb := d.FrameBuffer()
+	if !fb.GetPixel(0, 53) || !fb.GetPixel(127, 10) {
+		t.Error("CPU samples should map to the chart's bottom and top rows")
+	}
+}
+
+func TestGraphScreen_SingleTrafficSample(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.history.TxRateHistory = []float64{1024}
+	daemon.history.RxRateHistory = []float64{512}
+
+	s := &GraphScreen{Kind: GraphTraffic, daemon: daemon}
+	if err := s.Render(d, &Metrics{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestClockScreen_Render(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetClock12Hour(true)
+
+	s := &ClockScreen{daemon: daemon}
+	if err := s.Render(d, &Metrics{NTPStatus: NTPSynced}); err != nil {
+		t.Fatal(err)
+	}
+	if d.FrameBuffer().CountSetPixels() == 0 {
+		t.Error("Expected the clock to draw")
+	}
+}
+
+func TestCoresScreen_Render(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	s := &CoresScreen{}
+
+	for _, n := range []int{0, 2, 8, 24} {
+		m := &Metrics{PerCPU: make([]float64, n)}
+		for i := range m.PerCPU {
+			m.PerCPU[i] = float64(i * 10 % 100)
+		}
+		if err := s.Render(d, m); err != nil {
+			t.Fatalf("%d cores: render failed: %v", n, err)
+		}
+	}
+}
+
+func TestMemoryScreen_SwapBar(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	s := &MemoryScreen{}
+	m := &Metrics{MemUsed: 512 << 20, MemTotal: 1024 << 20}
+
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	noSwap := d.FrameBuffer().ToDeviceFormat()
+
+	m.SwapUsed, m.SwapTotal = 256<<20, 1024<<20
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if d.FrameBuffer().ToDeviceFormat() == noSwap {
+		t.Error("Expected the swap bar to change the layout")
+	}
+}
+
+func TestLeasesScreen_Render(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	s := &LeasesScreen{}
+
+	m := &Metrics{}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	for i := 0; i < 7; i++ {
+		m.DHCPLeases = append(m.DHCPLeases, DHCPLease{
+			IP:       fmt.Sprintf("192.168.100.%d", 200+i),
+			Hostname: "a-very-long-client-hostname",
+		})
+	}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	first := d.FrameBuffer().ToDeviceFormat()
+
+	// More leases than fit: the list cycles
+	s.frame = 15
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if d.FrameBuffer().ToDeviceFormat() == first {
+		t.Error("Expected the lease list to scroll")
+	}
+}
+
+func TestScrollText_Unicode(t *testing.T) {
+	text := "Café Réseau Ñandú" // 17 runes, more bytes
+
+	// Short text is returned unchanged
+	if got := scrollText("Café", 8, 100); got != "Café" {
+		t.Errorf("short text = %q, want %q", got, "Café")
+	}
+
+	// During the initial pause the window is the first maxLen runes
+	if got := scrollText(text, 8, 0); got != "Café Rés" {
+		t.Errorf("paused window = %q, want %q", got, "Café Rés")
+	}
+
+	padded := []rune(text + "    ")
+	for step := 0; step < len(padded); step++ {
+		frame := 20 + step*5
+		got := scrollText(text, 8, frame)
+		if !utf8.ValidString(got) {
+			t.Fatalf("frame %d: invalid UTF-8 %q", frame, got)
+		}
+		runes := []rune(got)
+		if len(runes) != 8 {
+			t.Fatalf("frame %d: got %d runes, want 8", frame, len(runes))
+		}
+		// Each step advances the window by exactly one rune
+		if want := padded[step]; runes[0] != want {
+			t.Errorf("frame %d: window starts with %q, want %q", frame, runes[0], want)
+		}
+	}
+}
+
+func TestStatusDaemon_ReconnectingScreen(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetLoadingScreen(nil)
+	var states []eziog500.ConnState
+	daemon.SetOnConnState(func(state eziog500.ConnState) { states = append(states, state) })
+
+	// Without metrics render draws nothing, unless the port is down
+	daemon.connStateChanged(eziog500.Reconnecting)
+	if err := daemon.render(); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if d.FrameBuffer().CountSetPixels() == 0 {
+		t.Error("Expected the reconnecting screen to be drawn")
+	}
+
+	daemon.connStateChanged(eziog500.Connected)
+	if daemon.reconnecting.Load() {
+		t.Error("Expected reconnecting to clear once connected")
+	}
+	if len(states) != 2 || states[0] != eziog500.Reconnecting || states[1] != eziog500.Connected {
+		t.Errorf("OnConnState got %v, want [reconnecting connected]", states)
+	}
+}
+
+func TestStatusDaemon_FallbackScreens(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.SetErrorThreshold(3)
+	provider := &staticProvider{err: errors.New("sysctl failed")}
+	daemon.metrics = provider
+
+	// Before the first metrics the loading screen is drawn
+	if _, ok := daemon.fallbackScreen(nil).(*LoadingScreen); !ok {
+		t.Fatal("Expected the loading screen before any metrics")
+	}
+	if err := daemon.render(); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if d.FrameBuffer().CountSetPixels() == 0 {
+		t.Error("Expected the loading screen to be drawn")
+	}
+
+	// Failures below the threshold keep showing the last metrics
+	provider.m, provider.err = testMetrics(), nil
+	daemon.fetchMetrics()
+	provider.m, provider.err = nil, errors.New("sysctl failed")
+	for i := 1; i < 3; i++ {
+		daemon.fetchMetrics()
+		m, _ := daemon.GetMetrics()
+		if s := daemon.fallbackScreen(m); s != nil {
+			t.Fatalf("After %d failures got %s, want the rotation", i, s.Name())
+		}
+	}
+
+	daemon.fetchMetrics()
+	m, _ := daemon.GetMetrics()
+	s, ok := daemon.fallbackScreen(m).(*ErrorScreen)
+	if !ok {
+		t.Fatal("Expected the error screen after 3 failures")
+	}
+	if s.Err == nil || s.Err.Error() != "sysctl failed" || s.Since.IsZero() {
+		t.Errorf("Got error screen %+v, want the last error and when failing began", s)
+	}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	// One success clears it
+	provider.m, provider.err = testMetrics(), nil
+	daemon.fetchMetrics()
+	m, _ = daemon.GetMetrics()
+	if s := daemon.fallbackScreen(m); s != nil {
+		t.Errorf("Got %s after a successful fetch, want the rotation", s.Name())
+	}
+}
+
+func TestStatusDaemon_OnReboot(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	var reboots []time.Time
+	daemon.SetOnReboot(func(boot time.Time) { reboots = append(reboots, boot) })
+
+	first := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	second := first.Add(36 * time.Hour)
+	provider := &staticProvider{}
+	daemon.metrics = provider
+	for _, boot := range []time.Time{
+		first,
+		first,
+		first.Add(2 * time.Second), // Clock adjustment, not a reboot
+		{},                         // Unknown
+		second,
+		second,
+	} {
+		provider.m = &Metrics{BootTime: &boot}
+		daemon.fetchMetrics()
+	}
+	provider.m = &Metrics{} // Nil boot time
+	daemon.fetchMetrics()
+
+	if len(reboots) != 1 || !reboots[0].Equal(second) {
+		t.Errorf("Got reboots %v, want one at %v", reboots, second)
+	}
+}
+
+func TestStatusDaemon_BootStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "boottime")
+	first := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	second := first.Add(36 * time.Hour)
+
+	// Each run is a new daemon, as after a reboot
+	var reboots []time.Time
+	for _, boot := range []time.Time{first, first, second} {
+		d, _ := testutil.NewDisplay(t)
+		daemon := NewStatusDaemon(d, 0, 0)
+		if err := daemon.SetBootStateFile(path); err != nil {
+			t.Fatal(err)
+		}
+		daemon.SetOnReboot(func(boot time.Time) { reboots = append(reboots, boot) })
+		daemon.metrics = &staticProvider{m: &Metrics{BootTime: &boot}}
+		daemon.fetchMetrics()
+	}
+
+	if len(reboots) != 1 || !reboots[0].Equal(second) {
+		t.Errorf("Got reboots %v, want one at %v", reboots, second)
+	}
+
+	if err := os.WriteFile(path, []byte("garbage\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	d, _ := testutil.NewDisplay(t)
+	if err := NewStatusDaemon(d, 0, 0).SetBootStateFile(path); err == nil {
+		t.Error("Expected an error for a malformed state file")
+	}
+
+	// Write failures are reported rather than dropped
+	daemon := NewStatusDaemon(d, 0, 0)
+	if err := daemon.SetBootStateFile(filepath.Join(t.TempDir(), "missing", "boottime")); err != nil {
+		t.Fatal(err)
+	}
+	var writeErr error
+	daemon.SetOnBootStateError(func(err error) { writeErr = err })
+	daemon.metrics = &staticProvider{m: &Metrics{BootTime: &first}}
+	daemon.fetchMetrics()
+	if writeErr == nil {
+		t.Error("Expected the failed boot state write to be reported")
+	}
+}
+
+func TestStatusDaemon_RateSmoothing(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	if err := daemon.SetRateSmoothing(0); err == nil {
+		t.Error("Expected an error for zero smoothing")
+	}
+	if err := daemon.SetRateSmoothing(0.5); err != nil {
+		t.Fatal(err)
+	}
+
+	// Steady at 1000 B/s, then a step to 5000 B/s; each sample is a
+	// second apart
+	var tx uint64
+	provider := &staticProvider{}
+	daemon.metrics = provider
+	sample := func(rate uint64) {
+		tx += rate
+		provider.m = &Metrics{Interfaces: []InterfaceMetrics{{Name: "em0", TxBytes: tx}}}
+		daemon.lastSampleTime = time.Now().Add(-time.Second)
+		daemon.fetchMetrics()
+	}
+	for i := 0; i < 3; i++ {
+		sample(1000)
+	}
+	if got, _ := daemon.GetIfaceRate("em0"); math.Abs(got-1000) > 50 {
+		t.Fatalf("Got steady rate %.0f, want 1000", got)
+	}
+
+	last := 1000.0
+	for i := 0; i < 5; i++ {
+		sample(5000)
+		got, _ := daemon.GetIfaceRate("em0")
+		if got <= last || got > 5000*1.05 {
+			t.Errorf("Sample %d: smoothed rate %.0f, want rising from %.0f towards 5000", i, got, last)
+		}
+		last = got
+		if raw, _ := daemon.GetIfaceRawRate("em0"); math.Abs(raw-5000) > 250 {
+			t.Errorf("Sample %d: raw rate %.0f, want 5000", i, raw)
+		}
+	}
+	if math.Abs(last-5000) > 250 {
+		t.Errorf("Smoothed rate %.0f after 5 samples, want close to 5000", last)
+	}
+	if peak, _ := daemon.PeakRate("em0"); peak < 4750 {
+		t.Errorf("Peak %.0f, want the raw 5000", peak)
+	}
+}
+
+func TestStatusDaemon_IfaceHistory(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	provider := &staticProvider{}
+	daemon.metrics = provider
+
+	var em0, em1 uint64
+	sample := func(ifaces ...string) {
+		m := &Metrics{}
+		for _, name := range ifaces {
+			switch name {
+			case "em0":
+				em0 += 1000
+				m.Interfaces = append(m.Interfaces, InterfaceMetrics{Name: name, RxBytes: em0})
+			case "em1":
+				em1 += 500
+				m.Interfaces = append(m.Interfaces, InterfaceMetrics{Name: name, TxBytes: em1})
+			}
+		}
+		provider.m = m
+		daemon.lastSampleTime = time.Now().Add(-time.Second)
+		daemon.fetchMetrics()
+	}
+
+	sample("em0", "em1")
+	if h := daemon.IfaceHistory("em0"); len(h) != 0 {
+		t.Errorf("Got %d history samples after one fetch, want none", len(h))
+	}
+	for i := 0; i < ifaceHistoryLen+10; i++ {
+		sample("em0", "em1")
+	}
+	h := daemon.IfaceHistory("em0")
+	if len(h) != ifaceHistoryLen {
+		t.Fatalf("Got %d history samples, want it bounded at %d", len(h), ifaceHistoryLen)
+	}
+	if math.Abs(h[len(h)-1]-1000) > 50 {
+		t.Errorf("Latest em0 sample %.0f, want 1000", h[len(h)-1])
+	}
+
+	// em1 goes away and its history with it
+	sample("em0")
+	if h := daemon.IfaceHistory("em1"); len(h) != 0 {
+		t.Errorf("Got %d history samples for a removed interface, want none", len(h))
+	}
+	if _, ok := daemon.ifaceHistory["em1"]; ok {
+		t.Error("Expected the removed interface's history to be pruned")
+	}
+}
+
+func TestDashboardScreen_Render(t *testing.T) {
+	d, written := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.history.TxRateHistory = []float64{1024, 2 << 20}
+	daemon.history.RxRateHistory = []float64{512, 300 << 10}
+
+	s := &DashboardScreen{daemon: daemon}
+	m := &Metrics{
+		Hostname: "pfsense.home.arpa",
+		CPU:      42,
+		MemUsed:  768 << 20,
+		MemTotal: 1024 << 20,
+		LoadAvg:  [3]float64{0.5, 0.25, 0.1},
+		Uptime:   50 * time.Hour,
+	}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	if d.FrameBuffer().CountSetPixels() == 0 {
+		t.Error("Expected the dashboard to draw")
+	}
+	if got := written(); !bytes.Contains(got, []byte{0x1B, 'G'}) {
+		t.Error("Expected an image upload to the device")
+	}
+}
+
+func TestStatusDaemon_SessionTotals(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	provider := &staticProvider{m: &Metrics{Interfaces: []InterfaceMetrics{
+		{Name: "igb0", Description: "WAN", TxBytes: 1000, RxBytes: 5000},
+	}}}
+	daemon.metrics = provider
+	daemon.fetchMetrics()
+
+	session := func(tx, rx uint64) (uint64, uint64) {
+		provider.m = &Metrics{Interfaces: []InterfaceMetrics{
+			{Name: "igb0", Description: "WAN", TxBytes: tx, RxBytes: rx},
+		}}
+		daemon.fetchMetrics()
+		return daemon.GetIfaceSession(provider.m.Interfaces[0])
+	}
+
+	if tx, rx := session(4000, 5500); tx != 3000 || rx != 500 {
+		t.Errorf("session = %d/%d, want 3000/500", tx, rx)
+	}
+	// The TX counter reset, so the session counts it from zero
+	if tx, rx := session(200, 6000); tx != 200 || rx != 1000 {
+		t.Errorf("after reset session = %d/%d, want 200/1000", tx, rx)
+	}
+	if tx, rx := session(700, 6000); tx != 700 || rx != 1000 {
+		t.Errorf("session = %d/%d, want 700/1000", tx, rx)
+	}
+
+	if tx, rx := daemon.GetIfaceSession(InterfaceMetrics{Name: "igb9", TxBytes: 10}); tx != 0 || rx != 0 {
+		t.Errorf("unseen interface session = %d/%d, want 0/0", tx, rx)
+	}
+}
+
+func TestWANTrafficScreen_Totals(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	m := &Metrics{Interfaces: []InterfaceMetrics{
+		{Name: "igb0", Description: "WAN", TxBytes: 3 << 30, RxBytes: 12 << 30},
+		{Name: "igb1", Description: "WAN2", TxBytes: 1 << 20, RxBytes: 5 << 20},
+		{Name: "igb2", Description: "WAN3"}, // Off screen
+	}}
+	daemon.metrics = &staticProvider{m: m}
+	daemon.fetchMetrics()
+
+	s := &WANTrafficScreen{daemon: daemon}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	// The second WAN's totals end just above the bottom edge
+	fb := d.FrameBuffer()
+	if fb.CountSetPixels() == 0 {
+		t.Fatal("Expected the WAN screen to draw")
+	}
+	for x := 0; x < 128; x++ {
+		if fb.GetPixel(x, 63) {
+			t.Fatalf("Expected the bottom row clear, pixel set at x=%d", x)
+		}
+	}
+}
+
+func TestCounterReset_RateIsZero(t *testing.T) {
+	// Per-interface rates
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	sample := func(tx, rx uint64) {
+		daemon.metrics = &staticProvider{m: &Metrics{Interfaces: []InterfaceMetrics{
+			{Name: "igb0", TxBytes: tx, RxBytes: rx},
+		}}}
+		daemon.lastSampleTime = time.Now().Add(-time.Second)
+		daemon.fetchMetrics()
+	}
+	sample(1<<32-100, 5000)
+	sample(1<<32-100, 7000)
+	sample(50, 9000) // TX counter wrapped at 32 bits
+
+	tx, rx := daemon.GetIfaceRate("igb0")
+	if tx != 0 {
+		t.Errorf("TX rate after a counter reset = %v, want 0", tx)
+	}
+	if rx < 1000 || rx > 3000 {
+		t.Errorf("RX rate = %v, want about 2000", rx)
+	}
+	if peak, _ := daemon.PeakRate("igb0"); peak > 1e6 {
+		t.Errorf("Peak TX rate = %v, want the reset ignored", peak)
+	}
+
+	// The next sample counts from the new baseline
+	sample(1050, 9000)
+	if tx, _ := daemon.GetIfaceRate("igb0"); tx < 500 || tx > 1500 {
+		t.Errorf("TX rate after reseeding = %v, want about 1000", tx)
+	}
+
+	// Aggregate history
+	h := NewMetricsHistory(5)
+	h.AddSample(&Metrics{Interfaces: []InterfaceMetrics{{TxBytes: 1 << 40, RxBytes: 1 << 40}}})
+	h.lastSampleTime = time.Now().Add(-time.Second)
+	h.AddSample(&Metrics{Interfaces: []InterfaceMetrics{{TxBytes: 10, RxBytes: 20}}})
+	if len(h.TxRateHistory) != 1 || h.TxRateHistory[0] != 0 || h.RxRateHistory[0] != 0 {
+		t.Errorf("History after a counter reset = %v/%v, want 0/0", h.TxRateHistory, h.RxRateHistory)
+	}
+}
+
+func TestMetricsHistory_Snapshot(t *testing.T) {
+	h := NewMetricsHistory(3)
+	h.TxRateHistory = []float64{10, 20}
+	h.RxRateHistory = nil
+
+	// A Tx sample without its Rx one yet must not index Rx
+	snap := h.Snapshot()
+	if tx, rx := snap.LatestRates(); tx != 20 || rx != 0 {
+		t.Errorf("LatestRates() = %v, %v, want 20, 0", tx, rx)
+	}
+
+	// The snapshot doesn't change with the history
+	h.AddSample(&Metrics{CPU: 50})
+	h.AddSample(&Metrics{CPU: 60})
+	if len(snap.CPU) != 0 || snap.Tx[1] != 20 {
+		t.Errorf("Snapshot changed to %v/%v", snap.CPU, snap.Tx)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			h.AddSample(&Metrics{CPU: float64(i)})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		h.Snapshot().LatestRates()
+	}
+	<-done
+}
+
+func TestInterfaceScreen_StatusFilter(t *testing.T) {
+	m := &Metrics{Interfaces: []InterfaceMetrics{
+		{Name: "igb0", Status: InterfaceUp, IP: "203.0.113.2", RxBytes: 300},
+		{Name: "igb1", Status: InterfaceDown, IP: "192.168.1.1", RxBytes: 200},
+		{Name: "igb2", Status: InterfaceUp, RxBytes: 100},
+		{Name: "ovpns1", Status: InterfaceUp, IP: "10.8.0.1"},
+	}}
+	names := func(ifaces []InterfaceMetrics) string {
+		var n []string
+		for _, iface := range ifaces {
+			n = append(n, iface.Name)
+		}
+		return strings.Join(n, ",")
+	}
+
+	if got := names((&InterfaceScreen{}).visible(m)); got != "igb0,ovpns1" {
+		t.Errorf("Active interfaces = %s, want igb0,ovpns1", got)
+	}
+	if got := names((&InterfaceScreen{ShowDown: true}).visible(m)); got != "igb0,igb1,igb2,ovpns1" {
+		t.Errorf("All interfaces = %s, want igb0,igb1,igb2,ovpns1", got)
+	}
+
+	// With nothing up, the fallback message is all that's drawn below the header
+	d, _ := testutil.NewDisplay(t)
+	down := &Metrics{Interfaces: []InterfaceMetrics{m.Interfaces[1]}}
+	if err := (&InterfaceScreen{}).Render(d, down); err != nil {
+		t.Fatal(err)
+	}
+	want := eziog500.NewFrameBuffer()
+	font.RenderTextInverted(want, font.BuiltinFont, 0, 0, " INTERFACES ")
+	font.RenderText(want, font.BuiltinFont, 10, 30, "No active ifaces")
+	if d.FrameBuffer().ToDeviceFormat() != want.ToDeviceFormat() {
+		t.Error("Expected only the No active ifaces fallback")
+	}
+
+	// Showing down interfaces lists it instead
+	if err := (&InterfaceScreen{ShowDown: true}).Render(d, down); err != nil {
+		t.Fatal(err)
+	}
+	if d.FrameBuffer().ToDeviceFormat() == want.ToDeviceFormat() {
+		t.Error("Expected the down interface to be listed")
+	}
+}
+
+// TestStatusDaemon_ConcurrentRates reads the per-interface state the way
+// the screens do while the collector updates it; run it with -race.
+func TestStatusDaemon_ConcurrentRates(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	daemon.metrics = &staticProvider{m: &Metrics{Interfaces: []InterfaceMetrics{{Name: "igb0", TxBytes: 1000}}}}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			daemon.lastSampleTime = time.Now().Add(-time.Second)
+			daemon.fetchMetrics()
+		}
+	}()
+	iface := InterfaceMetrics{Name: "igb0", TxBytes: 2000}
+	for i := 0; i < 50; i++ {
+		daemon.GetIfaceRate("igb0")
+		daemon.GetIfaceRawRate("igb0")
+		daemon.IfaceHistory("igb0")
+		daemon.GetIfaceSession(iface)
+	}
+	<-done
+
+	if tx, _ := daemon.GetIfaceSession(iface); tx != 1000 {
+		t.Errorf("Session TX = %d, want 1000", tx)
+	}
+}
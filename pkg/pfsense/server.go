@@ -0,0 +1,128 @@
+package pfsense
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MetricsServer exposes metrics over HTTP for remote scraping:
+//
+//	/metrics       Prometheus text exposition format
+//	/metrics.json  The full Metrics struct as JSON
+//
+// Pass the StatusDaemon as the provider so requests are served from its
+// cache instead of running ifconfig/netstat on every scrape.
+type MetricsServer struct {
+	provider MetricsProvider
+	server   *http.Server
+}
+
+// NewMetricsServer creates a server listening on addr (e.g. ":9000").
+func NewMetricsServer(addr string, provider MetricsProvider) *MetricsServer {
+	s := &MetricsServer{provider: provider}
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+// Handler returns the HTTP handler serving the metrics endpoints.
+func (s *MetricsServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", s.handlePrometheus)
+	mux.HandleFunc("/metrics.json", s.handleJSON)
+	return mux
+}
+
+// ListenAndServe serves until Close is called. Like http.Server, it returns
+// http.ErrServerClosed after a Close.
+func (s *MetricsServer) ListenAndServe() error {
+	return s.server.ListenAndServe()
+}
+
+// Close stops the server.
+func (s *MetricsServer) Close() error {
+	return s.server.Close()
+}
+
+func (s *MetricsServer) handleJSON(w http.ResponseWriter, r *http.Request) {
+	m, ok := s.metrics(w)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(m)
+}
+
+func (s *MetricsServer) handlePrometheus(w http.ResponseWriter, r *http.Request) {
+	m, ok := s.metrics(w)
+	if !ok {
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	writePrometheus(w, m)
+}
+
+// metrics fetches metrics from the provider, writing an error response if
+// none are available.
+func (s *MetricsServer) metrics(w http.ResponseWriter) (*Metrics, bool) {
+	m, err := s.provider.GetMetrics()
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrNoMetrics) {
+			status = http.StatusServiceUnavailable
+		}
+		http.Error(w, err.Error(), status)
+		return nil, false
+	}
+	return m, true
+}
+
+// writePrometheus writes m in the Prometheus text exposition format.
+func writePrometheus(w io.Writer, m *Metrics) {
+	gauge := func(name, help string, value float64) {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %g\n", name, help, name, name, value)
+	}
+	gauge("ezio_cpu_usage_percent", "CPU usage percentage.", m.CPU)
+	gauge("ezio_memory_used_bytes", "Memory in use.", float64(m.MemUsed))
+	gauge("ezio_memory_total_bytes", "Total physical memory.", float64(m.MemTotal))
+	gauge("ezio_swap_used_bytes", "Swap in use.", float64(m.SwapUsed))
+	gauge("ezio_swap_total_bytes", "Total swap space.", float64(m.SwapTotal))
+	gauge("ezio_uptime_seconds", "System uptime.", m.Uptime.Seconds())
+
+	fmt.Fprintln(w, "# HELP ezio_load_average System load average.")
+	fmt.Fprintln(w, "# TYPE ezio_load_average gauge")
+	for i, period := range []string{"1m", "5m", "15m"} {
+		fmt.Fprintf(w, "ezio_load_average{period=%q} %g\n", period, m.LoadAvg[i])
+	}
+
+	counters := []struct {
+		name, help string
+		value      func(InterfaceMetrics) uint64
+	}{
+		{"ezio_interface_tx_bytes_total", "Bytes transmitted per interface.", func(i InterfaceMetrics) uint64 { return i.TxBytes }},
+		{"ezio_interface_rx_bytes_total", "Bytes received per interface.", func(i InterfaceMetrics) uint64 { return i.RxBytes }},
+		{"ezio_interface_rx_errors_total", "Input errors per interface.", func(i InterfaceMetrics) uint64 { return i.RxErrors }},
+		{"ezio_interface_tx_errors_total", "Output errors per interface.", func(i InterfaceMetrics) uint64 { return i.TxErrors }},
+		{"ezio_interface_drops_total", "Input drops per interface.", func(i InterfaceMetrics) uint64 { return i.Drops }},
+	}
+	for _, c := range counters {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+		for _, iface := range m.Interfaces {
+			fmt.Fprintf(w, "%s{interface=\"%s\",description=\"%s\"} %d\n",
+				c.name, promLabel(iface.Name), promLabel(iface.Description), c.value(iface))
+		}
+	}
+}
+
+// promLabel escapes a Prometheus label value.
+func promLabel(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,132 @@
+package pfsense
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+)
+
+// staticProvider serves fixed metrics and counts calls.
+type staticProvider struct {
+	m     *Metrics
+	err   error
+	calls int
+}
+
+func (p *staticProvider) GetMetrics() (*Metrics, error) {
+	p.calls++
+	return p.m, p.err
+}
+
+func testMetrics() *Metrics {
+	return &Metrics{
+		Hostname: "fw",
+		CPU:      12.5,
+		MemUsed:  1024,
+		MemTotal: 4096,
+		Uptime:   90 * time.Second,
+		LoadAvg:  [3]float64{0.5, 0.25, 0.1},
+		Interfaces: []InterfaceMetrics{
+			{Name: "igb0", Description: "WAN", TxBytes: 100, RxBytes: 200},
+		},
+	}
+}
+
+func TestMetricsServer_JSON(t *testing.T) {
+	p := &staticProvider{m: testMetrics()}
+	srv := NewMetricsServer(":0", p)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+	var got Metrics
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Hostname != "fw" || got.CPU != 12.5 || len(got.Interfaces) != 1 || got.Interfaces[0].TxBytes != 100 {
+		t.Errorf("Unexpected decoded metrics: %+v", got)
+	}
+}
+
+func TestMetricsServer_Prometheus(t *testing.T) {
+	p := &staticProvider{m: testMetrics()}
+	srv := NewMetricsServer(":0", p)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"ezio_cpu_usage_percent 12.5\n",
+		"ezio_memory_used_bytes 1024\n",
+		"ezio_memory_total_bytes 4096\n",
+		"ezio_uptime_seconds 90\n",
+		`ezio_load_average{period="5m"} 0.25`,
+		`ezio_interface_tx_bytes_total{interface="igb0",description="WAN"} 100`,
+		`ezio_interface_rx_bytes_total{interface="igb0",description="WAN"} 200`,
+		"# TYPE ezio_interface_tx_bytes_total counter",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Missing %q in output:\n%s", want, body)
+		}
+	}
+	if p.calls != 1 {
+		t.Errorf("Expected one provider call per scrape, got %d", p.calls)
+	}
+}
+
+func TestMetricsServer_NoMetrics(t *testing.T) {
+	srv := NewMetricsServer(":0", &staticProvider{err: ErrNoMetrics})
+
+	for _, path := range []string{"/metrics", "/metrics.json"} {
+		rec := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s: expected 503, got %d", path, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	NewMetricsServer(":0", &staticProvider{err: errors.New("boom")}).Handler().
+		ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500 for provider error, got %d", rec.Code)
+	}
+}
+
+func TestStatusDaemon_GetMetricsUsesCache(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+
+	if _, err := daemon.GetMetrics(); !errors.Is(err, ErrNoMetrics) {
+		t.Errorf("Expected ErrNoMetrics before first collection, got %v", err)
+	}
+
+	want := testMetrics()
+	daemon.cachedMetrics = want
+	got, err := daemon.GetMetrics()
+	if err != nil || got != want {
+		t.Errorf("Expected cached metrics, got %v, %v", got, err)
+	}
+}
+
+func TestPromLabel(t *testing.T) {
+	if got := promLabel(`a"b\c`); got != `a\"b\\c` {
+		t.Errorf("Unexpected escape: %s", got)
+	}
+}
@@ -0,0 +1,124 @@
+package pfsense
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// DefaultServices are the daemons checked unless SetServices picks others.
+var DefaultServices = []string{"unbound", "dpinger", "openvpn"}
+
+// ServiceStatus reports whether a daemon is running.
+type ServiceStatus struct {
+	Name    string `json:"name"`
+	Running bool   `json:"running"`
+}
+
+// listProcesses returns the command name of every running process.
+// Overridden in tests.
+var listProcesses = func() ([]string, error) {
+	// Same column list works on FreeBSD and Linux (procps)
+	out, err := exec.Command("ps", "-axo", "comm=").Output()
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// getServices reports which of the named services have a running process
+// with that command name. The result is in the order given.
+func getServices(names []string) ([]ServiceStatus, error) {
+	procs, err := listProcesses()
+	if err != nil {
+		return nil, err
+	}
+	running := make(map[string]bool, len(procs))
+	for _, p := range procs {
+		running[filepath.Base(p)] = true
+	}
+
+	services := make([]ServiceStatus, len(names))
+	for i, name := range names {
+		services[i] = ServiceStatus{Name: name, Running: running[name]}
+	}
+	return services, nil
+}
+
+// ServiceController restarts services, e.g. from the menu.
+type ServiceController interface {
+	Restart(name string) error
+}
+
+// pfSshPath is pfSense's shell, whose svc playback controls services the
+// way the web UI does. Overridden in tests.
+var pfSshPath = "/usr/local/sbin/pfSsh.php"
+
+// CommandServiceController restarts services with pfSense's service control
+// where it exists, or else the system's service command.
+type CommandServiceController struct {
+	// Run runs a command to completion; nil uses os/exec.
+	Run func(name string, args ...string) error
+}
+
+// Restart restarts the named service, e.g. "unbound".
+func (c *CommandServiceController) Restart(name string) error {
+	cmd := []string{"service", name, "restart"}
+	if _, err := os.Stat(pfSshPath); err == nil {
+		cmd = []string{pfSshPath, "playback", "svc", "restart", name}
+	}
+
+	run := c.Run
+	if run == nil {
+		run = func(name string, args ...string) error {
+			return exec.Command(name, args...).Run()
+		}
+	}
+	if err := run(cmd[0], cmd[1:]...); err != nil {
+		return fmt.Errorf("restart %s: %w", name, err)
+	}
+	return nil
+}
+
+// SetServices sets the services whose status is collected (nil restores
+// DefaultServices).
+func (s *SystemMetrics) SetServices(names []string) {
+	s.services = names
+}
+
+// SetServices sets the services whose status is collected.
+func (g *GopsutilMetrics) SetServices(names []string) {
+	g.sys.SetServices(names)
+}
+
+// SetServices sets the services the metrics provider checks, if it supports
+// service status.
+func (sd *StatusDaemon) SetServices(names []string) {
+	if p, ok := sd.metrics.(interface{ SetServices([]string) }); ok {
+		p.SetServices(names)
+	}
+}
+
+// SetServiceIcons sets the icons the Services screen shows for running and
+// stopped services, by their ui.GetIcon names. An empty name keeps that
+// icon's default (check and x). Unknown names are an error and leave the
+// icons unchanged.
+func (sd *StatusDaemon) SetServiceIcons(running, stopped string) error {
+	icons := [2]ui.Icon{ui.IconCheck, ui.IconX}
+	for i, name := range []string{running, stopped} {
+		if name == "" {
+			continue
+		}
+		icon, ok := ui.GetIcon(name)
+		if !ok {
+			return fmt.Errorf("unknown icon %q (available: %s)", name, strings.Join(ui.IconNames(), ", "))
+		}
+		icons[i] = icon
+	}
+	sd.serviceIcons = icons
+	return nil
+}
@@ -0,0 +1,139 @@
+package pfsense
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/internal/testutil"
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/ui"
+)
+
+// mockProcesses replaces listProcesses for the duration of a test.
+func mockProcesses(t *testing.T, procs []string, err error) {
+	t.Helper()
+	orig := listProcesses
+	listProcesses = func() ([]string, error) { return procs, err }
+	t.Cleanup(func() { listProcesses = orig })
+}
+
+func TestGetServices(t *testing.T) {
+	mockProcesses(t, []string{"init", "/usr/local/sbin/unbound", "dpinger", "dpinger", "sshd"}, nil)
+
+	got, err := getServices([]string{"unbound", "dpinger", "openvpn"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ServiceStatus{
+		{Name: "unbound", Running: true},
+		{Name: "dpinger", Running: true},
+		{Name: "openvpn", Running: false},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d services, got %+v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Service %d: expected %+v, got %+v", i, want[i], got[i])
+		}
+	}
+}
+
+func TestGetServices_ListError(t *testing.T) {
+	mockProcesses(t, nil, errors.New("ps failed"))
+	if _, err := getServices(DefaultServices); err == nil {
+		t.Error("Expected an error when processes can't be listed")
+	}
+}
+
+func TestServiceScreen_Render(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	s := &ServiceScreen{}
+
+	m := &Metrics{}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	// Enough services for two columns
+	for _, name := range []string{"unbound", "dpinger", "openvpn", "sshd", "ntpd", "syslogd", "dhcpd"} {
+		m.Services = append(m.Services, ServiceStatus{Name: name, Running: name != "openvpn"})
+	}
+	if err := s.Render(d, m); err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if d.FrameBuffer().GetPixel(64+4, 11+3) == false {
+		t.Error("Expected the second column's first icon to be drawn")
+	}
+}
+
+func TestStatusDaemon_SetServiceIcons(t *testing.T) {
+	d, _ := testutil.NewDisplay(t)
+	daemon := NewStatusDaemon(d, 0, 0)
+	s, err := NewScreenByName("Services", daemon)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &Metrics{Services: []ServiceStatus{{Name: "sshd", Running: true}}}
+
+	if err := daemon.SetServiceIcons("arrow-up", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Render(d, m); err != nil {
+		t.Fatal(err)
+	}
+	want := eziog500.NewFrameBuffer()
+	ui.IconArrowUp.Render(want, 0, 11)
+	for x := 0; x < 8; x++ {
+		for y := 11; y < 19; y++ {
+			if d.FrameBuffer().GetPixel(x, y) != want.GetPixel(x, y) {
+				t.Fatalf("Expected the arrow-up icon for a running service, differs at %d,%d", x, y)
+			}
+		}
+	}
+
+	if err := daemon.SetServiceIcons("nope", ""); err == nil {
+		t.Error("Expected an error for an unknown icon")
+	}
+	if daemon.serviceIcons[0] != ui.IconArrowUp {
+		t.Error("Expected a failed SetServiceIcons to leave the icons unchanged")
+	}
+}
+
+func TestCommandServiceController_Restart(t *testing.T) {
+	var ran []string
+	ctl := &CommandServiceController{Run: func(name string, args ...string) error {
+		ran = append(ran, strings.Join(append([]string{name}, args...), " "))
+		return nil
+	}}
+
+	orig := pfSshPath
+	t.Cleanup(func() { pfSshPath = orig })
+
+	// Without pfSense's shell the service command is used
+	pfSshPath = filepath.Join(t.TempDir(), "missing")
+	if err := ctl.Restart("unbound"); err != nil {
+		t.Fatal(err)
+	}
+
+	pfSshPath = filepath.Join(t.TempDir(), "pfSsh.php")
+	if err := os.WriteFile(pfSshPath, nil, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctl.Restart("dpinger"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"service unbound restart", pfSshPath + " playback svc restart dpinger"}
+	if len(ran) != 2 || ran[0] != want[0] || ran[1] != want[1] {
+		t.Errorf("Ran %q, want %q", ran, want)
+	}
+
+	ctl.Run = func(string, ...string) error { return errors.New("exit status 1") }
+	if err := ctl.Restart("unbound"); err == nil || !strings.Contains(err.Error(), "unbound") {
+		t.Errorf("Got %v, want an error naming the service", err)
+	}
+}
@@ -0,0 +1,115 @@
+package pfsense
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// webhookTimeout bounds each POST so a slow endpoint can't pile up requests.
+const webhookTimeout = 5 * time.Second
+
+// webhookQueueSize is how many notifications may wait for delivery before
+// new ones are dropped.
+const webhookQueueSize = 16
+
+// WebhookPayload is the JSON body POSTed for each alert.
+type WebhookPayload struct {
+	Hostname  string        `json:"hostname"`
+	Rule      string        `json:"rule"`
+	Metric    string        `json:"metric"`
+	Value     float64       `json:"value"`
+	Threshold float64       `json:"threshold"`
+	Severity  AlertSeverity `json:"severity"`
+	State     string        `json:"state"` // "firing" or "resolved"
+	Timestamp time.Time     `json:"timestamp"`
+}
+
+// WebhookNotifier returns AlertManager callbacks that POST a WebhookPayload
+// to url. Requests are sent in order from a background goroutine, so the
+// callbacks never block; failed or overflowing notifications are dropped
+// and reported on stderr. stop ends the goroutine, cancelling a request in
+// flight, and waits for it to exit; call it when the daemon shuts down.
+// Notifications after stop are dropped.
+func WebhookNotifier(url string) (onAlert, onClear func(Alert), stop func()) {
+	return newWebhook(url, &http.Client{Timeout: webhookTimeout}, os.Stderr)
+}
+
+// newWebhook builds the notifier callbacks with a given client and log output.
+func newWebhook(url string, client *http.Client, logw io.Writer) (onAlert, onClear func(Alert), stop func()) {
+	queue := make(chan WebhookPayload, webhookQueueSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	exited := make(chan struct{})
+
+	go func() {
+		defer close(exited)
+		for {
+			select {
+			case p := <-queue:
+				if err := postWebhook(ctx, client, url, p); err != nil && ctx.Err() == nil {
+					fmt.Fprintf(logw, "webhook: dropping %s %s alert: %v\n", p.Rule, p.State, err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	enqueue := func(a Alert, state string) {
+		p := WebhookPayload{
+			Hostname:  a.Hostname,
+			Rule:      a.Rule,
+			Metric:    a.Metric,
+			Value:     a.Value,
+			Threshold: a.Threshold,
+			Severity:  a.Severity,
+			State:     state,
+			Timestamp: a.Time,
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		select {
+		case queue <- p:
+		default:
+			fmt.Fprintf(logw, "webhook: queue full, dropping %s %s alert\n", p.Rule, p.State)
+		}
+	}
+
+	onAlert = func(a Alert) { enqueue(a, "firing") }
+	onClear = func(a Alert) { enqueue(a, "resolved") }
+	stop = func() {
+		cancel()
+		<-exited
+	}
+	return onAlert, onClear, stop
+}
+
+// postWebhook sends one payload, treating non-2xx responses as errors.
+func postWebhook(ctx context.Context, client *http.Client, url string, p WebhookPayload) error {
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
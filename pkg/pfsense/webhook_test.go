@@ -0,0 +1,153 @@
+package pfsense
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWebhookNotifier_Payload(t *testing.T) {
+	bodies := make(chan []byte, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Got %s with Content-Type %q", r.Method, r.Header.Get("Content-Type"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		bodies <- body
+	}))
+	defer srv.Close()
+
+	onAlert, onClear, stop := WebhookNotifier(srv.URL)
+	defer stop()
+	when := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	alert := Alert{
+		Rule: "cpu_critical", Metric: "cpu", Hostname: "fw1",
+		Value: 97.5, Threshold: 90, Severity: SeverityCritical, Time: when,
+	}
+	onAlert(alert)
+	onClear(alert)
+
+	var got map[string]interface{}
+	select {
+	case body := <-bodies:
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Fatalf("Invalid JSON %q: %v", body, err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the webhook")
+	}
+	want := map[string]interface{}{
+		"hostname":  "fw1",
+		"rule":      "cpu_critical",
+		"metric":    "cpu",
+		"value":     97.5,
+		"threshold": 90.0,
+		"severity":  "critical",
+		"state":     "firing",
+		"timestamp": "2024-03-01T12:00:00Z",
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("%s = %v, want %v", k, got[k], v)
+		}
+	}
+
+	// The clear follows, in order
+	select {
+	case body := <-bodies:
+		if !strings.Contains(string(body), `"state":"resolved"`) {
+			t.Errorf("Expected a resolved payload, got %s", body)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the clear webhook")
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for the notifier goroutine to write.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestWebhookNotifier_LogsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	var logs syncBuffer
+	onAlert, _, stop := newWebhook(srv.URL, srv.Client(), &logs)
+	defer stop()
+	onAlert(Alert{Rule: "gateway_down"})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !strings.Contains(logs.String(), "gateway_down") {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the failed delivery to be logged")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !strings.Contains(logs.String(), "500") {
+		t.Errorf("Expected the status in the log, got %q", logs.String())
+	}
+}
+
+func TestWebhookNotifier_Stop(t *testing.T) {
+	requests := make(chan struct{}, 2)
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests <- struct{}{}
+		<-release // Hang until the test ends
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	var logs syncBuffer
+	onAlert, _, stop := newWebhook(srv.URL, srv.Client(), &logs)
+	onAlert(Alert{Rule: "cpu_critical"})
+	select {
+	case <-requests:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the webhook")
+	}
+
+	// stop cancels the hung request and waits for the goroutine
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("stop didn't return")
+	}
+
+	onAlert(Alert{Rule: "mem_critical"})
+	select {
+	case <-requests:
+		t.Error("Expected no requests after stop")
+	case <-time.After(50 * time.Millisecond):
+	}
+	if logs.String() != "" {
+		t.Errorf("Expected nothing logged for a cancelled request, got %q", logs.String())
+	}
+}
@@ -0,0 +1,95 @@
+// Package dither converts grayscale images to 1-bit framebuffers for the EZIO-G500.
+//
+// The display can only show lit or unlit pixels, so a plain threshold turns
+// gradients and photos into flat blobs. Dithering trades spatial resolution
+// for apparent gray levels.
+package dither
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Bayer4 is the 4x4 Bayer threshold matrix (values 0-15), for ordered
+// dithering elsewhere, such as render3d's face shading.
+var Bayer4 = [4][4]int{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+}
+
+// luminance reads the image into a display-sized luminance grid (0-255).
+// The image is sampled 1:1 from its top-left corner; use
+// eziog500.ScaleImage first to fit arbitrary sizes.
+func luminance(img image.Image) (lum [eziog500.Height][eziog500.Width]float64, w, h int) {
+	b := img.Bounds()
+	w, h = b.Dx(), b.Dy()
+	if w > eziog500.Width {
+		w = eziog500.Width
+	}
+	if h > eziog500.Height {
+		h = eziog500.Height
+	}
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			g := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			lum[y][x] = float64(g.Y)
+		}
+	}
+	return lum, w, h
+}
+
+// Dither renders an image into a framebuffer using Floyd–Steinberg error
+// diffusion. Bright pixels are lit.
+func Dither(img image.Image) *eziog500.FrameBuffer {
+	fb := eziog500.NewFrameBuffer()
+	lum, w, h := luminance(img)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			old := lum[y][x]
+			var val float64
+			if old >= 128 {
+				val = 255
+				fb.SetPixel(x, y, true)
+			}
+			e := old - val
+
+			// Distribute the quantization error to unvisited neighbours
+			if x+1 < w {
+				lum[y][x+1] += e * 7 / 16
+			}
+			if y+1 < h {
+				if x > 0 {
+					lum[y+1][x-1] += e * 3 / 16
+				}
+				lum[y+1][x] += e * 5 / 16
+				if x+1 < w {
+					lum[y+1][x+1] += e * 1 / 16
+				}
+			}
+		}
+	}
+	return fb
+}
+
+// DitherOrdered renders an image into a framebuffer using a 4x4 Bayer
+// matrix. The output depends only on each pixel's own value, so it is
+// deterministic and stable between animation frames.
+func DitherOrdered(img image.Image) *eziog500.FrameBuffer {
+	fb := eziog500.NewFrameBuffer()
+	lum, w, h := luminance(img)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			threshold := (float64(Bayer4[y%4][x%4]) + 0.5) * 256 / 16
+			if lum[y][x] >= threshold {
+				fb.SetPixel(x, y, true)
+			}
+		}
+	}
+	return fb
+}
@@ -0,0 +1,77 @@
+package dither
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// gradient returns a left-to-right black-to-white gradient filling the display.
+func gradient() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, eziog500.Width, eziog500.Height))
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(x * 255 / (eziog500.Width - 1))})
+		}
+	}
+	return img
+}
+
+// density returns the fraction of lit pixels in columns x0..x1 (exclusive).
+func density(fb *eziog500.FrameBuffer, x0, x1 int) float64 {
+	on := 0
+	for y := 0; y < eziog500.Height; y++ {
+		for x := x0; x < x1; x++ {
+			if fb.GetPixel(x, y) {
+				on++
+			}
+		}
+	}
+	return float64(on) / float64((x1-x0)*eziog500.Height)
+}
+
+func TestDither_Gradient(t *testing.T) {
+	fb := Dither(gradient())
+
+	// The gradient averages to mid gray
+	if c := fb.Coverage(); c < 0.45 || c > 0.55 {
+		t.Errorf("Expected overall coverage ~0.5, got %.3f", c)
+	}
+
+	// Darker half is sparser than the brighter half
+	if density(fb, 0, 64) >= density(fb, 64, 128) {
+		t.Error("Dark half should have fewer lit pixels than bright half")
+	}
+}
+
+func TestDitherOrdered_Gradient(t *testing.T) {
+	fb := DitherOrdered(gradient())
+
+	if c := fb.Coverage(); c < 0.45 || c > 0.55 {
+		t.Errorf("Expected overall coverage ~0.5, got %.3f", c)
+	}
+
+	// Ordered dithering is deterministic
+	again := DitherOrdered(gradient())
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) != again.GetPixel(x, y) {
+				t.Fatalf("Ordered dither output differs at (%d,%d)", x, y)
+			}
+		}
+	}
+}
+
+func TestDither_Extremes(t *testing.T) {
+	black := image.NewUniform(color.Gray{Y: 0})
+	white := image.NewUniform(color.Gray{Y: 255})
+
+	if n := Dither(black).CountSetPixels(); n != 0 {
+		t.Errorf("Black image should have no lit pixels, got %d", n)
+	}
+	if c := DitherOrdered(white).Coverage(); c != 1 {
+		t.Errorf("White image should be fully lit, got %.3f", c)
+	}
+}
@@ -0,0 +1,56 @@
+// Package qr renders QR codes for the EZIO-G500, e.g. to show a management
+// URL or WiFi credentials that a phone can scan.
+package qr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// QuietZone is the light margin, in modules, drawn around the code. The
+// standard asks for 4, but the display is only 64 pixels tall and phone
+// scanners cope with 2.
+const QuietZone = 2
+
+// ErrTooLarge is returned when text needs more modules than fit on the
+// display at one pixel per module.
+var ErrTooLarge = errors.New("qr: text too long to fit on the display")
+
+// Encode renders text as a QR code centred on a new framebuffer, using the
+// largest whole number of pixels per module that fits the display height.
+// Light modules and the quiet zone are lit, matching eziog500.FromImage's
+// lit-is-bright convention, so the code reads dark on light.
+func Encode(text string) (*eziog500.FrameBuffer, error) {
+	// Low error correction keeps codes small enough for 64 pixels; an LCD
+	// doesn't get scuffed like a printed code
+	code, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return nil, fmt.Errorf("qr: %w", err)
+	}
+	code.DisableBorder = true
+	modules := code.Bitmap()
+
+	size := len(modules) + 2*QuietZone
+	scale := eziog500.Height / size
+	if scale < 1 {
+		return nil, fmt.Errorf("%w (%d modules, at most %d)", ErrTooLarge, len(modules), eziog500.Height-2*QuietZone)
+	}
+
+	fb := eziog500.NewFrameBuffer()
+	left := (eziog500.Width - size*scale) / 2
+	top := (eziog500.Height - size*scale) / 2
+	fb.FillRect(left, top, size*scale, size*scale, true)
+
+	origin := QuietZone * scale
+	for my, row := range modules {
+		for mx, dark := range row {
+			if dark {
+				fb.FillRect(left+origin+mx*scale, top+origin+my*scale, scale, scale, false)
+			}
+		}
+	}
+	return fb, nil
+}
@@ -0,0 +1,78 @@
+package qr
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// litBounds returns the bounding box of lit pixels.
+func litBounds(fb *eziog500.FrameBuffer) (x0, y0, x1, y1 int) {
+	x0, y0, x1, y1 = eziog500.Width, eziog500.Height, -1, -1
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) {
+				x0, y0 = min(x0, x), min(y0, y)
+				x1, y1 = max(x1, x), max(y1, y)
+			}
+		}
+	}
+	return x0, y0, x1, y1
+}
+
+func TestEncode(t *testing.T) {
+	for _, text := range []string{
+		"https://192.168.1.1",
+		"WIFI:T:WPA;S:office;P:correct horse battery staple;;",
+	} {
+		fb, err := Encode(text)
+		if err != nil {
+			t.Fatalf("Encode(%q): %v", text, err)
+		}
+		if fb.CountSetPixels() == 0 {
+			t.Fatalf("Encode(%q) produced an empty framebuffer", text)
+		}
+
+		x0, y0, x1, y1 := litBounds(fb)
+		w, h := x1-x0+1, y1-y0+1
+		if w != h {
+			t.Errorf("Encode(%q): code is %dx%d, want square", text, w, h)
+		}
+		if h > eziog500.Height {
+			t.Errorf("Encode(%q): code is %d pixels tall", text, h)
+		}
+		if left, right := x0, eziog500.Width-1-x1; left-right > 1 || right-left > 1 {
+			t.Errorf("Encode(%q): not centred, margins %d and %d", text, left, right)
+		}
+
+		// Dark modules are unlit pixels inside the lit square
+		if dark := w*h - fb.CountSetPixels(); dark == 0 {
+			t.Errorf("Encode(%q): no dark modules", text)
+		}
+	}
+}
+
+func TestEncode_LargestScale(t *testing.T) {
+	// A short URL is a 21-module version 1 code: 25 modules with the quiet
+	// zone, so 2 pixels per module
+	fb, err := Encode("http://fw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	x0, y0, x1, _ := litBounds(fb)
+	if w := x1 - x0 + 1; w != 50 {
+		t.Errorf("code is %d pixels wide, want 50", w)
+	}
+	corner := QuietZone * 2
+	if fb.GetPixel(x0+corner, y0+corner) {
+		t.Error("Expected the finder pattern's corner module to be dark")
+	}
+}
+
+func TestEncode_TooLarge(t *testing.T) {
+	if _, err := Encode(strings.Repeat("x", 500)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("Encode of 500 bytes = %v, want ErrTooLarge", err)
+	}
+}
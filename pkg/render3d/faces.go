@@ -0,0 +1,123 @@
+package render3d
+
+import (
+	"math"
+	"sort"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/render/dither"
+)
+
+// FillPattern decides whether pixel (x, y) of a face is set, given how
+// directly the face points at the camera (brightness 0 = edge-on, 1 = head-on).
+type FillPattern func(x, y int, brightness float64) bool
+
+// SolidFill sets every pixel of every face, ignoring brightness. Pair it with
+// a wireframe Draw in the opposite colour to show the face boundaries.
+func SolidFill(x, y int, brightness float64) bool {
+	return true
+}
+
+// DitherFill shades faces with a 4x4 ordered dither, so faces turned away
+// from the camera look darker.
+func DitherFill(x, y int, brightness float64) bool {
+	level := int(brightness*16 + 0.5)
+	return level > dither.Bayer4[y&3][x&3]
+}
+
+// sub returns a - b.
+func sub(a, b Point3D) Point3D {
+	return Point3D{a.X - b.X, a.Y - b.Y, a.Z - b.Z}
+}
+
+// cross returns the cross product a × b.
+func cross(a, b Point3D) Point3D {
+	return Point3D{
+		X: a.Y*b.Z - a.Z*b.Y,
+		Y: a.Z*b.X - a.X*b.Z,
+		Z: a.X*b.Y - a.Y*b.X,
+	}
+}
+
+// dot returns the dot product a · b.
+func dot(a, b Point3D) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+// faceInfo holds the per-face values used for culling, sorting and shading.
+type faceInfo struct {
+	index      int
+	depth      float64 // Mean Z of the face's vertices
+	brightness float64 // Cosine between the normal and the direction to the camera
+}
+
+// facing computes culling and shading data for each face turned towards the
+// camera. Faces are skipped when their normal points away from the viewer.
+func facing(cam *Camera, vertices []Point3D, faces [][]int) []faceInfo {
+	eye := Point3D{0, 0, -cam.Distance}
+
+	var result []faceInfo
+	for i, face := range faces {
+		if len(face) < 3 {
+			continue
+		}
+		a, b, c := vertices[face[0]], vertices[face[1]], vertices[face[2]]
+		normal := cross(sub(b, a), sub(c, a))
+
+		var centroid Point3D
+		for _, idx := range face {
+			v := vertices[idx]
+			centroid.X += v.X
+			centroid.Y += v.Y
+			centroid.Z += v.Z
+		}
+		n := float64(len(face))
+		centroid = Point3D{centroid.X / n, centroid.Y / n, centroid.Z / n}
+
+		// Back face when the normal points along the view direction
+		view := sub(centroid, eye)
+		d := dot(normal, view)
+		if d >= 0 {
+			continue
+		}
+
+		brightness := -d / math.Sqrt(dot(normal, normal)*dot(view, view))
+		result = append(result, faceInfo{index: i, depth: centroid.Z, brightness: brightness})
+	}
+	return result
+}
+
+// visibleFaces returns the indices of faces turned towards the camera.
+func visibleFaces(cam *Camera, vertices []Point3D, faces [][]int) []int {
+	info := facing(cam, vertices, faces)
+	indices := make([]int, len(info))
+	for i, f := range info {
+		indices[i] = f.index
+	}
+	return indices
+}
+
+// drawFaces fills the visible faces farthest first (painter's algorithm) so
+// nearer faces overwrite the ones behind them.
+func drawFaces(fb *eziog500.FrameBuffer, cam *Camera, vertices []Point3D, faces [][]int, pattern FillPattern) {
+	info := facing(cam, vertices, faces)
+	sort.SliceStable(info, func(i, j int) bool { return info[i].depth > info[j].depth })
+
+	projected := make([]Point2D, len(vertices))
+	for i, v := range vertices {
+		projected[i] = cam.Project(v)
+	}
+
+	for _, f := range info {
+		face := faces[f.index]
+		brightness := f.brightness
+		pixel := func(x, y int) bool { return pattern(x, y, brightness) }
+
+		// Fan-triangulate polygons around the first vertex
+		p0 := projected[face[0]]
+		for i := 1; i+1 < len(face); i++ {
+			p1, p2 := projected[face[i]], projected[face[i+1]]
+			fb.FillTriangleFunc(p0.X, p0.Y, p1.X, p1.Y, p2.X, p2.Y, pixel)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package render3d
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestCube_BackfaceCulling(t *testing.T) {
+	cam := DefaultCamera()
+
+	// Square on, only the front face can be seen
+	cube := NewCube(1.5)
+	if got := cube.VisibleFaces(cam); len(got) != 1 || got[0] != 0 {
+		t.Errorf("unrotated visible faces = %v, want [0]", got)
+	}
+
+	// Turned so a corner points at the camera: three faces show, three are culled
+	cube.Rotate(math.Pi/5, math.Pi/4, 0)
+	visible := cube.VisibleFaces(cam)
+	if len(visible) != 3 {
+		t.Fatalf("visible faces = %v, want 3", visible)
+	}
+	seen := make(map[int]bool)
+	for _, i := range visible {
+		seen[i] = true
+	}
+	// Opposite faces are adjacent pairs in Faces; exactly one of each pair shows
+	for i := 0; i < 6; i += 2 {
+		if seen[i] == seen[i+1] {
+			t.Errorf("faces %d and %d: both visible or both culled (%v)", i, i+1, visible)
+		}
+	}
+}
+
+func TestCube_DrawFilled(t *testing.T) {
+	cam := DefaultCamera()
+	cube := NewCube(1.5)
+	cube.Rotate(math.Pi/5, math.Pi/4, 0)
+
+	solid := eziog500.NewFrameBuffer()
+	cube.DrawFilled(solid, cam, SolidFill)
+	wire := eziog500.NewFrameBuffer()
+	cube.Draw(wire, cam, true)
+	if solid.CountSetPixels() <= wire.CountSetPixels() {
+		t.Errorf("filled cube set %d pixels, wireframe %d; expected more when filled",
+			solid.CountSetPixels(), wire.CountSetPixels())
+	}
+
+	dithered := eziog500.NewFrameBuffer()
+	cube.DrawFilled(dithered, cam, DitherFill)
+	if n := dithered.CountSetPixels(); n == 0 || n >= solid.CountSetPixels() {
+		t.Errorf("dithered cube set %d pixels, want between 0 and %d", n, solid.CountSetPixels())
+	}
+}
+
+func TestDitherFill_Levels(t *testing.T) {
+	count := func(brightness float64) int {
+		n := 0
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				if DitherFill(x, y, brightness) {
+					n++
+				}
+			}
+		}
+		return n
+	}
+	if count(0) != 0 || count(0.5) != 8 || count(1) != 16 {
+		t.Errorf("coverage = %d/%d/%d, want 0/8/16", count(0), count(0.5), count(1))
+	}
+}
@@ -0,0 +1,160 @@
+package render3d
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Mesh is an arbitrary 3D shape made of vertices, edges and triangular faces.
+type Mesh struct {
+	Vertices []Point3D
+	Edges    [][2]int // Pairs of vertex indices
+	Faces    [][]int  // Vertex indices of each triangle
+}
+
+// Rotate rotates all vertices of the mesh.
+func (m *Mesh) Rotate(angleX, angleY, angleZ float64) {
+	rotateAll(m.Vertices, angleX, angleY, angleZ)
+}
+
+// Draw renders the mesh wireframe onto the framebuffer.
+func (m *Mesh) Draw(fb *eziog500.FrameBuffer, cam *Camera, on bool) {
+	drawEdges(fb, cam, m.Vertices, m.Edges, on)
+}
+
+// DrawFilled renders the mesh's visible faces, shaded with pattern.
+func (m *Mesh) DrawFilled(fb *eziog500.FrameBuffer, cam *Camera, pattern FillPattern) {
+	drawFaces(fb, cam, m.Vertices, m.Faces, pattern)
+}
+
+// VisibleFaces returns the indices of faces turned towards the camera.
+func (m *Mesh) VisibleFaces(cam *Camera) []int {
+	return visibleFaces(cam, m.Vertices, m.Faces)
+}
+
+// Copy returns a copy of the mesh for animation.
+func (m *Mesh) Copy() *Mesh {
+	newMesh := &Mesh{
+		Vertices: make([]Point3D, len(m.Vertices)),
+		Edges:    m.Edges, // Edges and faces are shared (immutable indices)
+		Faces:    m.Faces,
+	}
+	copy(newMesh.Vertices, m.Vertices)
+	return newMesh
+}
+
+// LoadOBJ reads a Wavefront OBJ model. Only "v" and "f" statements are used;
+// polygons are fan-triangulated into Faces, and Edges follow the polygon
+// outlines so quads don't show their diagonals in wireframe.
+//
+// OBJ models are Y-up while the screen is Y-down, so vertices are rotated
+// 180° around X on load to appear upright and facing the camera.
+func LoadOBJ(r io.Reader) (*Mesh, error) {
+	mesh := &Mesh{}
+	edges := newEdgeSet()
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: vertex needs 3 coordinates", lineNum)
+			}
+			var xyz [3]float64
+			for i := range xyz {
+				v, err := strconv.ParseFloat(fields[i+1], 64)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: invalid coordinate %q", lineNum, fields[i+1])
+				}
+				xyz[i] = v
+			}
+			mesh.Vertices = append(mesh.Vertices, Point3D{X: xyz[0], Y: -xyz[1], Z: -xyz[2]})
+
+		case "f":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: face needs at least 3 vertices", lineNum)
+			}
+			poly := make([]int, len(fields)-1)
+			for i, ref := range fields[1:] {
+				idx, err := objIndex(ref, len(mesh.Vertices))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNum, err)
+				}
+				poly[i] = idx
+			}
+
+			// Fan triangulation around the first vertex
+			for i := 1; i+1 < len(poly); i++ {
+				mesh.Faces = append(mesh.Faces, []int{poly[0], poly[i], poly[i+1]})
+			}
+
+			edges.addOutline(poly)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(mesh.Vertices) == 0 {
+		return nil, fmt.Errorf("no vertices found")
+	}
+	mesh.Edges = edges.edges
+	return mesh, nil
+}
+
+// edgeSet collects polygon outline edges, each stored once regardless of
+// direction.
+type edgeSet struct {
+	seen  map[[2]int]bool
+	edges [][2]int
+}
+
+func newEdgeSet() *edgeSet {
+	return &edgeSet{seen: make(map[[2]int]bool)}
+}
+
+// addOutline adds the edges around a closed polygon.
+func (e *edgeSet) addOutline(poly []int) {
+	for i, a := range poly {
+		b := poly[(i+1)%len(poly)]
+		key := [2]int{a, b}
+		if a > b {
+			key = [2]int{b, a}
+		}
+		if !e.seen[key] {
+			e.seen[key] = true
+			e.edges = append(e.edges, key)
+		}
+	}
+}
+
+// objIndex resolves a face vertex reference ("3", "3/1", "3//2" or a
+// negative relative index) to a zero-based vertex index.
+func objIndex(ref string, numVertices int) (int, error) {
+	if slash := strings.IndexByte(ref, '/'); slash >= 0 {
+		ref = ref[:slash]
+	}
+	n, err := strconv.Atoi(ref)
+	if err != nil {
+		return 0, fmt.Errorf("invalid vertex index %q", ref)
+	}
+	idx := n - 1
+	if n < 0 {
+		idx = numVertices + n
+	}
+	if n == 0 || idx < 0 || idx >= numVertices {
+		return 0, fmt.Errorf("vertex index %d out of range", n)
+	}
+	return idx, nil
+}
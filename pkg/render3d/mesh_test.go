@@ -0,0 +1,82 @@
+package render3d
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+const testOBJ = `# square pyramid
+o pyramid
+v -1 0 -1
+v 1 0 -1
+v 1 0 1
+v -1 0 1
+v 0 1.5 0
+vn 0 1 0
+f 1 2 3 4
+f 1/1 5/1 2/1
+f 2//1 5//1 3//1
+f 3 5 4
+f -5 -1 -2
+`
+
+func TestLoadOBJ(t *testing.T) {
+	mesh, err := LoadOBJ(strings.NewReader(testOBJ))
+	if err != nil {
+		t.Fatalf("LoadOBJ failed: %v", err)
+	}
+	if len(mesh.Vertices) != 5 {
+		t.Errorf("Expected 5 vertices, got %d", len(mesh.Vertices))
+	}
+	// 4 base edges + 4 edges up to the apex, no quad diagonal
+	if len(mesh.Edges) != 8 {
+		t.Errorf("Expected 8 edges, got %d", len(mesh.Edges))
+	}
+	// The quad base splits into 2 triangles
+	if len(mesh.Faces) != 6 {
+		t.Errorf("Expected 6 triangles, got %d", len(mesh.Faces))
+	}
+	// Y-up apex ends up above the base on screen (negative Y)
+	if apex := mesh.Vertices[4]; apex.Y != -1.5 {
+		t.Errorf("apex = %+v, want Y -1.5", apex)
+	}
+}
+
+func TestLoadOBJ_Errors(t *testing.T) {
+	tests := map[string]string{
+		"empty":        "# nothing here\n",
+		"bad vertex":   "v 1 two 3\n",
+		"short face":   "v 0 0 0\nv 1 0 0\nf 1 2\n",
+		"out of range": "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4\n",
+		"zero index":   "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 0 1 2\n",
+	}
+	for name, src := range tests {
+		if _, err := LoadOBJ(strings.NewReader(src)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMesh_CopyRotateDraw(t *testing.T) {
+	mesh, err := LoadOBJ(strings.NewReader(testOBJ))
+	if err != nil {
+		t.Fatalf("LoadOBJ failed: %v", err)
+	}
+
+	rotated := mesh.Copy()
+	rotated.Rotate(0.5, 0.5, 0)
+	if rotated.Vertices[0] == mesh.Vertices[0] {
+		t.Error("Expected Rotate to move the copy's vertices")
+	}
+	if mesh.Vertices[0] != (Point3D{-1, 0, 1}) {
+		t.Errorf("Rotating a copy changed the original: %+v", mesh.Vertices[0])
+	}
+
+	fb := eziog500.NewFrameBuffer()
+	rotated.Draw(fb, DefaultCamera(), true)
+	if fb.CountSetPixels() == 0 {
+		t.Error("Expected Draw to set pixels")
+	}
+}
@@ -0,0 +1,102 @@
+package render3d
+
+import "math"
+
+// minSphereSegments is the fewest longitude slices NewSphere will build.
+const minSphereSegments = 4
+
+// NewSphere creates a UV sphere centred at origin with the given number of
+// longitude slices and half as many latitude bands. Segments below 4 are
+// raised to 4.
+func NewSphere(radius float64, segments int) *Mesh {
+	if segments < minSphereSegments {
+		segments = minSphereSegments
+	}
+	rings := segments / 2
+
+	// Top pole, then each latitude ring from top to bottom, then bottom pole.
+	// Y is negated so the "top" pole is at the top of the screen.
+	vertices := []Point3D{{0, -radius, 0}}
+	for i := 1; i < rings; i++ {
+		phi := math.Pi * float64(i) / float64(rings)
+		y := -radius * math.Cos(phi)
+		r := radius * math.Sin(phi)
+		for j := 0; j < segments; j++ {
+			theta := 2 * math.Pi * float64(j) / float64(segments)
+			vertices = append(vertices, Point3D{r * math.Cos(theta), y, r * math.Sin(theta)})
+		}
+	}
+	bottom := len(vertices)
+	vertices = append(vertices, Point3D{0, radius, 0})
+
+	ring := func(i, j int) int { return 1 + (i-1)*segments + j%segments }
+
+	var faces [][]int
+	for j := 0; j < segments; j++ {
+		faces = append(faces, []int{0, ring(1, j), ring(1, j+1)})
+		for i := 1; i+1 < rings; i++ {
+			faces = append(faces, []int{ring(i, j), ring(i+1, j), ring(i+1, j+1), ring(i, j+1)})
+		}
+		faces = append(faces, []int{bottom, ring(rings-1, j+1), ring(rings-1, j)})
+	}
+
+	return newConvexMesh(vertices, faces)
+}
+
+// NewPyramid creates a square-based pyramid centred at origin, with base
+// width and height both equal to size and the apex pointing up.
+func NewPyramid(size float64) *Mesh {
+	s := size / 2
+	vertices := []Point3D{
+		{-s, s, -s}, // 0
+		{s, s, -s},  // 1
+		{s, s, s},   // 2
+		{-s, s, s},  // 3
+		{0, -s, 0},  // 4 apex
+	}
+	faces := [][]int{
+		{0, 1, 2, 3}, // Base
+		{0, 4, 1},
+		{1, 4, 2},
+		{2, 4, 3},
+		{3, 4, 0},
+	}
+	return newConvexMesh(vertices, faces)
+}
+
+// NewTetrahedron creates a regular tetrahedron centred at origin with edges
+// of length size.
+func NewTetrahedron(size float64) *Mesh {
+	// Alternate corners of a cube have edges of 2√2 times the half-width
+	s := size / (2 * math.Sqrt2)
+	vertices := []Point3D{
+		{s, s, s},
+		{s, -s, -s},
+		{-s, s, -s},
+		{-s, -s, s},
+	}
+	faces := [][]int{
+		{0, 1, 2},
+		{0, 3, 1},
+		{0, 2, 3},
+		{1, 3, 2},
+	}
+	return newConvexMesh(vertices, faces)
+}
+
+// newConvexMesh builds a mesh for a convex shape enclosing the origin. Face
+// winding is corrected so every normal points away from the origin, which
+// DrawFilled relies on for culling, and edges are derived from the faces.
+func newConvexMesh(vertices []Point3D, faces [][]int) *Mesh {
+	edges := newEdgeSet()
+	for _, face := range faces {
+		a, b, c := vertices[face[0]], vertices[face[1]], vertices[face[2]]
+		if dot(cross(sub(b, a), sub(c, a)), a) < 0 {
+			for i, j := 0, len(face)-1; i < j; i, j = i+1, j-1 {
+				face[i], face[j] = face[j], face[i]
+			}
+		}
+		edges.addOutline(face)
+	}
+	return &Mesh{Vertices: vertices, Edges: edges.edges, Faces: faces}
+}
@@ -0,0 +1,68 @@
+package render3d
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewSphere(t *testing.T) {
+	for _, segments := range []int{8, 12, 16} {
+		sphere := NewSphere(2, segments)
+		rings := segments / 2
+
+		// Two poles plus one full ring per interior latitude
+		if want := 2 + (rings-1)*segments; len(sphere.Vertices) != want {
+			t.Errorf("segments %d: %d vertices, want %d", segments, len(sphere.Vertices), want)
+		}
+		// Meridian edges per band, plus parallel edges per interior ring
+		if want := segments*rings + segments*(rings-1); len(sphere.Edges) != want {
+			t.Errorf("segments %d: %d edges, want %d", segments, len(sphere.Edges), want)
+		}
+		for i, v := range sphere.Vertices {
+			if r := math.Sqrt(dot(v, v)); math.Abs(r-2) > 1e-9 {
+				t.Errorf("segments %d: vertex %d at radius %f", segments, i, r)
+			}
+		}
+		assertOutward(t, "sphere", sphere)
+	}
+
+	// Too few segments are raised to the minimum
+	if small := NewSphere(1, 1); len(small.Vertices) != 2+minSphereSegments {
+		t.Errorf("minimum sphere has %d vertices", len(small.Vertices))
+	}
+}
+
+func TestNewPyramid(t *testing.T) {
+	p := NewPyramid(2)
+	if len(p.Vertices) != 5 || len(p.Edges) != 8 || len(p.Faces) != 5 {
+		t.Errorf("pyramid = %d vertices, %d edges, %d faces; want 5, 8, 5",
+			len(p.Vertices), len(p.Edges), len(p.Faces))
+	}
+	assertOutward(t, "pyramid", p)
+}
+
+func TestNewTetrahedron(t *testing.T) {
+	tet := NewTetrahedron(3)
+	if len(tet.Vertices) != 4 || len(tet.Edges) != 6 || len(tet.Faces) != 4 {
+		t.Errorf("tetrahedron = %d vertices, %d edges, %d faces; want 4, 6, 4",
+			len(tet.Vertices), len(tet.Edges), len(tet.Faces))
+	}
+	for _, e := range tet.Edges {
+		d := sub(tet.Vertices[e[0]], tet.Vertices[e[1]])
+		if l := math.Sqrt(dot(d, d)); math.Abs(l-3) > 1e-9 {
+			t.Errorf("edge %v has length %f, want 3", e, l)
+		}
+	}
+	assertOutward(t, "tetrahedron", tet)
+}
+
+// assertOutward checks every face normal points away from the origin.
+func assertOutward(t *testing.T, name string, m *Mesh) {
+	t.Helper()
+	for i, face := range m.Faces {
+		a, b, c := m.Vertices[face[0]], m.Vertices[face[1]], m.Vertices[face[2]]
+		if dot(cross(sub(b, a), sub(c, a)), a) <= 0 {
+			t.Errorf("%s: face %d faces inward", name, i)
+		}
+	}
+}
@@ -84,6 +84,7 @@ func RotateZ(p Point3D, angle float64) Point3D {
 type Cube struct {
 	Vertices []Point3D
 	Edges    [][2]int // Pairs of vertex indices
+	Faces    [][]int  // Vertex indices of each face, wound so normals point outward
 	Size     float64
 }
 
@@ -110,32 +111,35 @@ func NewCube(size float64) *Cube {
 			// Connecting edges
 			{0, 4}, {1, 5}, {2, 6}, {3, 7},
 		},
+		Faces: [][]int{
+			{0, 3, 2, 1}, // Front (-Z)
+			{4, 5, 6, 7}, // Back (+Z)
+			{0, 4, 7, 3}, // Left (-X)
+			{1, 2, 6, 5}, // Right (+X)
+			{0, 1, 5, 4}, // Top (-Y)
+			{3, 7, 6, 2}, // Bottom (+Y)
+		},
 	}
 }
 
 // Rotate rotates all vertices of the cube.
 func (c *Cube) Rotate(angleX, angleY, angleZ float64) {
-	for i := range c.Vertices {
-		c.Vertices[i] = RotateX(c.Vertices[i], angleX)
-		c.Vertices[i] = RotateY(c.Vertices[i], angleY)
-		c.Vertices[i] = RotateZ(c.Vertices[i], angleZ)
-	}
+	rotateAll(c.Vertices, angleX, angleY, angleZ)
 }
 
 // Draw renders the cube wireframe onto the framebuffer.
 func (c *Cube) Draw(fb *eziog500.FrameBuffer, cam *Camera, on bool) {
-	// Project all vertices
-	projected := make([]Point2D, len(c.Vertices))
-	for i, v := range c.Vertices {
-		projected[i] = cam.Project(v)
-	}
+	drawEdges(fb, cam, c.Vertices, c.Edges, on)
+}
 
-	// Draw all edges
-	for _, edge := range c.Edges {
-		p1 := projected[edge[0]]
-		p2 := projected[edge[1]]
-		fb.DrawLine(p1.X, p1.Y, p2.X, p2.Y, on)
-	}
+// DrawFilled renders the cube's visible faces, shaded with pattern.
+func (c *Cube) DrawFilled(fb *eziog500.FrameBuffer, cam *Camera, pattern FillPattern) {
+	drawFaces(fb, cam, c.Vertices, c.Faces, pattern)
+}
+
+// VisibleFaces returns the indices of faces turned towards the camera.
+func (c *Cube) VisibleFaces(cam *Camera) []int {
+	return visibleFaces(cam, c.Vertices, c.Faces)
 }
 
 // CubeCopy returns a copy of the cube for animation.
@@ -143,8 +147,34 @@ func (c *Cube) Copy() *Cube {
 	newCube := &Cube{
 		Size:     c.Size,
 		Vertices: make([]Point3D, len(c.Vertices)),
-		Edges:    c.Edges, // Edges are shared (immutable indices)
+		Edges:    c.Edges, // Edges and faces are shared (immutable indices)
+		Faces:    c.Faces,
 	}
 	copy(newCube.Vertices, c.Vertices)
 	return newCube
 }
+
+// rotateAll rotates vertices in place around X, then Y, then Z.
+func rotateAll(vertices []Point3D, angleX, angleY, angleZ float64) {
+	for i := range vertices {
+		vertices[i] = RotateX(vertices[i], angleX)
+		vertices[i] = RotateY(vertices[i], angleY)
+		vertices[i] = RotateZ(vertices[i], angleZ)
+	}
+}
+
+// drawEdges projects vertices and draws a line for each edge.
+func drawEdges(fb *eziog500.FrameBuffer, cam *Camera, vertices []Point3D, edges [][2]int, on bool) {
+	// Project all vertices
+	projected := make([]Point2D, len(vertices))
+	for i, v := range vertices {
+		projected[i] = cam.Project(v)
+	}
+
+	// Draw all edges
+	for _, edge := range edges {
+		p1 := projected[edge[0]]
+		p2 := projected[edge[1]]
+		fb.DrawLine(p1.X, p1.Y, p2.X, p2.Y, on)
+	}
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// BarChartOther is the label of the bar that sums values past MaxBars.
+const BarChartOther = "OTHER"
+
+// BarValue is one labelled bar of a BarChart.
+type BarValue struct {
+	Label string
+	Value float64
+}
+
+// BarChart draws a horizontal bar per value, scaled so the largest value
+// fills the bar area, with labels in the small font on the left. Values are
+// shown in order, so sort them first to keep the largest ones visible.
+type BarChart struct {
+	Values    []BarValue
+	MaxBars   int                  // Bars to show, the last summing the rest (0 shows as many as fit)
+	BarHeight int                  // Pixels per bar; rows are 2 pixels apart
+	Format    func(float64) string // Optional value text drawn right of the bars
+	width     int
+	height    int
+}
+
+// NewBarChart creates an empty bar chart of the given size.
+func NewBarChart(width, height int) *BarChart {
+	return &BarChart{
+		BarHeight: font.SmallFont.Height(),
+		width:     width,
+		height:    height,
+	}
+}
+
+func (c *BarChart) Width() int  { return c.width }
+func (c *BarChart) Height() int { return c.height }
+
+// rows returns how many bars fit in the chart's height.
+func (c *BarChart) rows() int {
+	return (c.height + 2) / (c.BarHeight + 2)
+}
+
+// Bars returns the bars to draw: Values, cut down to MaxBars or the rows
+// that fit, with the values that don't fit summed into an OTHER bar.
+func (c *BarChart) Bars() []BarValue {
+	limit := c.rows()
+	if c.MaxBars > 0 {
+		limit = min(limit, c.MaxBars)
+	}
+	if len(c.Values) <= limit {
+		return c.Values
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	bars := append([]BarValue(nil), c.Values[:limit-1]...)
+	other := BarValue{Label: BarChartOther}
+	for _, v := range c.Values[limit-1:] {
+		other.Value += v.Value
+	}
+	return append(bars, other)
+}
+
+// Render draws the bars with the chart's top-left corner at (x, y).
+func (c *BarChart) Render(fb *eziog500.FrameBuffer, x, y int) {
+	sf := font.SmallFont
+	bars := c.Bars()
+
+	largest := 0.0
+	labelW, valueW := 0, 0
+	for _, b := range bars {
+		largest = max(largest, b.Value)
+		labelW = max(labelW, font.MeasureText(sf, b.Label))
+		if c.Format != nil {
+			valueW = max(valueW, font.MeasureText(sf, c.Format(b.Value)))
+		}
+	}
+	barX := x + labelW + 2
+	barW := c.barWidth(labelW, valueW)
+
+	for i, b := range bars {
+		rowY := y + i*(c.BarHeight+2)
+		textY := rowY + (c.BarHeight-sf.Height())/2
+		font.RenderText(fb, sf, x, textY, b.Label)
+
+		if largest > 0 && b.Value > 0 {
+			w := max(1, int(float64(barW)*b.Value/largest+0.5))
+			fb.FillRect(barX, rowY, w, c.BarHeight, true)
+		}
+		if c.Format != nil {
+			text := c.Format(b.Value)
+			font.RenderText(fb, sf, x+c.width-font.MeasureText(sf, text), textY, text)
+		}
+	}
+}
+
+// barWidth returns the width of a full bar given the widest label and value
+// text.
+func (c *BarChart) barWidth(labelW, valueW int) int {
+	w := c.width - labelW - 2
+	if valueW > 0 {
+		w -= valueW + 2
+	}
+	return max(0, w)
+}
@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// litInRow counts lit pixels in row y from x0 to the right edge.
+func litInRow(fb *eziog500.FrameBuffer, x0, y int) int {
+	n := 0
+	for x := x0; x < eziog500.Width; x++ {
+		if fb.GetPixel(x, y) {
+			n++
+		}
+	}
+	return n
+}
+
+func TestBarChart_LargestIsFullWidth(t *testing.T) {
+	c := NewBarChart(100, 40)
+	c.Values = []BarValue{{"LAN", 10}, {"WAN", 40}, {"DMZ", 20}}
+	fb := eziog500.NewFrameBuffer()
+	c.Render(fb, 0, 0)
+
+	labelW := 0
+	for _, v := range c.Values {
+		labelW = max(labelW, font.MeasureText(font.SmallFont, v.Label))
+	}
+	barX := labelW + 2
+	full := 100 - barX
+
+	rowY := func(i int) int { return i*(c.BarHeight+2) + c.BarHeight/2 }
+	if got := litInRow(fb, barX, rowY(1)); got != full {
+		t.Errorf("Largest bar is %d pixels wide, want %d", got, full)
+	}
+	if got, want := litInRow(fb, barX, rowY(0)), full/4; got < want-1 || got > want+1 {
+		t.Errorf("Quarter bar is %d pixels wide, want about %d", got, want)
+	}
+}
+
+func TestBarChart_Other(t *testing.T) {
+	c := NewBarChart(100, 60)
+	c.MaxBars = 3
+	c.Values = []BarValue{{"A", 5}, {"B", 4}, {"C", 3}, {"D", 2}, {"E", 1}}
+
+	bars := c.Bars()
+	if len(bars) != 3 {
+		t.Fatalf("Expected 3 bars, got %d", len(bars))
+	}
+	if last := bars[2]; last.Label != BarChartOther || last.Value != 6 {
+		t.Errorf("Expected OTHER bar summing 6, got %+v", last)
+	}
+
+	// Without MaxBars the height limits the bars
+	c.MaxBars = 0
+	c.height = 2*(c.BarHeight+2) - 2
+	if bars := c.Bars(); len(bars) != 2 || bars[1].Value != 10 {
+		t.Errorf("Expected 2 bars fitting the height, got %+v", bars)
+	}
+}
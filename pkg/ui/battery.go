@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Battery icon layout in pixels: a body with a nub on the right end.
+const (
+	batteryBodyW = 16
+	batteryNubW  = 2
+	batteryH     = 9
+	batteryNubH  = 3
+)
+
+// batteryBolt is the charging bolt, drawn inverted over the middle of the
+// body so it shows against both the fill and the empty part.
+var batteryBolt = []string{
+	"..#.",
+	".##.",
+	"####",
+	".##.",
+	".#..",
+}
+
+// BatteryIcon draws a battery outline filled in proportion to Charge, with
+// a lightning bolt when Charging.
+type BatteryIcon struct {
+	Charge   float64 // 0 to 100
+	Charging bool
+}
+
+func (b *BatteryIcon) Width() int  { return batteryBodyW + batteryNubW }
+func (b *BatteryIcon) Height() int { return batteryH }
+
+// FillWidth returns how many of the body's inner columns are filled.
+func (b *BatteryIcon) FillWidth() int {
+	inner := batteryBodyW - 4
+	charge := max(0, min(b.Charge, 100))
+	return int(float64(inner)*charge/100 + 0.5)
+}
+
+// Render draws the battery with its top-left corner at (x, y).
+func (b *BatteryIcon) Render(fb *eziog500.FrameBuffer, x, y int) {
+	fb.DrawRect(x, y, batteryBodyW, batteryH, true)
+	fb.FillRect(x+batteryBodyW, y+(batteryH-batteryNubH)/2, batteryNubW, batteryNubH, true)
+
+	// One pixel gap inside the outline
+	if w := b.FillWidth(); w > 0 {
+		fb.FillRect(x+2, y+2, w, batteryH-4, true)
+	}
+
+	if b.Charging {
+		bx := x + (batteryBodyW-len(batteryBolt[0]))/2
+		by := y + 2
+		for row, line := range batteryBolt {
+			for col, c := range line {
+				if c == '#' {
+					fb.Invert(bx+col, by+row)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestBatteryIcon_Fill(t *testing.T) {
+	inner := batteryBodyW - 4
+	for _, tt := range []struct {
+		charge float64
+		want   int
+	}{
+		{0, 0},
+		{50, inner / 2},
+		{100, inner},
+	} {
+		b := &BatteryIcon{Charge: tt.charge}
+		fb := eziog500.NewFrameBuffer()
+		b.Render(fb, 0, 0)
+
+		// Count the fill along the middle row, inside the outline and gap
+		got := 0
+		for x := 2; x < batteryBodyW-2; x++ {
+			if fb.GetPixel(x, batteryH/2) {
+				got++
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%.0f%%: fill is %d pixels wide, want %d", tt.charge, got, tt.want)
+		}
+		if got != b.FillWidth() {
+			t.Errorf("%.0f%%: FillWidth() = %d, drawn %d", tt.charge, b.FillWidth(), got)
+		}
+	}
+}
+
+func TestBatteryIcon_ChargingBolt(t *testing.T) {
+	plain, charging := eziog500.NewFrameBuffer(), eziog500.NewFrameBuffer()
+	(&BatteryIcon{Charge: 50}).Render(plain, 0, 0)
+	(&BatteryIcon{Charge: 50, Charging: true}).Render(charging, 0, 0)
+	if plain.ToDeviceFormat() == charging.ToDeviceFormat() {
+		t.Error("Expected the charging bolt to change the icon")
+	}
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Bitmap is a 1-bit image of any size, such as a 16x16 logo or status glyph.
+// Data is packed like Icon's: column by column, each column a byte per 8
+// rows with bit 0 at the top, so column x, row y is bit y%8 of
+// Data[x*((H+7)/8) + y/8].
+type Bitmap struct {
+	W, H int
+	Data []byte
+}
+
+// NewBitmap creates a blank bitmap of the given size.
+func NewBitmap(w, h int) *Bitmap {
+	return &Bitmap{W: w, H: h, Data: make([]byte, w*((h+7)/8))}
+}
+
+// BitmapFromImage converts img into a bitmap of the same size, lighting
+// every pixel whose luminance is at or above threshold as
+// eziog500.FromImage does.
+func BitmapFromImage(img image.Image, threshold uint8) *Bitmap {
+	b := img.Bounds()
+	bm := NewBitmap(b.Dx(), b.Dy())
+	for y := 0; y < bm.H; y++ {
+		for x := 0; x < bm.W; x++ {
+			g := color.GrayModel.Convert(img.At(b.Min.X+x, b.Min.Y+y)).(color.Gray)
+			bm.Set(x, y, g.Y >= threshold)
+		}
+	}
+	return bm
+}
+
+func (b *Bitmap) Width() int  { return b.W }
+func (b *Bitmap) Height() int { return b.H }
+
+// index returns the byte and bit holding (x, y), or -1 if it is outside
+// the bitmap.
+func (b *Bitmap) index(x, y int) (int, byte) {
+	if x < 0 || x >= b.W || y < 0 || y >= b.H {
+		return -1, 0
+	}
+	i := x*((b.H+7)/8) + y/8
+	if i >= len(b.Data) {
+		return -1, 0
+	}
+	return i, 1 << (y % 8)
+}
+
+// Get reports whether the pixel at (x, y) is lit. Pixels outside the bitmap
+// are unlit.
+func (b *Bitmap) Get(x, y int) bool {
+	i, bit := b.index(x, y)
+	return i >= 0 && b.Data[i]&bit != 0
+}
+
+// Set lights or clears the pixel at (x, y), ignoring pixels outside the
+// bitmap.
+func (b *Bitmap) Set(x, y int, on bool) {
+	i, bit := b.index(x, y)
+	switch {
+	case i < 0:
+	case on:
+		b.Data[i] |= bit
+	default:
+		b.Data[i] &^= bit
+	}
+}
+
+// Render draws the bitmap's lit pixels with its top-left corner at (x, y).
+func (b *Bitmap) Render(fb *eziog500.FrameBuffer, x, y int) {
+	for by := 0; by < b.H; by++ {
+		for bx := 0; bx < b.W; bx++ {
+			if b.Get(bx, by) {
+				fb.SetPixel(x+bx, y+by, true)
+			}
+		}
+	}
+}
+
+// Bitmap returns the icon as an 8x8 bitmap.
+func (i *Icon) Bitmap() *Bitmap {
+	return &Bitmap{W: 8, H: 8, Data: append([]byte(nil), i.Data[:]...)}
+}
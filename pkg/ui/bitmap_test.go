@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestBitmap_Render16(t *testing.T) {
+	// A 16x16 frame: every edge pixel lit
+	bm := NewBitmap(16, 16)
+	if len(bm.Data) != 32 {
+		t.Fatalf("16x16 bitmap holds %d bytes, want 32", len(bm.Data))
+	}
+	for i := 0; i < 16; i++ {
+		bm.Set(i, 0, true)
+		bm.Set(i, 15, true)
+		bm.Set(0, i, true)
+		bm.Set(15, i, true)
+	}
+
+	fb := eziog500.NewFrameBuffer()
+	bm.Render(fb, 20, 10)
+	for _, p := range [][2]int{{20, 10}, {35, 10}, {20, 25}, {35, 25}} {
+		if !fb.GetPixel(p[0], p[1]) {
+			t.Errorf("Expected corner %v lit", p)
+		}
+	}
+	for _, p := range [][2]int{{19, 10}, {36, 25}, {20, 26}, {28, 18}} {
+		if fb.GetPixel(p[0], p[1]) {
+			t.Errorf("Expected %v unlit", p)
+		}
+	}
+	if got := fb.CountSetPixels(); got != 60 {
+		t.Errorf("Drew %d pixels, want 60", got)
+	}
+}
+
+func TestBitmap_MatchesIcon(t *testing.T) {
+	want, got := eziog500.NewFrameBuffer(), eziog500.NewFrameBuffer()
+	IconCheck.Render(want, 3, 5)
+	IconCheck.Bitmap().Render(got, 3, 5)
+	if want.ToDeviceFormat() != got.ToDeviceFormat() {
+		t.Error("Expected an icon's bitmap to draw the same pixels")
+	}
+}
+
+func TestBitmapFromImage(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 12, 10))
+	img.SetGray(0, 0, color.Gray{Y: 255})
+	img.SetGray(11, 9, color.Gray{Y: 200})
+	img.SetGray(5, 5, color.Gray{Y: 100})
+
+	bm := BitmapFromImage(img, 128)
+	if bm.Width() != 12 || bm.Height() != 10 {
+		t.Fatalf("Bitmap is %dx%d, want 12x10", bm.Width(), bm.Height())
+	}
+	if !bm.Get(0, 0) || !bm.Get(11, 9) || bm.Get(5, 5) {
+		t.Error("Expected pixels at or above the threshold to be lit")
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// Clock shows the current time as HH:MM:SS, optionally with the date below.
+type Clock struct {
+	Scale    int  // Pixel scale of the time digits (1 or 2)
+	Hour12   bool // 12-hour time with an AM/PM suffix instead of 24-hour
+	ShowDate bool
+
+	// Now returns the time to display (defaults to time.Now).
+	Now func() time.Time
+}
+
+// NewClock creates a 24-hour clock at the given scale.
+func NewClock(scale int) *Clock {
+	return &Clock{Scale: scale}
+}
+
+// clockGap is the space between the time and the AM/PM suffix or date.
+const clockGap = 2
+
+func (c *Clock) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Clock) scale() int {
+	if c.Scale < 1 {
+		return 1
+	}
+	return c.Scale
+}
+
+// FormatTime returns the time digits and the AM/PM suffix (empty in 24-hour mode).
+func (c *Clock) FormatTime(t time.Time) (digits, suffix string) {
+	if c.Hour12 {
+		return t.Format("3:04:05"), t.Format("PM")
+	}
+	return t.Format("15:04:05"), ""
+}
+
+// FormatDate returns the date line shown when ShowDate is set.
+func (c *Clock) FormatDate(t time.Time) string {
+	return t.Format("Mon 2006-01-02")
+}
+
+// Render draws the time, with the suffix at normal size beside it and the
+// date underneath.
+func (c *Clock) Render(fb *eziog500.FrameBuffer, x, y int) {
+	f := font.BuiltinFont
+	t := c.now()
+
+	digits, suffix := c.FormatTime(t)
+	end := font.RenderTextScaled(fb, f, x, y, digits, c.scale())
+	if suffix != "" {
+		font.RenderText(fb, f, end+clockGap, y, suffix)
+	}
+	if c.ShowDate {
+		font.RenderText(fb, f, x, y+f.Height()*c.scale()+clockGap, c.FormatDate(t))
+	}
+}
+
+// Width is the widest the clock can be, so it doesn't change size as the
+// digits change.
+func (c *Clock) Width() int {
+	f := font.BuiltinFont
+	sample := time.Date(2000, 1, 2, 12, 0, 0, 0, time.UTC)
+	digits, suffix := c.FormatTime(sample)
+	w := widestDigits(f, digits) * c.scale()
+	if suffix != "" {
+		w += clockGap + font.MeasureText(f, suffix)
+	}
+	if c.ShowDate {
+		for day := 0; day < 7; day++ {
+			date := c.FormatDate(sample.AddDate(0, 0, day))
+			if dw := widestDigits(f, date); dw > w {
+				w = dw
+			}
+		}
+	}
+	return w
+}
+
+// widestDigits measures text with every digit replaced by the widest digit.
+func widestDigits(f font.Font, text string) int {
+	widest := 0
+	for r := '0'; r <= '9'; r++ {
+		if w := f.GetWidth(r); w > widest {
+			widest = w
+		}
+	}
+	width := 0
+	for _, r := range text {
+		if r >= '0' && r <= '9' {
+			width += widest
+		} else {
+			width += f.GetWidth(r)
+		}
+	}
+	return width
+}
+
+func (c *Clock) Height() int {
+	h := font.BuiltinFont.Height() * c.scale()
+	if c.ShowDate {
+		h += clockGap + font.BuiltinFont.Height()
+	}
+	return h
+}
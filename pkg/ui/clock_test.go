@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestClock_FormatTime(t *testing.T) {
+	afternoon := time.Date(2024, 3, 9, 15, 4, 5, 0, time.UTC)
+	midnight := time.Date(2024, 3, 9, 0, 30, 0, 0, time.UTC)
+
+	c := NewClock(2)
+	if d, s := c.FormatTime(afternoon); d != "15:04:05" || s != "" {
+		t.Errorf("24h afternoon: got %q %q", d, s)
+	}
+	if d, _ := c.FormatTime(midnight); d != "00:30:00" {
+		t.Errorf("24h midnight: got %q", d)
+	}
+
+	c.Hour12 = true
+	if d, s := c.FormatTime(afternoon); d != "3:04:05" || s != "PM" {
+		t.Errorf("12h afternoon: got %q %q", d, s)
+	}
+	if d, s := c.FormatTime(midnight); d != "12:30:00" || s != "AM" {
+		t.Errorf("12h midnight: got %q %q", d, s)
+	}
+
+	if got := c.FormatDate(afternoon); got != "Sat 2024-03-09" {
+		t.Errorf("Date: got %q", got)
+	}
+}
+
+func TestClock_RenderWithinBounds(t *testing.T) {
+	c := &Clock{Scale: 2, Hour12: true, ShowDate: true, Now: func() time.Time {
+		return time.Date(2024, 3, 9, 23, 59, 59, 0, time.UTC)
+	}}
+	fb := eziog500.NewFrameBuffer()
+	c.Render(fb, 0, 0)
+
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if fb.GetPixel(x, y) && (x >= c.Width() || y >= c.Height()) {
+				t.Fatalf("Pixel (%d, %d) outside %dx%d", x, y, c.Width(), c.Height())
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// Dialog layout in pixels.
+const (
+	dialogPad       = 4 // Between the border and the content
+	dialogMinWidth  = 60
+	dialogLineGap   = 1 // Between body lines
+	dialogButtonGap = 8
+)
+
+// Dialog is a message box: a rounded, bordered box with an optional
+// inverted title bar, word-wrapped body text, and up to two buttons. It
+// sizes itself to its content within the display, dropping body lines that
+// don't fit. The caller moves the focus with Focus and acts on Focused.
+type Dialog struct {
+	Title   string
+	Body    string
+	Buttons []string // At most two are drawn
+	focused int
+}
+
+// NewDialog creates a dialog with the first button focused.
+func NewDialog(title, body string, buttons ...string) *Dialog {
+	return &Dialog{Title: title, Body: body, Buttons: buttons}
+}
+
+// Focused returns the index of the focused button, or -1 if there are none.
+func (d *Dialog) Focused() int {
+	if len(d.buttons()) == 0 {
+		return -1
+	}
+	return d.focused
+}
+
+// SetFocus focuses button i if it exists.
+func (d *Dialog) SetFocus(i int) {
+	if i >= 0 && i < len(d.buttons()) {
+		d.focused = i
+	}
+}
+
+// Focus moves the focus by delta buttons, wrapping around.
+func (d *Dialog) Focus(delta int) {
+	if n := len(d.buttons()); n > 0 {
+		d.focused = ((d.focused+delta)%n + n) % n
+	}
+}
+
+// buttons returns the buttons that are drawn.
+func (d *Dialog) buttons() []string {
+	return d.Buttons[:min(len(d.Buttons), 2)]
+}
+
+// titleHeight returns the height of the title bar, 0 without a title.
+func (d *Dialog) titleHeight() int {
+	if d.Title == "" {
+		return 0
+	}
+	return font.BuiltinFont.Height() + 2
+}
+
+// buttonRowHeight returns the space the buttons take, 0 without buttons.
+func (d *Dialog) buttonRowHeight() int {
+	if len(d.buttons()) == 0 {
+		return 0
+	}
+	return NewButton("").Height() + 2
+}
+
+// bodyLines returns the wrapped body lines that fit on the display.
+func (d *Dialog) bodyLines() []string {
+	f := font.BuiltinFont
+	lines := font.WrapText(f, d.Body, eziog500.Width-2-2*dialogPad)
+	avail := eziog500.Height - 2 - 2*dialogPad - d.titleHeight() - d.buttonRowHeight()
+	return lines[:min(len(lines), max(0, avail/(f.LineHeight()+dialogLineGap)))]
+}
+
+// buttonsWidth returns the width of the button row.
+func (d *Dialog) buttonsWidth() int {
+	w := 0
+	for i, label := range d.buttons() {
+		if i > 0 {
+			w += dialogButtonGap
+		}
+		w += NewButton(label).Width()
+	}
+	return w
+}
+
+func (d *Dialog) Width() int {
+	f := font.BuiltinFont
+	w := max(dialogMinWidth, font.MeasureText(f, d.Title)+4, d.buttonsWidth())
+	for _, line := range d.bodyLines() {
+		w = max(w, font.MeasureText(f, line))
+	}
+	return min(eziog500.Width, w+2+2*dialogPad)
+}
+
+func (d *Dialog) Height() int {
+	f := font.BuiltinFont
+	body := len(d.bodyLines()) * (f.LineHeight() + dialogLineGap)
+	return 2 + 2*dialogPad + d.titleHeight() + body + d.buttonRowHeight()
+}
+
+// Render draws the dialog with its top-left corner at (x, y), clearing the
+// area behind it.
+func (d *Dialog) Render(fb *eziog500.FrameBuffer, x, y int) {
+	f := font.BuiltinFont
+	w, h := d.Width(), d.Height()
+
+	fb.FillRect(x, y, w, h, false)
+	fb.DrawRoundedRect(x, y, w, h, 2, true)
+
+	cy := y + 1
+	if d.Title != "" {
+		fb.FillRect(x+1, cy, w-2, d.titleHeight(), true)
+		title := font.TruncateText(f, d.Title, w-6)
+		font.RenderTextInverted(fb, f, x+(w-font.MeasureText(f, title))/2, cy+1, title)
+		cy += d.titleHeight()
+	}
+	cy += dialogPad
+
+	for _, line := range d.bodyLines() {
+		font.RenderTextAligned(fb, f, x, cy, w, f.Height(), line, font.AlignCenter, font.AlignTop)
+		cy += f.LineHeight() + dialogLineGap
+	}
+
+	bx := x + (w-d.buttonsWidth())/2
+	by := y + h - 1 - dialogPad - NewButton("").Height()
+	for i, label := range d.buttons() {
+		btn := NewButton(label)
+		btn.Selected = i == d.focused
+		btn.Render(fb, bx, by)
+		bx += btn.Width() + dialogButtonGap
+	}
+}
+
+// RenderCentered draws the dialog in the middle of the display.
+func (d *Dialog) RenderCentered(fb *eziog500.FrameBuffer) {
+	d.Render(fb, (eziog500.Width-d.Width())/2, (eziog500.Height-d.Height())/2)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestDialog_FitsDisplay(t *testing.T) {
+	dialogs := []*Dialog{
+		NewDialog("REBOOT", "Restart the firewall now? Active connections will be dropped.", "YES", "NO"),
+		NewDialog("", "Gateway WAN_DHCP is down", "OK"),
+		NewDialog("LOG", "A long alert with far more text than a 128x64 display could possibly show in one box, so the body is cut to the lines that fit."),
+	}
+	for _, d := range dialogs {
+		if d.Width() > eziog500.Width || d.Height() > eziog500.Height {
+			t.Errorf("%q: %dx%d does not fit the display", d.Body, d.Width(), d.Height())
+		}
+		if len(d.bodyLines()) == 0 {
+			t.Errorf("%q: no body lines shown", d.Body)
+		}
+
+		fb := eziog500.NewFrameBuffer()
+		d.RenderCentered(fb)
+		x, y := (eziog500.Width-d.Width())/2, (eziog500.Height-d.Height())/2
+		// The border's straight edges are drawn
+		if !fb.GetPixel(x+d.Width()/2, y+d.Height()-1) || !fb.GetPixel(x, y+d.Height()/2) {
+			t.Errorf("%q: border not drawn at the computed bounds", d.Body)
+		}
+	}
+}
+
+func TestDialog_Focus(t *testing.T) {
+	d := NewDialog("", "Apply?", "YES", "NO")
+	if d.Focused() != 0 {
+		t.Errorf("Expected the first button focused, got %d", d.Focused())
+	}
+	d.Focus(1)
+	d.Focus(1)
+	d.Focus(-1)
+	if d.Focused() != 1 {
+		t.Errorf("Expected focus to wrap to button 1, got %d", d.Focused())
+	}
+	if NewDialog("", "Done").Focused() != -1 {
+		t.Error("Expected -1 without buttons")
+	}
+}
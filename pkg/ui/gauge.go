@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// Gauge arc angles in degrees (0 = right, clockwise): a 270° dial open at
+// the bottom, running from bottom-left over the top to bottom-right.
+const (
+	GaugeStartAngle = 135.0
+	GaugeEndAngle   = GaugeStartAngle + 270
+)
+
+// Gauge is a radial dial with a needle pointing at Value between Min and Max.
+type Gauge struct {
+	Min, Max float64
+	Value    float64
+	Radius   int
+	Ticks    int // Number of tick marks along the arc (0 for none, 2+ to include both ends)
+}
+
+// NewGauge creates a 0-100 gauge of the given radius.
+func NewGauge(radius int) *Gauge {
+	return &Gauge{Max: 100, Radius: radius}
+}
+
+// NeedleAngle returns the needle direction in degrees, clamping Value to
+// Min..Max.
+func (g *Gauge) NeedleAngle() float64 {
+	frac := 0.0
+	if g.Max > g.Min {
+		frac = (g.Value - g.Min) / (g.Max - g.Min)
+	}
+	frac = math.Max(0, math.Min(1, frac))
+	return GaugeStartAngle + frac*(GaugeEndAngle-GaugeStartAngle)
+}
+
+// Render draws the arc, ticks, and needle with the dial's center at
+// (x+Radius, y+Radius).
+func (g *Gauge) Render(fb *eziog500.FrameBuffer, x, y int) {
+	cx, cy := x+g.Radius, y+g.Radius
+	fb.DrawArc(cx, cy, g.Radius, GaugeStartAngle, GaugeEndAngle, true)
+
+	for i := 0; i < g.Ticks && g.Ticks > 1; i++ {
+		a := GaugeStartAngle + float64(i)*(GaugeEndAngle-GaugeStartAngle)/float64(g.Ticks-1)
+		x1, y1 := polar(cx, cy, g.Radius-3, a)
+		x2, y2 := polar(cx, cy, g.Radius, a)
+		fb.DrawLine(x1, y1, x2, y2, true)
+	}
+
+	nx, ny := polar(cx, cy, g.Radius-2, g.NeedleAngle())
+	fb.DrawLine(cx, cy, nx, ny, true)
+	fb.FillCircle(cx, cy, 1, true)
+}
+
+// polar returns the point r pixels from (cx, cy) in direction deg.
+func polar(cx, cy, r int, deg float64) (int, int) {
+	rad := deg * math.Pi / 180
+	return cx + int(math.Round(float64(r)*math.Cos(rad))),
+		cy + int(math.Round(float64(r)*math.Sin(rad)))
+}
+
+func (g *Gauge) Width() int { return 2*g.Radius + 1 }
+
+// Height stops at the arc's ends, since the bottom of the dial is open.
+func (g *Gauge) Height() int {
+	return g.Radius + int(math.Ceil(float64(g.Radius)*math.Sin(math.Pi/4))) + 1
+}
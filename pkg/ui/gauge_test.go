@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestGauge_NeedleAngle(t *testing.T) {
+	g := NewGauge(20)
+
+	g.Value = g.Max
+	if a := g.NeedleAngle(); a != GaugeEndAngle {
+		t.Errorf("Value=Max should point to the arc end (%v), got %v", GaugeEndAngle, a)
+	}
+	g.Value = g.Min
+	if a := g.NeedleAngle(); a != GaugeStartAngle {
+		t.Errorf("Value=Min should point to the arc start (%v), got %v", GaugeStartAngle, a)
+	}
+	g.Value = 50
+	if a := g.NeedleAngle(); a != 270 {
+		t.Errorf("Midpoint should point straight up (270), got %v", a)
+	}
+	g.Value = 150
+	if a := g.NeedleAngle(); a != GaugeEndAngle {
+		t.Errorf("Values above Max should clamp, got %v", a)
+	}
+}
+
+func TestGauge_RenderNeedleAtMax(t *testing.T) {
+	g := NewGauge(20)
+	g.Value = g.Max
+	fb := eziog500.NewFrameBuffer()
+	g.Render(fb, 0, 0)
+
+	// At Max the needle points down-right (45°) from the center at (20, 20)
+	if !fb.GetPixel(30, 30) {
+		t.Error("Expected needle pixel toward the bottom-right")
+	}
+	// Nothing is drawn straight below the center (the dial is open there)
+	if fb.GetPixel(20, 40) {
+		t.Error("Arc should be open at the bottom")
+	}
+}
@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// LineStyle distinguishes graph series on the 1-bit display.
+type LineStyle int
+
+const (
+	LineSolid  LineStyle = iota // Every pixel drawn
+	LineDashed                  // 4 on, 2 off
+	LineDotted                  // Every other pixel
+)
+
+// On reports whether the pixel at the given step along a line is drawn.
+func (s LineStyle) On(step int) bool {
+	switch s {
+	case LineDashed:
+		return step%6 < 4
+	case LineDotted:
+		return step%2 == 0
+	default:
+		return true
+	}
+}
+
+// DrawStyledLine draws a line from (x1, y1) to (x2, y2) using Bresenham's
+// algorithm, skipping pixels according to the style. The returned step count
+// can be passed as start to continue the pattern across connected segments.
+func DrawStyledLine(fb *eziog500.FrameBuffer, x1, y1, x2, y2 int, style LineStyle, start int) int {
+	dx := x2 - x1
+	if dx < 0 {
+		dx = -dx
+	}
+	dy := y2 - y1
+	if dy > 0 {
+		dy = -dy
+	}
+	sx, sy := 1, 1
+	if x1 > x2 {
+		sx = -1
+	}
+	if y1 > y2 {
+		sy = -1
+	}
+	err := dx + dy
+	step := start
+
+	for {
+		if style.On(step) {
+			fb.SetPixel(x1, y1, true)
+		}
+		step++
+		if x1 == x2 && y1 == y2 {
+			break
+		}
+		e2 := 2 * err
+		if e2 >= dy {
+			err += dy
+			x1 += sx
+		}
+		if e2 <= dx {
+			err += dx
+			y1 += sy
+		}
+	}
+	return step
+}
+
+// DrawSparkline draws data as a line graph in the given box, auto-scaled to
+// the data's min/max. A flat series is drawn along the bottom edge.
+func DrawSparkline(fb *eziog500.FrameBuffer, x, y, w, h int, data []float64) {
+	min, max := dataRange(data)
+	DrawSparklineRange(fb, x, y, w, h, data, min, max)
+}
+
+// DrawSparklineRange draws data as a line graph in the given box, scaled to
+// a fixed min/max (values outside are clamped). Only the newest w samples
+// are drawn if there are more than fit.
+func DrawSparklineRange(fb *eziog500.FrameBuffer, x, y, w, h int, data []float64, min, max float64) {
+	if len(data) > w {
+		data = data[len(data)-w:]
+	}
+	chart := &LineChart{
+		Series: []Series{{Data: data, Style: LineSolid}},
+		W:      w,
+		H:      h,
+		Min:    min,
+		Max:    max,
+	}
+	chart.Render(fb, x, y)
+}
+
+// Sparkline is a small trend graph over a fixed number of recent samples.
+// Once full, each Push replaces the oldest sample.
+type Sparkline struct {
+	W, H    int
+	samples []float64 // Ring buffer
+	start   int       // Index of the oldest sample
+	count   int
+}
+
+// NewSparkline creates a sparkline keeping up to capacity samples, drawn in
+// a width x height box.
+func NewSparkline(capacity, width, height int) *Sparkline {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Sparkline{W: width, H: height, samples: make([]float64, capacity)}
+}
+
+// Push adds a sample, dropping the oldest if the buffer is full.
+func (s *Sparkline) Push(v float64) {
+	if s.count < len(s.samples) {
+		s.samples[(s.start+s.count)%len(s.samples)] = v
+		s.count++
+		return
+	}
+	s.samples[s.start] = v
+	s.start = (s.start + 1) % len(s.samples)
+}
+
+// Values returns the samples from oldest to newest.
+func (s *Sparkline) Values() []float64 {
+	out := make([]float64, s.count)
+	for i := range out {
+		out[i] = s.samples[(s.start+i)%len(s.samples)]
+	}
+	return out
+}
+
+// Render draws the samples auto-scaled to their min/max.
+func (s *Sparkline) Render(fb *eziog500.FrameBuffer, x, y int) {
+	DrawSparkline(fb, x, y, s.W, s.H, s.Values())
+}
+
+func (s *Sparkline) Width() int  { return s.W }
+func (s *Sparkline) Height() int { return s.H }
+
+// dataRange returns the min and max of data (0, 0 if empty).
+func dataRange(data []float64) (min, max float64) {
+	for i, v := range data {
+		if i == 0 || v < min {
+			min = v
+		}
+		if i == 0 || v > max {
+			max = v
+		}
+	}
+	return min, max
+}
+
+// Series is a labeled data series drawn with a line style.
+type Series struct {
+	Label string
+	Data  []float64
+	Style LineStyle
+}
+
+// LineChart plots one or more series in a shared coordinate space.
+// All series are scaled to the same min/max so they can be compared.
+type LineChart struct {
+	Series []Series
+	W, H   int
+	Min    float64 // Lower bound of the Y axis
+	Max    float64 // Upper bound of the Y axis (Min == Max means auto-scale)
+}
+
+// NewLineChart creates an auto-scaling line chart of the given size.
+func NewLineChart(width, height int, series ...Series) *LineChart {
+	return &LineChart{Series: series, W: width, H: height}
+}
+
+// Bounds returns the Y axis range used for rendering.
+func (c *LineChart) Bounds() (min, max float64) {
+	if c.Min != c.Max {
+		return c.Min, c.Max
+	}
+	var all []float64
+	for _, s := range c.Series {
+		all = append(all, s.Data...)
+	}
+	return dataRange(all)
+}
+
+// Render draws all series into the chart area.
+func (c *LineChart) Render(fb *eziog500.FrameBuffer, x, y int) {
+	min, max := c.Bounds()
+	for _, s := range c.Series {
+		n := len(s.Data)
+		if n == 0 {
+			continue
+		}
+		step := 0
+		prevX, prevY := x, c.valueY(s.Data[0], y, min, max)
+		if n == 1 {
+			DrawStyledLine(fb, x, prevY, x+c.W-1, prevY, s.Style, 0)
+			continue
+		}
+		for i := 1; i < n; i++ {
+			px := x + i*(c.W-1)/(n-1)
+			py := c.valueY(s.Data[i], y, min, max)
+			// Continue the pattern without redrawing the shared endpoint
+			step = DrawStyledLine(fb, prevX, prevY, px, py, s.Style, step) - 1
+			prevX, prevY = px, py
+		}
+	}
+}
+
+// valueY maps a value to a pixel row inside the chart (larger values higher).
+func (c *LineChart) valueY(v float64, y int, min, max float64) int {
+	if max <= min {
+		return y + c.H - 1
+	}
+	if v < min {
+		v = min
+	}
+	if v > max {
+		v = max
+	}
+	return y + c.H - 1 - int((v-min)/(max-min)*float64(c.H-1)+0.5)
+}
+
+func (c *LineChart) Width() int  { return c.W }
+func (c *LineChart) Height() int { return c.H }
+
+// legendSample is the width of the line sample drawn before each label.
+const legendSample = 9
+
+// Legend maps each series' line style to its label, in a single row.
+type Legend struct {
+	Series []Series
+}
+
+// NewLegend creates a legend for the given series.
+func NewLegend(series ...Series) *Legend {
+	return &Legend{Series: series}
+}
+
+// Render draws a line sample followed by the label for each series.
+func (l *Legend) Render(fb *eziog500.FrameBuffer, x, y int) {
+	f := font.SmallFont
+	curX := x
+	midY := y + f.Height()/2 - 1
+	for _, s := range l.Series {
+		DrawStyledLine(fb, curX, midY, curX+legendSample-1, midY, s.Style, 0)
+		curX += legendSample + 2
+		curX = font.RenderText(fb, f, curX, y, s.Label) + 3
+	}
+}
+
+func (l *Legend) Width() int {
+	w := 0
+	for _, s := range l.Series {
+		w += legendSample + 2 + font.MeasureText(font.SmallFont, s.Label) + 3
+	}
+	if w > 0 {
+		w -= 3
+	}
+	return w
+}
+
+func (l *Legend) Height() int { return font.SmallFont.Height() }
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// rowPattern returns the on/off pattern of a row between x0 and x1 inclusive.
+func rowPattern(fb *eziog500.FrameBuffer, y, x0, x1 int) []bool {
+	var p []bool
+	for x := x0; x <= x1; x++ {
+		p = append(p, fb.GetPixel(x, y))
+	}
+	return p
+}
+
+func TestLineChart_SeriesStyles(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+
+	// Three flat series at distinct levels, each with its own style
+	chart := NewLineChart(60, 21,
+		Series{Label: "CPU", Data: []float64{100, 100, 100}, Style: LineSolid},
+		Series{Label: "TX", Data: []float64{50, 50, 50}, Style: LineDashed},
+		Series{Label: "RX", Data: []float64{0, 0, 0}, Style: LineDotted},
+	)
+	chart.Render(fb, 0, 0)
+
+	rows := map[LineStyle]int{LineSolid: 0, LineDashed: 10, LineDotted: 20}
+	for style, y := range rows {
+		for x, on := range rowPattern(fb, y, 0, 59) {
+			if on != style.On(x) {
+				t.Errorf("Style %d: pixel (%d,%d) = %v, want %v", style, x, y, on, style.On(x))
+				break
+			}
+		}
+	}
+}
+
+func TestLineChart_EmptySeries(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	chart := NewLineChart(40, 20, Series{Label: "CPU"})
+	chart.Render(fb, 0, 0) // Should not panic
+
+	if fb.CountSetPixels() != 0 {
+		t.Error("Empty series should not draw anything")
+	}
+}
+
+func TestLegend_LabelsSeries(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+	legend := NewLegend(
+		Series{Label: "CPU", Style: LineSolid},
+		Series{Label: "TX", Style: LineDashed},
+	)
+	legend.Render(fb, 0, 0)
+
+	midY := 2
+	// First sample is solid
+	for x, on := range rowPattern(fb, midY, 0, legendSample-1) {
+		if !on {
+			t.Errorf("Solid legend sample missing pixel at x=%d", x)
+		}
+	}
+
+	// Second sample starts after the first label and uses the dashed pattern
+	secondX := legendSample + 2 + font.MeasureText(font.SmallFont, "CPU") + 3
+	for i, on := range rowPattern(fb, midY, secondX, secondX+legendSample-1) {
+		if on != LineDashed.On(i) {
+			t.Errorf("Dashed legend sample wrong at offset %d", i)
+		}
+	}
+
+	// Labels are drawn after each sample
+	if !hasPixels(fb, legendSample+2, secondX-3) {
+		t.Error("First label should be rendered")
+	}
+	if !hasPixels(fb, secondX+legendSample+2, legend.Width()) {
+		t.Error("Second label should be rendered")
+	}
+}
+
+func hasPixels(fb *eziog500.FrameBuffer, x0, x1 int) bool {
+	for y := 0; y < 6; y++ {
+		for x := x0; x < x1; x++ {
+			if fb.GetPixel(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// columnTop returns the topmost lit row in column x, or -1.
+func columnTop(fb *eziog500.FrameBuffer, x int) int {
+	for y := 0; y < eziog500.Height; y++ {
+		if fb.GetPixel(x, y) {
+			return y
+		}
+	}
+	return -1
+}
+
+func TestDrawSparklineRange_Heights(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+
+	// 0, 50, 100 on a 0-100 scale in an 11px tall box
+	DrawSparklineRange(fb, 0, 0, 21, 11, []float64{0, 50, 100}, 0, 100)
+
+	tests := []struct{ x, y int }{{0, 10}, {10, 5}, {20, 0}}
+	for _, tt := range tests {
+		if !fb.GetPixel(tt.x, tt.y) {
+			t.Errorf("Expected sample pixel at (%d,%d)", tt.x, tt.y)
+		}
+		if top := columnTop(fb, tt.x); top != tt.y {
+			t.Errorf("Column %d: expected top at %d, got %d", tt.x, tt.y, top)
+		}
+	}
+}
+
+func TestDrawSparkline_AutoScale(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+
+	// Auto-scaled: min maps to the bottom, max to the top
+	DrawSparkline(fb, 10, 20, 11, 9, []float64{5, 7})
+	if !fb.GetPixel(10, 28) || !fb.GetPixel(20, 20) {
+		t.Error("Auto-scaled sparkline should span the full box height")
+	}
+}
+
+func TestDrawSparkline_ShortData(t *testing.T) {
+	fb := eziog500.NewFrameBuffer()
+
+	DrawSparkline(fb, 0, 0, 20, 10, nil) // Should not panic
+	if fb.CountSetPixels() != 0 {
+		t.Error("Empty data should draw nothing")
+	}
+
+	DrawSparkline(fb, 0, 0, 20, 10, []float64{42})
+	if fb.CountSetPixels() != 20 || !fb.GetPixel(0, 9) {
+		t.Error("Single sample should draw a flat line along the bottom")
+	}
+
+	// More samples than pixels keeps the newest
+	fb.Clear()
+	data := make([]float64, 50)
+	data[49] = 1
+	DrawSparklineRange(fb, 0, 0, 10, 10, data, 0, 1)
+	if !fb.GetPixel(9, 0) {
+		t.Error("Newest sample should be drawn at the right edge")
+	}
+}
+
+func TestSparkline_PushKeepsNewest(t *testing.T) {
+	s := NewSparkline(4, 20, 10)
+	for i := 1; i <= 6; i++ {
+		s.Push(float64(i))
+	}
+
+	got := s.Values()
+	want := []float64{3, 4, 5, 6}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d samples, got %v", len(want), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Values() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func TestSparkline_RenderWithinBounds(t *testing.T) {
+	s := NewSparkline(30, 20, 10)
+	for i := 0; i < 45; i++ {
+		s.Push(float64(i % 7))
+	}
+
+	fb := eziog500.NewFrameBuffer()
+	x, y := 40, 20
+	s.Render(fb, x, y)
+
+	set := 0
+	for py := 0; py < eziog500.Height; py++ {
+		for px := 0; px < eziog500.Width; px++ {
+			if !fb.GetPixel(px, py) {
+				continue
+			}
+			set++
+			if px < x || px >= x+s.Width() || py < y || py >= y+s.Height() {
+				t.Fatalf("Pixel (%d, %d) outside the sparkline bounds", px, py)
+			}
+		}
+	}
+	if set == 0 {
+		t.Error("Expected the sparkline to draw something")
+	}
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+)
+
+// icons maps lower-case icon names to icons; names keeps the registered
+// spelling in registration order.
+var icons = struct {
+	sync.RWMutex
+	names []string
+	byKey map[string]Icon
+}{byKey: make(map[string]Icon)}
+
+func init() {
+	RegisterIcon("arrow-up", IconArrowUp)
+	RegisterIcon("arrow-down", IconArrowDown)
+	RegisterIcon("arrow-left", IconArrowLeft)
+	RegisterIcon("arrow-right", IconArrowRight)
+	RegisterIcon("check", IconCheck)
+	RegisterIcon("x", IconX)
+}
+
+// RegisterIcon makes an icon available to GetIcon, so configuration can
+// refer to it by name. It panics if the name is already registered.
+func RegisterIcon(name string, icon Icon) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	icons.Lock()
+	defer icons.Unlock()
+	if _, dup := icons.byKey[key]; dup {
+		panic("ui: RegisterIcon called twice for " + name)
+	}
+	icons.byKey[key] = icon
+	icons.names = append(icons.names, name)
+}
+
+// GetIcon returns the icon registered under name, matched
+// case-insensitively.
+func GetIcon(name string) (Icon, bool) {
+	icons.RLock()
+	defer icons.RUnlock()
+	icon, ok := icons.byKey[strings.ToLower(strings.TrimSpace(name))]
+	return icon, ok
+}
+
+// IconNames returns the names of all registered icons, built-in ones first,
+// in registration order.
+func IconNames() []string {
+	icons.RLock()
+	defer icons.RUnlock()
+	return append([]string(nil), icons.names...)
+}
@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// unregisterIcon removes an icon a test registered, so the test can run
+// again in the same process.
+func unregisterIcon(name string) {
+	icons.Lock()
+	defer icons.Unlock()
+	delete(icons.byKey, strings.ToLower(strings.TrimSpace(name)))
+	icons.names = slices.DeleteFunc(icons.names, func(n string) bool { return n == name })
+}
+
+func TestRegisterIcon(t *testing.T) {
+	if icon, ok := GetIcon("Check"); !ok || icon != IconCheck {
+		t.Error("Expected the built-in check icon by name")
+	}
+
+	lock := Icon{Data: [8]byte{0x00, 0x78, 0x7E, 0x79, 0x79, 0x7E, 0x78, 0x00}}
+	RegisterIcon("Test Lock", lock)
+	t.Cleanup(func() { unregisterIcon("Test Lock") })
+	if icon, ok := GetIcon("test lock"); !ok || icon != lock {
+		t.Errorf("GetIcon returned %v, %v; want the registered icon", icon, ok)
+	}
+	if names := IconNames(); names[len(names)-1] != "Test Lock" {
+		t.Errorf("IconNames() = %v, want Test Lock last", names)
+	}
+	if _, ok := GetIcon("missing"); ok {
+		t.Error("Expected no icon for an unknown name")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected registering a name twice to panic")
+		}
+	}()
+	RegisterIcon("TEST LOCK", lock)
+}
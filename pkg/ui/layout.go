@@ -0,0 +1,115 @@
+package ui
+
+import "github.com/sagostin/ezio-g500/pkg/eziog500"
+
+// Align positions children across a container's layout direction
+// (horizontally in a VBox, vertically in an HBox).
+type Align int
+
+const (
+	AlignStart  Align = iota // Left in a VBox, top in an HBox
+	AlignCenter              // Centered
+	AlignEnd                 // Right in a VBox, bottom in an HBox
+)
+
+// offset returns where a child of size child starts within size total.
+func (a Align) offset(total, child int) int {
+	switch a {
+	case AlignCenter:
+		return (total - child) / 2
+	case AlignEnd:
+		return total - child
+	default:
+		return 0
+	}
+}
+
+// VBox stacks widgets top to bottom with Spacing pixels between them.
+type VBox struct {
+	Children []Widget
+	Spacing  int
+	Align    Align
+}
+
+// NewVBox creates a vertical container.
+func NewVBox(spacing int, children ...Widget) *VBox {
+	return &VBox{Children: children, Spacing: spacing}
+}
+
+// Add appends a child widget.
+func (b *VBox) Add(w Widget) { b.Children = append(b.Children, w) }
+
+// Render draws each child below the previous one.
+func (b *VBox) Render(fb *eziog500.FrameBuffer, x, y int) {
+	width := b.Width()
+	for _, c := range b.Children {
+		c.Render(fb, x+b.Align.offset(width, c.Width()), y)
+		y += c.Height() + b.Spacing
+	}
+}
+
+func (b *VBox) Width() int {
+	w := 0
+	for _, c := range b.Children {
+		if c.Width() > w {
+			w = c.Width()
+		}
+	}
+	return w
+}
+
+func (b *VBox) Height() int {
+	h := 0
+	for i, c := range b.Children {
+		if i > 0 {
+			h += b.Spacing
+		}
+		h += c.Height()
+	}
+	return h
+}
+
+// HBox places widgets left to right with Spacing pixels between them.
+type HBox struct {
+	Children []Widget
+	Spacing  int
+	Align    Align
+}
+
+// NewHBox creates a horizontal container.
+func NewHBox(spacing int, children ...Widget) *HBox {
+	return &HBox{Children: children, Spacing: spacing}
+}
+
+// Add appends a child widget.
+func (b *HBox) Add(w Widget) { b.Children = append(b.Children, w) }
+
+// Render draws each child to the right of the previous one.
+func (b *HBox) Render(fb *eziog500.FrameBuffer, x, y int) {
+	height := b.Height()
+	for _, c := range b.Children {
+		c.Render(fb, x, y+b.Align.offset(height, c.Height()))
+		x += c.Width() + b.Spacing
+	}
+}
+
+func (b *HBox) Width() int {
+	w := 0
+	for i, c := range b.Children {
+		if i > 0 {
+			w += b.Spacing
+		}
+		w += c.Width()
+	}
+	return w
+}
+
+func (b *HBox) Height() int {
+	h := 0
+	for _, c := range b.Children {
+		if c.Height() > h {
+			h = c.Height()
+		}
+	}
+	return h
+}
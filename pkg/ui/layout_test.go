@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// boxWidget records where it was rendered.
+type boxWidget struct {
+	w, h       int
+	gotX, gotY int
+}
+
+func (b *boxWidget) Render(fb *eziog500.FrameBuffer, x, y int) {
+	b.gotX, b.gotY = x, y
+}
+func (b *boxWidget) Width() int  { return b.w }
+func (b *boxWidget) Height() int { return b.h }
+
+func TestVBox_Layout(t *testing.T) {
+	a := &boxWidget{w: 40, h: 8}
+	b := &boxWidget{w: 20, h: 12}
+	box := NewVBox(3, a, b)
+
+	if box.Width() != 40 || box.Height() != 8+3+12 {
+		t.Errorf("Expected 40x23, got %dx%d", box.Width(), box.Height())
+	}
+
+	box.Render(eziog500.NewFrameBuffer(), 5, 10)
+	if a.gotX != 5 || a.gotY != 10 {
+		t.Errorf("First child at (%d, %d), want (5, 10)", a.gotX, a.gotY)
+	}
+	if b.gotX != 5 || b.gotY != 21 {
+		t.Errorf("Second child at (%d, %d), want (5, 21)", b.gotX, b.gotY)
+	}
+
+	box.Align = AlignCenter
+	box.Render(eziog500.NewFrameBuffer(), 5, 10)
+	if b.gotX != 15 {
+		t.Errorf("Centered second child at x=%d, want 15", b.gotX)
+	}
+}
+
+func TestHBox_Layout(t *testing.T) {
+	a := &boxWidget{w: 10, h: 8}
+	b := &boxWidget{w: 20, h: 16}
+	box := NewHBox(2, a, b)
+	box.Align = AlignEnd
+
+	if box.Width() != 32 || box.Height() != 16 {
+		t.Errorf("Expected 32x16, got %dx%d", box.Width(), box.Height())
+	}
+
+	box.Render(eziog500.NewFrameBuffer(), 0, 0)
+	if a.gotX != 0 || a.gotY != 8 {
+		t.Errorf("Bottom-aligned first child at (%d, %d), want (0, 8)", a.gotX, a.gotY)
+	}
+	if b.gotX != 12 || b.gotY != 0 {
+		t.Errorf("Second child at (%d, %d), want (12, 0)", b.gotX, b.gotY)
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+// ScrollGap is the blank space, in characters, between the end of scrolling
+// text and its repeat.
+const ScrollGap = 4
+
+// ScrollWindow returns the width-character window of text starting pos
+// characters in, wrapping around seamlessly with a ScrollGap-space gap so
+// text can scroll forever. Text that already fits is returned unchanged,
+// and a width of 0 or less gives "". Positions are counted in runes, not
+// bytes.
+func ScrollWindow(text string, width, pos int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+
+	padded := append(runes, make([]rune, ScrollGap)...)
+	for i := len(runes); i < len(padded); i++ {
+		padded[i] = ' '
+	}
+	pos %= len(padded)
+	if pos < 0 {
+		pos += len(padded)
+	}
+
+	result := make([]rune, width)
+	for i := range result {
+		result[i] = padded[(pos+i)%len(padded)]
+	}
+	return string(result)
+}
@@ -0,0 +1,28 @@
+package ui
+
+import "testing"
+
+func TestScrollWindow(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		pos   int
+		want  string
+	}{
+		{"short", 10, 3, "short"},
+		{"abcdefgh", 4, 0, "abcd"},
+		{"abcdefgh", 4, 6, "gh  "},
+		{"abcdefgh", 4, 10, "  ab"},
+		{"abcdefgh", 4, 12, "abcd"}, // One full cycle: 8 runes plus the gap
+		{"abcdefgh", 4, -1, " abc"},
+		{"héllo wörld", 5, 7, "örld "},
+		{"abcdefgh", 0, 3, ""},
+		{"abcdefgh", -2, 3, ""},
+		{"", -1, 0, ""},
+	}
+	for _, tt := range tests {
+		if got := ScrollWindow(tt.text, tt.width, tt.pos); got != tt.want {
+			t.Errorf("ScrollWindow(%q, %d, %d) = %q, want %q", tt.text, tt.width, tt.pos, got, tt.want)
+		}
+	}
+}
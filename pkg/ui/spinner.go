@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"math"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+// SpinnerStyle selects how a Spinner is drawn.
+type SpinnerStyle int
+
+const (
+	SpinnerLine SpinnerStyle = iota // A line rotating about the center
+	SpinnerDots                     // A ring of dots with one gap chasing around
+)
+
+// spinnerSteps is the number of distinct positions in one rotation.
+const spinnerSteps = 8
+
+// Spinner is a busy indicator. Increment Frame on each tick to animate it.
+type Spinner struct {
+	Frame int
+	Size  int // Width and height in pixels (8 or 16 work best)
+	Style SpinnerStyle
+}
+
+// NewSpinner creates a spinner of the given size and style.
+func NewSpinner(size int, style SpinnerStyle) *Spinner {
+	return &Spinner{Size: size, Style: style}
+}
+
+// Render draws the current frame.
+func (s *Spinner) Render(fb *eziog500.FrameBuffer, x, y int) {
+	step := s.Frame % spinnerSteps
+	if step < 0 {
+		step += spinnerSteps
+	}
+	r := (s.Size - 1) / 2
+	cx, cy := x+r, y+r
+
+	switch s.Style {
+	case SpinnerDots:
+		dotR := r - 1
+		if s.Size >= 16 {
+			dotR = r - 2
+		}
+		for i := 0; i < spinnerSteps; i++ {
+			if i == step {
+				continue // The gap
+			}
+			px, py := polar(cx, cy, dotR, float64(i)*360/spinnerSteps-90)
+			if s.Size >= 16 {
+				fb.FillCircle(px, py, 1, true)
+			} else {
+				fb.SetPixel(px, py, true)
+			}
+		}
+	default:
+		// A full line through the center repeats every half turn, so use
+		// half-steps to keep 8 distinct frames
+		a := float64(step) * 180 / spinnerSteps
+		rad := a * math.Pi / 180
+		dx := int(math.Round(float64(r) * math.Cos(rad)))
+		dy := int(math.Round(float64(r) * math.Sin(rad)))
+		fb.DrawLine(cx-dx, cy-dy, cx+dx, cy+dy, true)
+	}
+}
+
+func (s *Spinner) Width() int  { return s.Size }
+func (s *Spinner) Height() int { return s.Size }
@@ -0,0 +1,39 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestSpinner_FramesDiffer(t *testing.T) {
+	for _, style := range []SpinnerStyle{SpinnerLine, SpinnerDots} {
+		for _, size := range []int{8, 16} {
+			s := NewSpinner(size, style)
+			var prev *eziog500.FrameBuffer
+			for frame := 0; frame < spinnerSteps; frame++ {
+				s.Frame = frame
+				fb := eziog500.NewFrameBuffer()
+				s.Render(fb, 0, 0)
+				if fb.CountSetPixels() == 0 {
+					t.Errorf("style %d size %d frame %d: nothing drawn", style, size, frame)
+				}
+				if prev != nil && sameFrame(prev, fb) {
+					t.Errorf("style %d size %d: frame %d matches the previous frame", style, size, frame)
+				}
+				prev = fb
+			}
+		}
+	}
+}
+
+func sameFrame(a, b *eziog500.FrameBuffer) bool {
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			if a.GetPixel(x, y) != b.GetPixel(x, y) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// TabBar is a row of small-font labels with the active one inverted,
+// underlined across its full width. If the tabs don't fit, the row scrolls
+// to keep the active tab in view.
+type TabBar struct {
+	Labels []string
+	Active int
+	width  int
+}
+
+// NewTabBar creates a tab bar of the given width.
+func NewTabBar(width int, labels ...string) *TabBar {
+	return &TabBar{Labels: labels, width: width}
+}
+
+func (t *TabBar) Width() int { return t.width }
+
+// Height is the small font plus a row of padding and the underline.
+func (t *TabBar) Height() int { return font.SmallFont.Height() + 2 }
+
+// tabs returns each tab's x offset and width, before scrolling.
+func (t *TabBar) tabs() (xs, widths []int) {
+	x := 0
+	for _, label := range t.Labels {
+		w := font.MeasureText(font.SmallFont, label) + 3
+		xs = append(xs, x)
+		widths = append(widths, w)
+		x += w + 1
+	}
+	return xs, widths
+}
+
+// Render clears the bar's area and draws the tabs.
+func (t *TabBar) Render(fb *eziog500.FrameBuffer, x, y int) {
+	sf := font.SmallFont
+	h := t.Height()
+
+	fb.FillRect(x, y, t.width, h, false)
+	fb.DrawHLine(x, x+t.width-1, y+h-1, true)
+
+	xs, widths := t.tabs()
+	scroll := 0
+	if t.Active >= 0 && t.Active < len(xs) {
+		scroll = max(0, xs[t.Active]+widths[t.Active]-t.width)
+	}
+
+	// Keep partly scrolled-out tabs inside the bar
+	cx, cy, cw, ch, clipped := fb.Clip()
+	fb.SetClip(x, y, t.width, h)
+	defer func() {
+		if clipped {
+			fb.SetClip(cx, cy, cw, ch)
+		} else {
+			fb.ClearClip()
+		}
+	}()
+
+	for i, label := range t.Labels {
+		tx := x + xs[i] - scroll
+		if i == t.Active {
+			fb.FillRect(tx, y, widths[i], h-1, true)
+			font.RenderTextInverted(fb, sf, tx+2, y+1, label)
+		} else {
+			font.RenderText(fb, sf, tx+2, y+1, label)
+		}
+	}
+}
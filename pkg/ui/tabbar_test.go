@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestTabBar_ActiveInverted(t *testing.T) {
+	bar := NewTabBar(eziog500.Width, "SYS", "NET", "FW")
+	bar.Active = 1
+	fb := eziog500.NewFrameBuffer()
+	bar.Render(fb, 0, 0)
+
+	xs, _ := bar.tabs()
+	for i, x := range xs {
+		// The left padding column is lit only behind the active tab
+		if got := fb.GetPixel(x, 2); got != (i == bar.Active) {
+			t.Errorf("Tab %d: padding lit = %v, want %v", i, got, i == bar.Active)
+		}
+	}
+
+	// The underline spans the bar
+	if !fb.GetPixel(0, bar.Height()-1) || !fb.GetPixel(eziog500.Width-1, bar.Height()-1) {
+		t.Error("Expected an underline across the bar")
+	}
+}
+
+func TestTabBar_ScrollsToActive(t *testing.T) {
+	bar := NewTabBar(40, "SYSTEM", "NETWORK", "FIREWALL")
+	bar.Active = 2
+	fb := eziog500.NewFrameBuffer()
+	bar.Render(fb, 0, 0)
+
+	// The active tab is scrolled to end at the bar's right edge
+	if !fb.GetPixel(39, 0) {
+		t.Error("Expected the active tab at the right edge")
+	}
+	for x := 40; x < eziog500.Width; x++ {
+		if fb.GetPixel(x, 0) {
+			t.Fatalf("Pixel drawn outside the bar at x=%d", x)
+		}
+	}
+}
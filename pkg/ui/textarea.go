@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+// TextArea shows word-wrapped lines of text, such as the tail of a log,
+// in a fixed box that scrolls a line at a time.
+type TextArea struct {
+	Font   font.Font // nil uses font.BuiltinFont; set before SetLines
+	lines  []string  // Wrapped to the area's width
+	offset int       // First visible line
+	width  int
+	height int
+}
+
+// NewTextArea creates an empty text area of the given size.
+func NewTextArea(width, height int) *TextArea {
+	return &TextArea{width: width, height: height}
+}
+
+func (t *TextArea) Width() int  { return t.width }
+func (t *TextArea) Height() int { return t.height }
+
+// font returns the text area's font.
+func (t *TextArea) font() font.Font {
+	if t.Font == nil {
+		return font.BuiltinFont
+	}
+	return t.Font
+}
+
+// SetText replaces the text, splitting it into lines at newlines.
+func (t *TextArea) SetText(text string) {
+	t.SetLines(strings.Split(strings.TrimRight(text, "\n"), "\n"))
+}
+
+// SetLines replaces the text with lines, wrapping any that are too wide.
+// Blank lines are kept.
+func (t *TextArea) SetLines(lines []string) {
+	t.lines = nil
+	for _, line := range lines {
+		wrapped := font.WrapText(t.font(), line, t.width)
+		if len(wrapped) == 0 {
+			wrapped = []string{""}
+		}
+		t.lines = append(t.lines, wrapped...)
+	}
+	t.offset = min(t.offset, t.maxOffset())
+}
+
+// Lines returns the wrapped lines.
+func (t *TextArea) Lines() []string {
+	return t.lines
+}
+
+// VisibleLines returns how many lines fit in the area.
+func (t *TextArea) VisibleLines() int {
+	return t.height / t.font().LineHeight()
+}
+
+// Offset returns the index of the first visible line.
+func (t *TextArea) Offset() int {
+	return t.offset
+}
+
+// maxOffset is the offset that shows the last line at the bottom.
+func (t *TextArea) maxOffset() int {
+	return max(0, len(t.lines)-t.VisibleLines())
+}
+
+// ScrollUp moves the view up a line, reporting whether it moved.
+func (t *TextArea) ScrollUp() bool {
+	if t.offset == 0 {
+		return false
+	}
+	t.offset--
+	return true
+}
+
+// ScrollDown moves the view down a line, reporting whether it moved. It
+// stops once the last line is at the bottom.
+func (t *TextArea) ScrollDown() bool {
+	if t.offset >= t.maxOffset() {
+		return false
+	}
+	t.offset++
+	return true
+}
+
+// ScrollToEnd shows the last lines, as a log viewer usually wants.
+func (t *TextArea) ScrollToEnd() {
+	t.offset = t.maxOffset()
+}
+
+// Render draws the visible lines inside the area's bounds.
+func (t *TextArea) Render(fb *eziog500.FrameBuffer, x, y int) {
+	f := t.font()
+	end := min(len(t.lines), t.offset+t.VisibleLines())
+	for i := t.offset; i < end; i++ {
+		font.RenderTextClipped(fb, f, x, y+(i-t.offset)*f.LineHeight(), t.width, t.lines[i])
+	}
+}
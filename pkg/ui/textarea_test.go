@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+	"github.com/sagostin/ezio-g500/pkg/font"
+)
+
+func TestTextArea_ScrollClamps(t *testing.T) {
+	ta := NewTextArea(eziog500.Width, 3*font.BuiltinFont.Height())
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	ta.SetLines(lines)
+
+	if ta.ScrollUp() {
+		t.Error("ScrollUp at the top should not move")
+	}
+	for i := 0; i < 20; i++ {
+		ta.ScrollDown()
+	}
+	if ta.Offset() != 7 {
+		t.Errorf("Expected scrolling to stop with the last line at the bottom (7), got %d", ta.Offset())
+	}
+	if !ta.ScrollUp() || ta.Offset() != 6 {
+		t.Errorf("Expected ScrollUp to move to 6, got %d", ta.Offset())
+	}
+
+	// Shorter text pulls the offset back in range
+	ta.SetLines(lines[:4])
+	if ta.Offset() != 1 {
+		t.Errorf("Expected offset clamped to 1, got %d", ta.Offset())
+	}
+}
+
+func TestTextArea_RendersVisibleLines(t *testing.T) {
+	f := font.BuiltinFont
+	ta := NewTextArea(60, 2*f.Height())
+	ta.SetText("first line\nsecond line\nthird line\n")
+	if got := len(ta.Lines()); got != 3 {
+		t.Fatalf("Expected 3 lines, got %d: %q", got, ta.Lines())
+	}
+
+	fb := eziog500.NewFrameBuffer()
+	ta.Render(fb, 0, 10)
+	for y := 0; y < eziog500.Height; y++ {
+		for x := 0; x < eziog500.Width; x++ {
+			inside := x < 60 && y >= 10 && y < 10+2*f.Height()
+			if fb.GetPixel(x, y) && !inside {
+				t.Fatalf("Pixel (%d, %d) drawn outside the text area", x, y)
+			}
+		}
+	}
+
+	// Scrolled down, the first visible line is the second one
+	ta.ScrollDown()
+	got := eziog500.NewFrameBuffer()
+	ta.Render(got, 0, 0)
+	want := eziog500.NewFrameBuffer()
+	font.RenderText(want, f, 0, 0, "second line")
+	font.RenderText(want, f, 0, f.Height(), "third line")
+	if got.ToDeviceFormat() != want.ToDeviceFormat() {
+		t.Error("Expected lines 2-3 after scrolling down")
+	}
+}
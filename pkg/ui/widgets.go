@@ -61,7 +61,7 @@ func (b *Button) Render(fb *eziog500.FrameBuffer, x, y int) {
 	} else {
 		// Outline button
 		fb.DrawRect(x, y, b.width, b.height, true)
-		font.RenderText(fb, f, x+4, y+2, b.Label)
+		font.RenderTextAligned(fb, f, x, y, b.width, b.height, b.Label, font.AlignCenter, font.AlignCenter)
 	}
 
 	// Disabled style (strikethrough)
@@ -74,12 +74,23 @@ func (b *Button) Render(fb *eziog500.FrameBuffer, x, y int) {
 func (b *Button) Width() int  { return b.width }
 func (b *Button) Height() int { return b.height }
 
-// ProgressIndicator shows a horizontal progress bar.
+// Orientation is the direction a bar fills in.
+type Orientation int
+
+const (
+	Horizontal Orientation = iota // Fills left to right
+	Vertical                      // Fills bottom to top
+)
+
+// ProgressIndicator shows a progress bar, solid or split into segments
+// like a row of LEDs.
 type ProgressIndicator struct {
-	Value   float64 // 0.0 to 100.0
-	Width   int
-	Height  int
-	Rounded bool
+	Value       float64 // 0.0 to 100.0
+	Width       int
+	Height      int
+	Rounded     bool
+	Orientation Orientation
+	Segments    int // Discrete blocks 1 pixel apart; 0 draws a solid fill
 }
 
 // NewProgressIndicator creates a new progress indicator.
@@ -90,19 +101,62 @@ func NewProgressIndicator(width, height int) *ProgressIndicator {
 	}
 }
 
+// length returns the inner length of the bar along its fill direction.
+func (p *ProgressIndicator) length() int {
+	if p.Orientation == Vertical {
+		return p.Height - 4
+	}
+	return p.Width - 4
+}
+
+// value returns Value clamped to 0-100.
+func (p *ProgressIndicator) value() float64 {
+	return max(0, min(p.Value, 100))
+}
+
+// FillLength returns how many pixels of a solid bar are filled along its
+// fill direction.
+func (p *ProgressIndicator) FillLength() int {
+	return max(0, int(float64(p.length())*p.value()/100.0))
+}
+
+// LitSegments returns how many segments are lit, rounding to the nearest.
+func (p *ProgressIndicator) LitSegments() int {
+	return int(float64(p.Segments)*p.value()/100.0 + 0.5)
+}
+
 // Render draws the progress indicator.
 func (p *ProgressIndicator) Render(fb *eziog500.FrameBuffer, x, y int) {
 	// Draw border
-	if p.Rounded && p.Height > 4 {
+	if p.Rounded && p.Width > 4 && p.Height > 4 {
 		fb.DrawRoundedRect(x, y, p.Width, p.Height, 2, true)
 	} else {
 		fb.DrawRect(x, y, p.Width, p.Height, true)
 	}
 
-	// Draw fill
-	fillWidth := int(float64(p.Width-4) * p.Value / 100.0)
-	if fillWidth > 0 {
-		fb.FillRect(x+2, y+2, fillWidth, p.Height-4, true)
+	if p.Segments <= 0 {
+		p.fill(fb, x, y, 0, p.FillLength())
+		return
+	}
+
+	// Spread the leftover pixels over the segments so the gaps stay even
+	n, span := p.Segments, p.length()+1
+	for i := 0; i < p.LitSegments(); i++ {
+		start, end := i*span/n, (i+1)*span/n-1
+		p.fill(fb, x, y, start, end-start)
+	}
+}
+
+// fill fills length pixels of the bar's inside from offset along the fill
+// direction, measured from the left or the bottom.
+func (p *ProgressIndicator) fill(fb *eziog500.FrameBuffer, x, y, offset, length int) {
+	if length <= 0 {
+		return
+	}
+	if p.Orientation == Vertical {
+		fb.FillRect(x+2, y+p.Height-2-offset-length, p.Width-4, length, true)
+	} else {
+		fb.FillRect(x+2+offset, y+2, length, p.Height-4, true)
 	}
 }
 
@@ -110,6 +164,7 @@ func (p *ProgressIndicator) Render(fb *eziog500.FrameBuffer, x, y int) {
 type Label struct {
 	Text     string
 	Inverted bool
+	Font     font.Font // nil uses font.BuiltinFont
 }
 
 // NewLabel creates a new label.
@@ -117,22 +172,29 @@ func NewLabel(text string) *Label {
 	return &Label{Text: text}
 }
 
+// font returns the label's font.
+func (l *Label) font() font.Font {
+	if l.Font == nil {
+		return font.BuiltinFont
+	}
+	return l.Font
+}
+
 // Render draws the label.
 func (l *Label) Render(fb *eziog500.FrameBuffer, x, y int) {
-	f := font.BuiltinFont
 	if l.Inverted {
-		font.RenderTextInverted(fb, f, x, y, l.Text)
+		font.RenderTextInverted(fb, l.font(), x, y, l.Text)
 	} else {
-		font.RenderText(fb, f, x, y, l.Text)
+		font.RenderText(fb, l.font(), x, y, l.Text)
 	}
 }
 
 func (l *Label) Width() int {
-	return font.MeasureText(font.BuiltinFont, l.Text)
+	return font.MeasureText(l.font(), l.Text)
 }
 
 func (l *Label) Height() int {
-	return font.BuiltinFont.Height()
+	return l.font().Height()
 }
 
 // Checkbox represents a toggle checkbox.
@@ -159,19 +221,27 @@ func (c *Checkbox) Toggle() {
 func (c *Checkbox) Render(fb *eziog500.FrameBuffer, x, y int) {
 	f := font.BuiltinFont
 
-	// Draw checkbox box
-	fb.DrawRect(x, y, c.size, c.size, true)
+	c.RenderBox(fb, x, y, true)
+
+	// Draw label
+	font.RenderText(fb, f, x+c.size+4, y, c.Label)
+}
+
+// RenderBox draws only the box and check mark, using on=false to draw onto
+// a filled (inverted) background.
+func (c *Checkbox) RenderBox(fb *eziog500.FrameBuffer, x, y int, on bool) {
+	fb.DrawRect(x, y, c.size, c.size, on)
 
 	// Draw check mark if checked
 	if c.Checked {
-		fb.DrawLine(x+2, y+4, x+3, y+6, true)
-		fb.DrawLine(x+3, y+6, x+6, y+2, true)
+		fb.DrawLine(x+2, y+4, x+3, y+6, on)
+		fb.DrawLine(x+3, y+6, x+6, y+2, on)
 	}
-
-	// Draw label
-	font.RenderText(fb, f, x+c.size+4, y, c.Label)
 }
 
+// BoxSize returns the width and height of the box in pixels.
+func (c *Checkbox) BoxSize() int { return c.size }
+
 func (c *Checkbox) Width() int {
 	return c.size + 4 + font.MeasureText(font.BuiltinFont, c.Label)
 }
@@ -215,7 +285,7 @@ func (i *Icon) Render(fb *eziog500.FrameBuffer, x, y int) {
 func (i *Icon) Width() int  { return 8 }
 func (i *Icon) Height() int { return 8 }
 
-// Predefined icons
+// Predefined icons, also registered by name (see GetIcon)
 var (
 	IconArrowUp    = Icon{Data: [8]byte{0x00, 0x04, 0x02, 0xFF, 0x02, 0x04, 0x00, 0x00}}
 	IconArrowDown  = Icon{Data: [8]byte{0x00, 0x20, 0x40, 0xFF, 0x40, 0x20, 0x00, 0x00}}
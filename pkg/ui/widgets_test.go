@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/sagostin/ezio-g500/pkg/eziog500"
+)
+
+func TestProgressIndicator_Vertical(t *testing.T) {
+	for _, tt := range []struct {
+		value float64
+		want  int
+	}{
+		{0, 0},
+		{50, 10},
+		{100, 20},
+		{150, 20},
+	} {
+		p := NewProgressIndicator(8, 24)
+		p.Orientation = Vertical
+		p.Value = tt.value
+		fb := eziog500.NewFrameBuffer()
+		p.Render(fb, 0, 0)
+
+		// Count the fill up the middle column, inside the border and gap
+		got := 0
+		for y := 2; y < p.Height-2; y++ {
+			if fb.GetPixel(p.Width/2, y) {
+				got++
+			}
+		}
+		if got != tt.want {
+			t.Errorf("%.0f%%: fill is %d pixels high, want %d", tt.value, got, tt.want)
+		}
+		if got != p.FillLength() {
+			t.Errorf("%.0f%%: FillLength() = %d, drawn %d", tt.value, p.FillLength(), got)
+		}
+		// The fill grows from the bottom
+		if got > 0 && !fb.GetPixel(p.Width/2, p.Height-3) {
+			t.Errorf("%.0f%%: expected the bottom row to be filled", tt.value)
+		}
+	}
+}
+
+func TestProgressIndicator_Segments(t *testing.T) {
+	for _, orientation := range []Orientation{Horizontal, Vertical} {
+		for _, tt := range []struct {
+			value float64
+			want  int
+		}{
+			{0, 0},
+			{24, 2},
+			{50, 5},
+			{100, 10},
+		} {
+			p := NewProgressIndicator(64, 64)
+			p.Orientation = orientation
+			p.Segments = 10
+			p.Value = tt.value
+			fb := eziog500.NewFrameBuffer()
+			p.Render(fb, 0, 0)
+
+			if p.LitSegments() != tt.want {
+				t.Errorf("%.0f%%: LitSegments() = %d, want %d", tt.value, p.LitSegments(), tt.want)
+			}
+
+			// Count the runs of set pixels along the middle of the bar
+			runs, on := 0, false
+			for i := 2; i < 62; i++ {
+				px := fb.GetPixel(i, 32)
+				if orientation == Vertical {
+					px = fb.GetPixel(32, i)
+				}
+				if px && !on {
+					runs++
+				}
+				on = px
+			}
+			if runs != tt.want {
+				t.Errorf("orientation %d, %.0f%%: %d segments lit, want %d", orientation, tt.value, runs, tt.want)
+			}
+		}
+	}
+}